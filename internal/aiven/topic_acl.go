@@ -0,0 +1,81 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nais/cli/internal/k8s"
+	nais_kafka "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetTopicACLs returns the ACL of a topic, read from the Topic resource in the cluster.
+func GetTopicACLs(ctx context.Context, namespace, topicName, environment string) ([]nais_kafka.TopicACL, error) {
+	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+
+	var topic nais_kafka.Topic
+	if err := client.Get(ctx, ctrl.ObjectKey{Name: topicName, Namespace: namespace}, &topic); err != nil {
+		return nil, fmt.Errorf("get topic: %w", err)
+	}
+	return topic.Spec.ACL, nil
+}
+
+// RevokeAccessToTopic removes the ACL entries of an application from a topic, and returns the number of entries removed.
+// Only the entry with the given access is removed, or all entries of the application if access is empty.
+func RevokeAccessToTopic(ctx context.Context, namespace, topicName, environment, team, application, access string) (int, error) {
+	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+
+	revoked := 0
+	err := updateTopicACL(ctx, client, namespace, topicName, func(existing []nais_kafka.TopicACL) []nais_kafka.TopicACL {
+		updated := slices.DeleteFunc(slices.Clone(existing), func(e nais_kafka.TopicACL) bool {
+			return e.Team == team && e.Application == application && (access == "" || e.Access == access)
+		})
+		revoked = len(existing) - len(updated)
+		return updated
+	})
+	return revoked, err
+}
+
+// ChangeTopicACLs removes and adds entries to the ACL of a topic. The entries that are kept stay in their order, followed
+// by the added entries.
+func ChangeTopicACLs(ctx context.Context, namespace, topicName, environment string, add, remove []nais_kafka.TopicACL) error {
+	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+
+	return updateTopicACL(ctx, client, namespace, topicName, func(existing []nais_kafka.TopicACL) []nais_kafka.TopicACL {
+		return changeACLs(existing, add, remove)
+	})
+}
+
+func changeACLs(existing, add, remove []nais_kafka.TopicACL) []nais_kafka.TopicACL {
+	updated := slices.DeleteFunc(slices.Clone(existing), func(e nais_kafka.TopicACL) bool { return slices.Contains(remove, e) })
+	for _, a := range add {
+		if !slices.Contains(updated, a) {
+			updated = append(updated, a)
+		}
+	}
+	return updated
+}
+
+// updateTopicACL updates the ACL of a topic with the result of update. The topic is not updated if the ACL is unchanged.
+func updateTopicACL(ctx context.Context, client ctrl.Client, namespace, topicName string, update func([]nais_kafka.TopicACL) []nais_kafka.TopicACL) error {
+	if err := validateNamespace(ctx, client, namespace); err != nil {
+		return err
+	}
+
+	var topic nais_kafka.Topic
+	if err := client.Get(ctx, ctrl.ObjectKey{Name: topicName, Namespace: namespace}, &topic); err != nil {
+		return fmt.Errorf("get topic: %w", err)
+	}
+
+	updated := update(topic.Spec.ACL)
+	if slices.Equal(updated, topic.Spec.ACL) {
+		return nil
+	}
+	topic.Spec.ACL = updated
+
+	if err := client.Update(ctx, &topic); err != nil {
+		return fmt.Errorf("update topic: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	nais_kafka "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestChangeACLs(t *testing.T) {
+	a := nais_kafka.TopicACL{Team: "team-a", Application: "app-a", Access: "read"}
+	b := nais_kafka.TopicACL{Team: "team-b", Application: "app-b", Access: "write"}
+	c := nais_kafka.TopicACL{Team: "team-c", Application: "app-c", Access: "readwrite"}
+
+	got := changeACLs([]nais_kafka.TopicACL{a, b}, []nais_kafka.TopicACL{c, a}, []nais_kafka.TopicACL{b})
+	assert.Equal(t, []nais_kafka.TopicACL{a, c}, got)
+}
+
+func TestUpdateTopicACL(t *testing.T) {
+	ctx := context.Background()
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team"}}
+	existing := nais_kafka.TopicACL{Team: "team-a", Application: "app-a", Access: "read"}
+	topic := &nais_kafka.Topic{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-topic", Namespace: "team"},
+		Spec:       nais_kafka.TopicSpec{Pool: "nav-dev", ACL: nais_kafka.TopicACLs{existing}},
+	}
+
+	client := buildWithScheme(namespace, topic).Build()
+	added := nais_kafka.TopicACL{Team: "team-b", Application: "app-b", Access: "write"}
+	err := updateTopicACL(ctx, client, "team", "my-topic", func(acls []nais_kafka.TopicACL) []nais_kafka.TopicACL {
+		return changeACLs(acls, []nais_kafka.TopicACL{added}, []nais_kafka.TopicACL{existing})
+	})
+	assert.NoError(t, err)
+
+	var updated nais_kafka.Topic
+	assert.NoError(t, client.Get(ctx, ctrl.ObjectKey{Name: "my-topic", Namespace: "team"}, &updated))
+	assert.Equal(t, nais_kafka.TopicACLs{added}, updated.Spec.ACL)
+
+	err = updateTopicACL(ctx, client, "team", "missing", func(acls []nais_kafka.TopicACL) []nais_kafka.TopicACL { return acls })
+	assert.Error(t, err)
+}
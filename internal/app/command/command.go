@@ -25,10 +25,15 @@ func App(parentFlags *flags.GlobalFlags) *naistrix.Command {
 			log(flags),
 			status(flags),
 			env(flags),
+			endpoints(flags),
 			labels(flags),
 			files(flags),
 			set(flags),
 			stop(flags),
+			portForward(flags),
+			exec(flags),
+			cp(flags),
+			runLocal(flags),
 		},
 	}
 }
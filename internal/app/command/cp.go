@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/app"
+	"github.com/nais/cli/internal/app/command/flag"
+	"github.com/nais/naistrix"
+)
+
+func cp(parentFlags *flag.App) *naistrix.Command {
+	flags := &flag.Copy{
+		App: parentFlags,
+	}
+
+	return &naistrix.Command{
+		Name:  "cp",
+		Title: "Copy files from an application instance.",
+		Description: heredoc.Doc(`
+			Copies a file or directory from the application container of an instance to the local file system. The source is given as NAME:PATH.
+
+			The first instance is used unless one is given with "--instance", or chosen interactively with "--by-pod". The container image must include tar.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "source"},
+			{Name: "destination"},
+		},
+		ValidateFunc: func(_ context.Context, args *naistrix.Arguments) error {
+			_, _, err := parseCopySource(args.Get("source"))
+			return err
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Copy a heap dump from my-app to the current directory.",
+				Command:     "my-app:/tmp/heap.hprof . --environment dev",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			name, remotePath, err := parseCopySource(args.Get("source"))
+			if err != nil {
+				return err
+			}
+
+			environment, err := resolveAppEnvironment(ctx, flags.Team, name, string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			selection := app.InstanceSelection{Instance: flags.Instance, ByPod: flags.ByPod}
+			return app.Copy(ctx, flags.Team, name, environment, selection, remotePath, args.Get("destination"), out)
+		},
+		AutoCompleteFunc: autoCompleteAppNames(parentFlags),
+	}
+}
+
+func parseCopySource(source string) (name, path string, err error) {
+	name, path, found := strings.Cut(source, ":")
+	if !found || name == "" || !strings.HasPrefix(path, "/") {
+		return "", "", fmt.Errorf("invalid source %q, must be given as NAME:/absolute/path", source)
+	}
+	return name, path, nil
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/app"
+	"github.com/nais/cli/internal/app/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+)
+
+func endpoints(parentFlags *flag.App) *naistrix.Command {
+	flags := &flag.Endpoints{
+		App:     parentFlags,
+		Timeout: 10 * time.Second,
+	}
+
+	return &naistrix.Command{
+		Name:  "endpoints",
+		Title: "List the ingresses of an application.",
+		Description: heredoc.Doc(`
+			Lists the ingresses of an application in each environment, with the ingress type and the auth integrations of the application (Entra ID, ID-porten, TokenX and Maskinporten).
+
+			With --probe, a request is sent to each ingress, and the response status, TLS certificate expiry and redirects are added to the list. The ingresses are probed concurrently.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "name"},
+		},
+		Flags: flags,
+		Examples: []naistrix.Example{
+			{
+				Description: "List the ingresses of an application in all environments.",
+				Command:     "my-app",
+			},
+			{
+				Description: "Check that the ingresses of an application in an environment respond.",
+				Command:     "my-app --environment prod --probe",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			ret, err := app.GetEndpoints(ctx, flags.Team, args.Get("name"), string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			if !flags.Probe {
+				if flags.Output == "json" {
+					return out.JSON(output.JSONWithPrettyOutput()).Render(ret)
+				}
+				return out.Table().Render(ret)
+			}
+
+			results := app.ProbeEndpoints(ctx, ret, flags.Timeout)
+			if flags.Output == "json" {
+				return out.JSON(output.JSONWithPrettyOutput()).Render(results)
+			}
+			return out.Table().Render(results)
+		},
+		AutoCompleteFunc: autoCompleteAppNames(parentFlags),
+	}
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/app"
+	"github.com/nais/cli/internal/app/command/flag"
+	"github.com/nais/naistrix"
+)
+
+func exec(parentFlags *flag.App) *naistrix.Command {
+	flags := &flag.Exec{
+		App: parentFlags,
+	}
+
+	return &naistrix.Command{
+		Name:  "exec",
+		Title: "Run a command in an application instance.",
+		Description: heredoc.Doc(`
+			Runs a command in the application container of an instance, through the Kubernetes API. The first instance is used unless one is given with "--instance", or chosen interactively with "--by-pod".
+
+			When run from a terminal the command gets an interactive TTY. Otherwise the output is streamed as is, so it can be redirected to a local file.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "name"},
+			{Name: "command", Repeatable: true},
+		},
+		ValidateFunc: func(_ context.Context, args *naistrix.Arguments) error {
+			if len(args.GetRepeatable("command")) == 0 {
+				return fmt.Errorf("a command must be specified after the application name, e.g. 'nais app exec my-app -- sh'")
+			}
+			return nil
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Start a shell in the first instance of my-app.",
+				Command:     "my-app --environment dev -- sh",
+			},
+			{
+				Description: "Print a thread dump from a JVM app in a specific instance.",
+				Command:     "my-app --environment dev --instance my-app-7d9c8b-abcde -- jcmd 1 Thread.print",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			name := args.Get("name")
+
+			environment, err := resolveAppEnvironment(ctx, flags.Team, name, string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			selection := app.InstanceSelection{Instance: flags.Instance, ByPod: flags.ByPod}
+			return app.Exec(ctx, flags.Team, name, environment, selection, args.GetRepeatable("command"), out)
+		},
+		AutoCompleteFunc: autoCompleteAppNames(parentFlags),
+	}
+}
@@ -102,6 +102,12 @@ type EnvVars struct {
 	*App
 }
 
+type Endpoints struct {
+	*App
+	Probe   bool          `name:"probe" usage:"Send a request to each ingress and show the response status, TLS certificate expiry and redirects."`
+	Timeout time.Duration `name:"timeout" usage:"Timeout for each probe, including redirects."`
+}
+
 type Labels struct {
 	*App
 }
@@ -132,3 +138,27 @@ type SetEnv struct {
 type SetImage struct {
 	*App
 }
+
+type PortForward struct {
+	*App
+	Address string `name:"address" usage:"Local |ADDRESS| to listen on."`
+}
+
+type Exec struct {
+	*App
+	Instance string `name:"instance" short:"i" usage:"Name of the |INSTANCE| to use. Defaults to the first instance."`
+	ByPod    bool   `name:"by-pod" short:"b" usage:"Interactively select the instance to use."`
+}
+
+type Copy struct {
+	*App
+	Instance string `name:"instance" short:"i" usage:"Name of the |INSTANCE| to use. Defaults to the first instance."`
+	ByPod    bool   `name:"by-pod" short:"b" usage:"Interactively select the instance to use."`
+}
+
+type RunLocal struct {
+	*App
+	Reason   string `name:"reason" usage:"Reason for accessing secret values (min 10 chars). Required, as the access is logged."`
+	Postgres bool   `name:"postgres" usage:"Start a proxy to the Postgres database of the application, and point the NAIS_DATABASE_* variables at it."`
+	Valkey   bool   `name:"valkey" usage:"Start a proxy to each Valkey instance of the application, and point the VALKEY_* variables at it."`
+}
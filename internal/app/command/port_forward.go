@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/app"
+	"github.com/nais/cli/internal/app/command/flag"
+	"github.com/nais/naistrix"
+)
+
+func portForward(parentFlags *flag.App) *naistrix.Command {
+	flags := &flag.PortForward{
+		App:     parentFlags,
+		Address: "localhost",
+	}
+
+	return &naistrix.Command{
+		Name:    "port-forward",
+		Aliases: []string{"pf"},
+		Title:   "Forward local ports to an application instance.",
+		Description: heredoc.Doc(`
+			Forwards one or more local ports to a ready instance of the application. If the instance goes away, for instance during a deploy, the command reconnects to another ready instance.
+
+			Ports are given as [LOCAL:]REMOTE. The remote port can be a number, or a name: "http" is the application port, "metrics" is the Prometheus port, and other names are looked up among the container ports of the instance.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "name"},
+			{Name: "ports", Repeatable: true},
+		},
+		ValidateFunc: func(_ context.Context, args *naistrix.Arguments) error {
+			ports := args.GetRepeatable("ports")
+			if len(ports) == 0 {
+				return fmt.Errorf("at least one port must be specified")
+			}
+			for _, port := range ports {
+				if _, _, err := app.ParsePortSpec(port); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Forward localhost:8080 to the application port of my-app.",
+				Command:     "my-app http --environment dev",
+			},
+			{
+				Description: "Forward localhost:9000 to port 8080, and the Prometheus port to the same port locally.",
+				Command:     "my-app 9000:8080 metrics --environment dev",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			name := args.Get("name")
+
+			environment, err := resolveAppEnvironment(ctx, flags.Team, name, string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			pf, err := app.NewPortForwarder(ctx, flags.Team, name, environment, flags.Address, args.GetRepeatable("ports"), out)
+			if err != nil {
+				return err
+			}
+
+			out.Infoln("Press Ctrl+C to stop forwarding.")
+			return pf.Run(ctx)
+		},
+		AutoCompleteFunc: autoCompleteAppNames(parentFlags),
+	}
+}
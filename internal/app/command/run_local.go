@@ -0,0 +1,154 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	osexec "os/exec"
+	"os/signal"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/app"
+	"github.com/nais/cli/internal/app/command/flag"
+	"github.com/nais/cli/internal/postgres"
+	pgflag "github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/cli/internal/valkey"
+	"github.com/nais/naistrix"
+)
+
+// commandShutdownTimeout is how long the command is given to exit after being interrupted, before it is killed.
+const commandShutdownTimeout = 30 * time.Second
+
+func runLocal(parentFlags *flag.App) *naistrix.Command {
+	flags := &flag.RunLocal{
+		App: parentFlags,
+	}
+
+	return &naistrix.Command{
+		Name:  "run-local",
+		Title: "Run a command locally with the environment of an application.",
+		Description: heredoc.Doc(`
+			Runs a local command with the environment variables and mounted files the application has in an environment. Secret values are read with the given reason, which is logged for auditing purposes.
+
+			Mounted files are written to a temporary directory, and environment variables that refer to them are rewritten to point there. The files are removed when the command exits.
+
+			With --postgres and --valkey, proxies to the databases of the application are started, and the variables are rewritten to point at them.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "name"},
+			{Name: "command", Repeatable: true},
+		},
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(_ context.Context, args *naistrix.Arguments) error {
+				if len(args.GetRepeatable("command")) == 0 {
+					return fmt.Errorf("a command must be specified after the application name, e.g. 'nais app run-local my-app -e dev -- ./gradlew run'")
+				}
+				if len(flags.Reason) < 10 {
+					return fmt.Errorf("--reason of at least 10 characters is required, as reading secret values is logged")
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Run my-app locally with its environment from dev.",
+				Command:     "my-app --environment dev --reason \"Reproducing bug #1234\" -- ./gradlew run",
+			},
+			{
+				Description: "Also connect to the database of the application through a proxy.",
+				Command:     "my-app --environment dev --reason \"Reproducing bug #1234\" --postgres -- go run .",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			name := args.Get("name")
+			environment := string(flags.Environment)
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			local, err := app.NewLocalEnvironment(ctx, flags.Team, name, environment, flags.Reason, out)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := local.Cleanup(); err != nil {
+					out.Warnf("Unable to remove %s: %v\n", local.Dir, err)
+				}
+			}()
+			out.Verbosef("Mounted files written to %s\n", local.Dir)
+
+			if flags.Postgres {
+				pgFlags := &pgflag.Postgres{GlobalFlags: flags.GlobalFlags, Reason: flags.Reason}
+				port, _, err := postgres.StartDatabaseProxy(ctx, name, flags.Team, environment, pgFlags, out)
+				if err != nil {
+					return fmt.Errorf("starting Postgres proxy: %w", err)
+				}
+				local.PointPostgresAt(port)
+				out.Infof("Postgres proxy running on localhost:%d\n", port)
+			}
+
+			if flags.Valkey {
+				instances := local.ValkeyInstances()
+				if len(instances) == 0 {
+					out.Warnln("The application has no Valkey instances.")
+				}
+
+				names := make([]string, 0, len(instances))
+				for instance := range instances {
+					names = append(names, instance)
+				}
+				slices.Sort(names)
+
+				for _, instance := range names {
+					port, err := startValkeyProxy(ctx, instances[instance])
+					if err != nil {
+						return fmt.Errorf("starting Valkey proxy for %s: %w", instance, err)
+					}
+					local.PointValkeyAt(instance, port)
+					out.Infof("Valkey proxy for %s running on localhost:%d\n", instance, port)
+				}
+			}
+
+			command := args.GetRepeatable("command")
+			cmd := osexec.CommandContext(ctx, command[0], command[1:]...)
+			cmd.Env = append(os.Environ(), local.Environ()...)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			// The terminal also sends Ctrl-C to the command, so it is interrupted rather than killed, and given time to run
+			// its shutdown hooks.
+			cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+			cmd.WaitDelay = commandShutdownTimeout
+
+			if err := cmd.Run(); err != nil {
+				var exitErr *osexec.ExitError
+				if errors.As(err, &exitErr) {
+					return fmt.Errorf("command exited with code %d", exitErr.ExitCode())
+				}
+				return err
+			}
+			return nil
+		},
+		AutoCompleteFunc: autoCompleteAppNames(parentFlags),
+	}
+}
+
+func startValkeyProxy(ctx context.Context, address string) (int, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	return valkey.StartProxy(ctx, host, port)
+}
@@ -0,0 +1,253 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+// maxRedirects is the number of redirects followed when probing an endpoint.
+const maxRedirects = 10
+
+// AuthIntegrations is the authentication and authorization integrations of an application.
+type AuthIntegrations []string
+
+func (a AuthIntegrations) String() string {
+	if len(a) == 0 {
+		return "-"
+	}
+	return strings.Join(a, ", ")
+}
+
+// Endpoint is an ingress of an application in an environment.
+type Endpoint struct {
+	Environment      string           `heading:"Environment" json:"environment"`
+	URL              string           `heading:"URL" json:"url"`
+	Type             string           `heading:"Type" json:"type"`
+	AuthIntegrations AuthIntegrations `heading:"Auth" json:"authIntegrations"`
+}
+
+// GetEndpoints returns the ingresses of an application in each environment, or only in the given environment, with the
+// auth integrations of the application. An environment where the application has no ingresses is included without a
+// URL, as the auth integrations also apply to traffic inside the cluster.
+func GetEndpoints(ctx context.Context, team, name, environment string) ([]Endpoint, error) {
+	_ = `# @genqlient
+		query GetApplicationEndpoints($team: Slug!, $name: String!, $env: [String!]) {
+			team(slug: $team) {
+				applications(filter: { name: $name, environments: $env }) {
+					nodes {
+						name
+						teamEnvironment {
+							environment {
+								name
+							}
+						}
+						ingresses {
+							url
+							type
+						}
+						authIntegrations {
+							__typename
+							... on EntraIDAuthIntegration {
+								name
+							}
+							... on IDPortenAuthIntegration {
+								name
+							}
+							... on MaskinportenAuthIntegration {
+								name
+							}
+							... on TokenXAuthIntegration {
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	client, err := naisapi.GraphqlClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var envs []string
+	if environment != "" {
+		envs = []string{environment}
+	}
+	resp, err := gql.GetApplicationEndpoints(ctx, client, team, name, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Endpoint
+	for _, a := range resp.Team.Applications.Nodes {
+		if a.Name != name {
+			continue
+		}
+
+		auth := make(AuthIntegrations, 0, len(a.AuthIntegrations))
+		for _, i := range a.AuthIntegrations {
+			auth = append(auth, authIntegrationName(i))
+		}
+
+		env := a.TeamEnvironment.Environment.Name
+		if len(a.Ingresses) == 0 {
+			ret = append(ret, Endpoint{Environment: env, URL: "-", Type: "-", AuthIntegrations: auth})
+			continue
+		}
+		for _, i := range a.Ingresses {
+			ret = append(ret, Endpoint{Environment: env, URL: i.Url, Type: strings.ToLower(string(i.Type)), AuthIntegrations: auth})
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("application %q not found in team %q", name, team)
+	}
+
+	slices.SortStableFunc(ret, func(a, b Endpoint) int { return strings.Compare(a.Environment, b.Environment) })
+	return ret, nil
+}
+
+func authIntegrationName(i gql.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations) string {
+	switch i := i.(type) {
+	case *gql.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration:
+		return i.Name
+	case *gql.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration:
+		return i.Name
+	case *gql.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration:
+		return i.Name
+	case *gql.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration:
+		return i.Name
+	default:
+		return strings.TrimSuffix(i.GetTypename(), "AuthIntegration")
+	}
+}
+
+// Redirects is the redirects followed when probing an endpoint, in order.
+type Redirects []string
+
+func (r Redirects) String() string {
+	if len(r) == 0 {
+		return "-"
+	}
+	return strings.Join(r, " → ")
+}
+
+// ProbeResult is the result of an HTTP request to an endpoint.
+type ProbeResult struct {
+	Status    string
+	TLSExpiry string
+	Redirects Redirects
+}
+
+// ProbedEndpoint is an endpoint with the result of probing it. Endpoints without a URL are not probed.
+type ProbedEndpoint struct {
+	Environment      string           `heading:"Environment" json:"environment"`
+	URL              string           `heading:"URL" json:"url"`
+	Type             string           `heading:"Type" json:"type"`
+	AuthIntegrations AuthIntegrations `heading:"Auth" json:"authIntegrations"`
+	Status           string           `heading:"Status" json:"status"`
+	TLSExpiry        string           `heading:"TLS expires" json:"tlsExpiry,omitempty"`
+	Redirects        Redirects        `heading:"Redirects" json:"redirects,omitempty"`
+}
+
+// ProbeEndpoints sends a GET request to the URL of each endpoint concurrently, following redirects, and returns the
+// endpoints with the results in the same order. Each request, including its redirects, must complete within timeout.
+func ProbeEndpoints(ctx context.Context, endpoints []Endpoint, timeout time.Duration) []ProbedEndpoint {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	ret := make([]ProbedEndpoint, len(endpoints))
+	wg := sync.WaitGroup{}
+	for i, e := range endpoints {
+		ret[i] = ProbedEndpoint{Environment: e.Environment, URL: e.URL, Type: e.Type, AuthIntegrations: e.AuthIntegrations, Status: "-"}
+		if e.URL == "-" {
+			continue
+		}
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			r := probe(ctx, client, e.URL)
+			ret[i].Status, ret[i].TLSExpiry, ret[i].Redirects = r.Status, r.TLSExpiry, r.Redirects
+		})
+	}
+	wg.Wait()
+	return ret
+}
+
+// probe requests a URL and follows redirects manually, so that each hop can be recorded. The TLS expiry is that of the
+// certificate of the endpoint itself, not of the hosts it redirects to.
+func probe(ctx context.Context, client *http.Client, rawURL string) ProbeResult {
+	var result ProbeResult
+
+	target := rawURL
+	for hop := 0; ; hop++ {
+		if hop > maxRedirects {
+			result.Status = fmt.Sprintf("error: more than %d redirects", maxRedirects)
+			return result
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			result.Status = "error: " + err.Error()
+			return result
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			result.Status = "error: " + err.Error()
+			return result
+		}
+		_ = resp.Body.Close()
+
+		if hop == 0 {
+			result.TLSExpiry = tlsExpiry(resp.TLS)
+		}
+
+		location := resp.Header.Get("Location")
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+			result.Status = resp.Status
+			return result
+		}
+
+		next, err := resp.Request.URL.Parse(location)
+		if err != nil {
+			result.Status = fmt.Sprintf("error: invalid redirect to %q", location)
+			return result
+		}
+		result.Redirects = append(result.Redirects, fmt.Sprintf("%d %s", resp.StatusCode, redactQuery(next)))
+		target = next.String()
+	}
+}
+
+// tlsExpiry returns when the certificate of a TLS connection expires, and how long until then.
+func tlsExpiry(state *tls.ConnectionState) string {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ""
+	}
+	notAfter := state.PeerCertificates[0].NotAfter
+	days := int(time.Until(notAfter).Hours() / 24)
+	return fmt.Sprintf("%s (%d days)", notAfter.Format(time.DateOnly), days)
+}
+
+// redactQuery returns a URL without its query, which for login redirects holds long state and nonce parameters.
+func redactQuery(u *url.URL) string {
+	c := *u
+	c.RawQuery = ""
+	c.Fragment = ""
+	return c.String()
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbeEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/oauth2/login?state=secret", http.StatusFound)
+	})
+	mux.HandleFunc("/oauth2/login", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	auth := AuthIntegrations{"my-app"}
+	var endpoints []Endpoint
+	for _, path := range []string{"/ok", "/login", "/missing", "/loop", "/slow"} {
+		endpoints = append(endpoints, Endpoint{Environment: "dev", URL: srv.URL + path, Type: "external", AuthIntegrations: auth})
+	}
+	endpoints = append(endpoints, Endpoint{Environment: "prod", URL: "-", Type: "-", AuthIntegrations: auth})
+
+	results := ProbeEndpoints(context.Background(), endpoints, 500*time.Millisecond)
+
+	want := ProbedEndpoint{Environment: "dev", URL: srv.URL + "/ok", Type: "external", AuthIntegrations: auth, Status: "200 OK"}
+	if got := results[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("ok: got %+v, want %+v", got, want)
+	}
+
+	wantRedirects := Redirects{"302 " + srv.URL + "/oauth2/login", "307 " + srv.URL + "/ok"}
+	if got := results[1]; got.Status != "200 OK" || !reflect.DeepEqual(got.Redirects, wantRedirects) {
+		t.Errorf("login: got %+v, want 200 OK with redirects %v", got, wantRedirects)
+	}
+
+	if got := results[2].Status; got != "404 Not Found" {
+		t.Errorf("missing: got status %q, want 404 Not Found", got)
+	}
+
+	if got := results[3]; !strings.Contains(got.Status, "more than 10 redirects") || len(got.Redirects) != maxRedirects+1 {
+		t.Errorf("loop: got status %q with %d redirects", got.Status, len(got.Redirects))
+	}
+
+	if got := results[4].Status; !strings.HasPrefix(got, "error:") {
+		t.Errorf("slow: got status %q, want timeout error", got)
+	}
+
+	want = ProbedEndpoint{Environment: "prod", URL: "-", Type: "-", AuthIntegrations: auth, Status: "-"}
+	if got := results[5]; !reflect.DeepEqual(got, want) {
+		t.Errorf("no ingress: got %+v, want %+v", got, want)
+	}
+}
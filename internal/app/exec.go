@@ -0,0 +1,282 @@
+package app
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nais/cli/internal/k8s"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+	"golang.org/x/term"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// InstanceSelection decides which instance of an application a command runs in. The first instance is used unless a
+// specific instance is named, or the user is asked to choose one.
+type InstanceSelection struct {
+	Instance string
+	ByPod    bool
+}
+
+// instanceTarget is a container in an application instance, reachable through the Kubernetes API.
+type instanceTarget struct {
+	client    kubernetes.Interface
+	config    *rest.Config
+	pod       *core_v1.Pod
+	container string
+}
+
+func setupInstanceTarget(ctx context.Context, team, name, environment string, selection InstanceSelection) (*instanceTarget, error) {
+	config, err := k8s.SetupRestConfig(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("load kubeclient configuration: %w", err)
+	}
+
+	pods, err := client.CoreV1().Pods(team).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=" + name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing instances of %s: %w", name, err)
+	}
+
+	pod, err := selectInstance(pods.Items, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instanceTarget{
+		client:    client,
+		config:    config,
+		pod:       pod,
+		container: appContainer(pod, name),
+	}, nil
+}
+
+func selectInstance(pods []core_v1.Pod, selection InstanceSelection) (*core_v1.Pod, error) {
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no instances found")
+	}
+
+	if selection.Instance != "" {
+		for i, pod := range pods {
+			if pod.Name == selection.Instance {
+				return &pods[i], nil
+			}
+		}
+		return nil, fmt.Errorf("instance %q not found", selection.Instance)
+	}
+
+	if !selection.ByPod {
+		return &pods[0], nil
+	}
+
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+
+	selected, err := input.Select("Select an instance", names)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pod := range pods {
+		if pod.Name == selected {
+			return &pods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("instance %q not found", selected)
+}
+
+// appContainer returns the name of the application container, which is named after the application.
+func appContainer(pod *core_v1.Pod, name string) string {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == name {
+			return c.Name
+		}
+	}
+	return pod.Spec.Containers[0].Name
+}
+
+func (t *instanceTarget) executor(command []string, stdin, tty bool) (remotecommand.Executor, error) {
+	req := t.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(t.pod.Namespace).
+		Name(t.pod.Name).
+		SubResource("exec").
+		VersionedParams(&core_v1.PodExecOptions{
+			Container: t.container,
+			Command:   command,
+			Stdin:     stdin,
+			Stdout:    true,
+			Stderr:    !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	return k8s.NewRemoteExecutor(t.config, req.URL())
+}
+
+// Exec runs a command in the application container of an instance. When stdin is a terminal the command gets a TTY,
+// otherwise stdin, stdout and stderr are streamed as is, so that output can be piped to local files.
+func Exec(ctx context.Context, team, name, environment string, selection InstanceSelection, command []string, out *naistrix.OutputWriter) error {
+	target, err := setupInstanceTarget(ctx, team, name, environment, selection)
+	if err != nil {
+		return err
+	}
+
+	tty := term.IsTerminal(int(os.Stdin.Fd())) // #nosec G115
+	executor, err := target.executor(command, true, tty)
+	if err != nil {
+		return err
+	}
+
+	out.Verbosef("Running %q in %s/%s\n", strings.Join(command, " "), target.pod.Name, target.container)
+
+	if tty {
+		err = k8s.StreamTerminal(ctx, executor, os.Stdin, os.Stdout)
+	} else {
+		err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdin:  os.Stdin,
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+		})
+	}
+
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("command exited with code %d", exitErr.ExitStatus())
+	}
+	return err
+}
+
+// Copy copies a file or directory from the application container of an instance to the local file system. The remote
+// path is archived with tar in the container, so the container image must include tar.
+func Copy(ctx context.Context, team, name, environment string, selection InstanceSelection, remotePath, localPath string, out *naistrix.OutputWriter) error {
+	target, err := setupInstanceTarget(ctx, team, name, environment, selection)
+	if err != nil {
+		return err
+	}
+
+	remotePath = path.Clean(remotePath)
+	base := path.Base(remotePath)
+	executor, err := target.executor([]string{"tar", "cf", "-", "-C", path.Dir(remotePath), base}, false, false)
+	if err != nil {
+		return err
+	}
+
+	// When copying into an existing directory, the copy ends up inside it, like cp.
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, base)
+	}
+
+	pr, pw := io.Pipe()
+	stderr := &bytes.Buffer{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+			Stdout: pw,
+			Stderr: stderr,
+		}))
+	}()
+
+	n, err := untar(pr, base, localPath)
+	_ = pr.Close()
+	<-done
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			if strings.Contains(msg, "executable file not found") {
+				return fmt.Errorf("the container image does not include tar, which is required to copy files")
+			}
+			return fmt.Errorf("copying %s: %w: %s", remotePath, err, msg)
+		}
+		return fmt.Errorf("copying %s: %w", remotePath, err)
+	}
+
+	out.Successf("Copied %s:%s to %s (%d files)\n", target.pod.Name, remotePath, localPath, n)
+	return nil
+}
+
+// untar extracts a tar stream whose entries are rooted at base, placing base at dest. Entries that would end up
+// outside of dest are rejected. It returns the number of regular files written.
+func untar(r io.Reader, base, dest string) (int, error) {
+	files := 0
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return files, fmt.Errorf("reading archive: %w", err)
+		}
+
+		rel, ok := strings.CutPrefix(path.Clean(hdr.Name), base)
+		if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+			return files, fmt.Errorf("unexpected entry %q in archive", hdr.Name)
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if target != filepath.Clean(dest) && !strings.HasPrefix(target, filepath.Clean(dest)+string(filepath.Separator)) {
+			return files, fmt.Errorf("entry %q points outside of %s", hdr.Name, dest)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return files, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return files, err
+			}
+			if err := writeFile(target, tr, hdr.FileInfo().Mode().Perm()); err != nil {
+				return files, err
+			}
+			files++
+		default:
+			// Links and special files are skipped, as they may point anywhere on the local file system.
+		}
+	}
+
+	if files == 0 {
+		if _, err := os.Stat(dest); err != nil {
+			return 0, fmt.Errorf("nothing was copied, check that the path exists")
+		}
+	}
+
+	return files, nil
+}
+
+func writeFile(name string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm) // #nosec G304
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil { // #nosec G110
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
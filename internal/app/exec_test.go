@@ -0,0 +1,97 @@
+package app
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type tarEntry struct {
+	name    string
+	content string
+	dir     bool
+}
+
+func archive(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.dir {
+			hdr.Typeflag, hdr.Mode, hdr.Size = tar.TypeDir, 0o755, 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestUntar(t *testing.T) {
+	t.Run("single file", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "dump.hprof")
+		n, err := untar(archive(t, tarEntry{name: "heap.hprof", content: "heap"}), "heap.hprof", dest)
+		if err != nil || n != 1 {
+			t.Fatalf("untar() = %d, %v", n, err)
+		}
+		if got, _ := os.ReadFile(dest); string(got) != "heap" {
+			t.Errorf("unexpected content %q", got)
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "logs")
+		n, err := untar(archive(t,
+			tarEntry{name: "logs/", dir: true},
+			tarEntry{name: "logs/a.log", content: "a"},
+			tarEntry{name: "logs/sub/b.log", content: "b"},
+		), "logs", dest)
+		if err != nil || n != 2 {
+			t.Fatalf("untar() = %d, %v", n, err)
+		}
+		if got, _ := os.ReadFile(filepath.Join(dest, "sub", "b.log")); string(got) != "b" {
+			t.Errorf("unexpected content %q", got)
+		}
+	})
+
+	t.Run("rejects entries outside of base", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "logs")
+		for _, name := range []string{"logs/../../etc/passwd", "other/file", "logsevil/file"} {
+			if _, err := untar(archive(t, tarEntry{name: name, content: "x"}), "logs", dest); err == nil {
+				t.Errorf("expected entry %q to be rejected", name)
+			}
+		}
+	})
+}
+
+func TestSelectInstance(t *testing.T) {
+	pods := []core_v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "my-app-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "my-app-2"}},
+	}
+
+	if pod, err := selectInstance(pods, InstanceSelection{}); err != nil || pod.Name != "my-app-1" {
+		t.Errorf("expected first instance, got %v, %v", pod, err)
+	}
+	if pod, err := selectInstance(pods, InstanceSelection{Instance: "my-app-2"}); err != nil || pod.Name != "my-app-2" {
+		t.Errorf("expected named instance, got %v, %v", pod, err)
+	}
+	if _, err := selectInstance(pods, InstanceSelection{Instance: "my-app-3"}); err == nil {
+		t.Error("expected error for unknown instance")
+	}
+	if _, err := selectInstance(nil, InstanceSelection{}); err == nil {
+		t.Error("expected error when there are no instances")
+	}
+}
@@ -0,0 +1,282 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/k8s"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/naistrix"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultApplicationPort = 8080
+
+	instancePollInterval = 2 * time.Second
+	instanceWaitTimeout  = 2 * time.Minute
+)
+
+// PortMapping is a local port forwarded to a port on an application instance.
+type PortMapping struct {
+	Local  int
+	Remote int
+}
+
+func (p PortMapping) String() string {
+	return fmt.Sprintf("%d:%d", p.Local, p.Remote)
+}
+
+// ParsePortSpec parses a port argument in the form [local:]remote. The remote port is either a number or a port name,
+// which is resolved with [ResolvePort]. A local port of 0 means that the remote port is used locally as well.
+func ParsePortSpec(spec string) (local int, remote string, err error) {
+	l, remote, found := strings.Cut(spec, ":")
+	if !found {
+		return 0, l, nil
+	}
+
+	if remote == "" {
+		return 0, "", fmt.Errorf("invalid port %q: missing remote port", spec)
+	}
+
+	local, err = parsePortNumber(l)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid local port in %q: %w", spec, err)
+	}
+
+	return local, remote, nil
+}
+
+// ResolvePort resolves a remote port given as a number or as a name. Names are resolved from the Application spec
+// first ("http" is the application port, "metrics" and "prometheus" the Prometheus port), and then from the named
+// container ports of the application container in the pod.
+func ResolvePort(spec nais_io_v1alpha1.ApplicationSpec, pod *core_v1.Pod, name string) (int, error) {
+	if port, err := parsePortNumber(name); err == nil {
+		return port, nil
+	}
+
+	appPort := cmp.Or(spec.Port, defaultApplicationPort)
+	switch name {
+	case "http":
+		return appPort, nil
+	case "metrics", "prometheus":
+		if spec.Prometheus != nil && spec.Prometheus.Port != "" {
+			return parsePortNumber(spec.Prometheus.Port)
+		}
+		return appPort, nil
+	}
+
+	if pod != nil {
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == name {
+					return int(p.ContainerPort), nil
+				}
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unknown port %q: not a number, and not a port named in the application spec or pod", name)
+}
+
+func parsePortNumber(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
+
+// ReadyInstance returns a ready, non-terminating instance of the application, or nil if there is none.
+func ReadyInstance(pods []core_v1.Pod) *core_v1.Pod {
+	for i, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != core_v1.PodRunning {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == core_v1.PodReady && c.Status == core_v1.ConditionTrue {
+				return &pods[i]
+			}
+		}
+	}
+	return nil
+}
+
+// PortForwarder forwards local ports to a ready instance of an application, and moves on to another instance if the
+// one it is connected to goes away.
+type PortForwarder struct {
+	client  kubernetes.Interface
+	config  *rest.Config
+	app     *nais_io_v1alpha1.Application
+	address string
+	ports   []string
+	out     *naistrix.OutputWriter
+}
+
+// NewPortForwarder sets up port forwarding to the named application. Ports are given as [local:]remote.
+func NewPortForwarder(ctx context.Context, team, name, environment, address string, ports []string, out *naistrix.OutputWriter) (*PortForwarder, error) {
+	config, err := k8s.SetupRestConfig(environment)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("load kubeclient configuration: %w", err)
+	}
+
+	app := &nais_io_v1alpha1.Application{}
+	ctrlClient := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+	if err := ctrlClient.Get(ctx, ctrl.ObjectKey{Namespace: team, Name: name}, app); err != nil {
+		return nil, fmt.Errorf("fetching application %s: %w", name, err)
+	}
+
+	return &PortForwarder{
+		client:  client,
+		config:  config,
+		app:     app,
+		address: address,
+		ports:   ports,
+		out:     out,
+	}, nil
+}
+
+// Run forwards ports until the context is cancelled.
+func (p *PortForwarder) Run(ctx context.Context) error {
+	for {
+		pod, err := p.waitForInstance(ctx)
+		if err != nil {
+			return err
+		}
+
+		mappings, err := p.mappings(pod)
+		if err != nil {
+			return err
+		}
+
+		err = p.forward(ctx, pod, mappings)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil && !errors.Is(err, portforward.ErrLostConnectionToPod) {
+			return err
+		}
+
+		p.out.Warnf("Lost connection to %s, reconnecting to another instance...\n", pod.Name)
+	}
+}
+
+func (p *PortForwarder) mappings(pod *core_v1.Pod) ([]PortMapping, error) {
+	ret := make([]PortMapping, 0, len(p.ports))
+	for _, spec := range p.ports {
+		local, remote, err := ParsePortSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		remotePort, err := ResolvePort(p.app.Spec, pod, remote)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, PortMapping{Local: cmp.Or(local, remotePort), Remote: remotePort})
+	}
+	return ret, nil
+}
+
+func (p *PortForwarder) waitForInstance(ctx context.Context) (*core_v1.Pod, error) {
+	var pod *core_v1.Pod
+	err := wait.PollUntilContextTimeout(ctx, instancePollInterval, instanceWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := p.client.CoreV1().Pods(p.app.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: "app=" + p.app.Name,
+		})
+		if err != nil {
+			return false, fmt.Errorf("listing instances of %s: %w", p.app.Name, err)
+		}
+
+		pod = ReadyInstance(pods.Items)
+		return pod != nil, nil
+	})
+	if wait.Interrupted(err) {
+		return nil, fmt.Errorf("no ready instance of %s found within %s", p.app.Name, instanceWaitTimeout)
+	}
+	return pod, err
+}
+
+// forward forwards ports to the pod until the connection is lost, the pod stops being ready, or the context is
+// cancelled.
+func (p *PortForwarder) forward(ctx context.Context, pod *core_v1.Pod, mappings []PortMapping) error {
+	u := p.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward").
+		URL()
+
+	dialer, err := k8s.NewPortForwardDialer(p.config, u)
+	if err != nil {
+		return err
+	}
+
+	ports := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		ports = append(ports, m.String())
+	}
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{p.address}, ports, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return fmt.Errorf("creating port forward: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ready:
+	}
+
+	for _, m := range mappings {
+		p.out.Successf("Forwarding %s:%d -> %s:%d\n", p.address, m.Local, pod.Name, m.Remote)
+	}
+
+	// A WebSocket connection is not necessarily closed when the pod goes away, so the pod is watched as well.
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_ = wait.PollUntilContextCancel(watchCtx, instancePollInterval, false, func(ctx context.Context) (bool, error) {
+			current, err := p.client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err != nil || ReadyInstance([]core_v1.Pod{*current}) == nil {
+				return true, nil
+			}
+			return false, nil
+		})
+		close(stop)
+	}()
+
+	err = <-errCh
+	if err == nil && ctx.Err() == nil {
+		return portforward.ErrLostConnectionToPod
+	}
+	return err
+}
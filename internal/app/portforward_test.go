@@ -0,0 +1,93 @@
+package app
+
+import (
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePortSpec(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantLocal  int
+		wantRemote string
+		wantErr    bool
+	}{
+		{spec: "8080", wantRemote: "8080"},
+		{spec: "http", wantRemote: "http"},
+		{spec: "9000:8080", wantLocal: 9000, wantRemote: "8080"},
+		{spec: "9090:metrics", wantLocal: 9090, wantRemote: "metrics"},
+		{spec: "9000:", wantErr: true},
+		{spec: "foo:8080", wantErr: true},
+		{spec: "70000:8080", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			local, remote, err := ParsePortSpec(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePortSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if local != tt.wantLocal || remote != tt.wantRemote {
+				t.Errorf("ParsePortSpec() = %d, %q, want %d, %q", local, remote, tt.wantLocal, tt.wantRemote)
+			}
+		})
+	}
+}
+
+func TestResolvePort(t *testing.T) {
+	spec := nais_io_v1alpha1.ApplicationSpec{
+		Port:       8081,
+		Prometheus: &nais_io_v1.PrometheusConfig{Port: "9090"},
+	}
+	pod := &core_v1.Pod{Spec: core_v1.PodSpec{Containers: []core_v1.Container{{
+		Ports: []core_v1.ContainerPort{{Name: "admin", ContainerPort: 8888}},
+	}}}}
+
+	tests := []struct {
+		name    string
+		spec    nais_io_v1alpha1.ApplicationSpec
+		want    int
+		wantErr bool
+	}{
+		{name: "1234", spec: spec, want: 1234},
+		{name: "http", spec: spec, want: 8081},
+		{name: "http", want: defaultApplicationPort},
+		{name: "metrics", spec: spec, want: 9090},
+		{name: "prometheus", want: defaultApplicationPort},
+		{name: "admin", spec: spec, want: 8888},
+		{name: "unknown", spec: spec, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePort(tt.spec, pod, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolvePort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolvePort() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadyInstance(t *testing.T) {
+	ready := []core_v1.PodCondition{{Type: core_v1.PodReady, Status: core_v1.ConditionTrue}}
+	pods := []core_v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pending"}, Status: core_v1.PodStatus{Phase: core_v1.PodPending}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "terminating", DeletionTimestamp: new(metav1.Now())}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning, Conditions: ready}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ready"}, Status: core_v1.PodStatus{Phase: core_v1.PodRunning, Conditions: ready}},
+	}
+
+	if got := ReadyInstance(pods); got == nil || got.Name != "ready" {
+		t.Errorf("ReadyInstance() = %v, want pod %q", got, "ready")
+	}
+	if got := ReadyInstance(pods[:3]); got != nil {
+		t.Errorf("ReadyInstance() = %q, want nil", got.Name)
+	}
+}
@@ -0,0 +1,315 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nais/cli/internal/config"
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/naistrix"
+)
+
+// LocalEnvironment is the environment of an application in an environment, materialized for running it locally.
+// Mounted files are written to Dir, and the environment variables refer to them there.
+type LocalEnvironment struct {
+	Dir string
+	Env map[string]string
+}
+
+// Cleanup removes the materialized files.
+func (l *LocalEnvironment) Cleanup() error {
+	return os.RemoveAll(l.Dir)
+}
+
+// Environ returns the environment variables in the KEY=VALUE form used by os/exec, sorted by key.
+func (l *LocalEnvironment) Environ() []string {
+	ret := make([]string, 0, len(l.Env))
+	for k, v := range l.Env {
+		ret = append(ret, k+"="+v)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// NewLocalEnvironment resolves the environment variables and mounted files of an application, and writes the files to a
+// new temporary directory. Secret values are read with the given reason, which is logged. The caller must call Cleanup.
+func NewLocalEnvironment(ctx context.Context, team, name, environment, reason string, out *naistrix.OutputWriter) (*LocalEnvironment, error) {
+	vars, err := GetApplicationEnvVars(ctx, team, name, environment)
+	if err != nil {
+		return nil, fmt.Errorf("fetching environment variables: %w", err)
+	}
+
+	files, err := GetApplicationFiles(ctx, team, name, environment)
+	if err != nil {
+		return nil, fmt.Errorf("fetching mounted files: %w", err)
+	}
+
+	values := &sourceValues{team: team, environment: environment, reason: reason}
+
+	dir, err := os.MkdirTemp("", "nais-run-local-"+name+"-")
+	if err != nil {
+		return nil, err
+	}
+	l := &LocalEnvironment{Dir: dir, Env: map[string]string{}}
+
+	var mountDirs []string
+	for _, f := range files {
+		if f.Error.Error != "" {
+			out.Warnf("Skipping mounted file %s: %s\n", f.Path, f.Error.Error)
+			continue
+		}
+
+		data, err := values.file(ctx, f)
+		if err != nil {
+			out.Warnf("Skipping mounted file %s: %v\n", f.Path, err)
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			_ = l.Cleanup()
+			return nil, err
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			_ = l.Cleanup()
+			return nil, err
+		}
+
+		if d := filepath.ToSlash(filepath.Dir(f.Path)); !slices.Contains(mountDirs, d) {
+			mountDirs = append(mountDirs, d)
+		}
+	}
+
+	for _, v := range vars {
+		value, ok, err := values.envVar(ctx, v)
+		if err != nil {
+			_ = l.Cleanup()
+			return nil, err
+		}
+		if !ok {
+			out.Warnf("Skipping environment variable %s: key not found in %s\n", v.Name, v.Source)
+			continue
+		}
+		l.Env[v.Name] = rewritePaths(value, mountDirs, dir)
+	}
+
+	return l, nil
+}
+
+// sourceValues reads and caches the values of the secrets and configs an application uses.
+type sourceValues struct {
+	team, environment, reason string
+
+	secrets map[string]map[string]naisapi.SecretValue
+	configs map[string]map[string]gql.GetConfigTeamEnvironmentConfigValuesConfigValue
+}
+
+func (s *sourceValues) secret(ctx context.Context, name string) (map[string]naisapi.SecretValue, error) {
+	if v, ok := s.secrets[name]; ok {
+		return v, nil
+	}
+
+	values, err := naisapi.ViewSecretValues(ctx, s.team, s.environment, name, s.reason)
+	if err != nil {
+		return nil, fmt.Errorf("viewing values of secret %q: %w", name, err)
+	}
+
+	ret := make(map[string]naisapi.SecretValue, len(values))
+	for _, v := range values {
+		ret[v.Name] = v
+	}
+	if s.secrets == nil {
+		s.secrets = map[string]map[string]naisapi.SecretValue{}
+	}
+	s.secrets[name] = ret
+	return ret, nil
+}
+
+func (s *sourceValues) config(ctx context.Context, name string) (map[string]gql.GetConfigTeamEnvironmentConfigValuesConfigValue, error) {
+	if v, ok := s.configs[name]; ok {
+		return v, nil
+	}
+
+	c, err := config.Get(ctx, config.Metadata{TeamSlug: s.team, EnvironmentName: s.environment, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("fetching config %q: %w", name, err)
+	}
+
+	ret := make(map[string]gql.GetConfigTeamEnvironmentConfigValuesConfigValue, len(c.Values))
+	for _, v := range c.Values {
+		ret[v.Name] = v
+	}
+	if s.configs == nil {
+		s.configs = map[string]map[string]gql.GetConfigTeamEnvironmentConfigValuesConfigValue{}
+	}
+	s.configs[name] = ret
+	return ret, nil
+}
+
+// secretBacked returns true if values from sources of the given kind are read from a secret. Values injected by the
+// platform, such as the NAIS_DATABASE_* variables, are stored in secrets as well.
+func secretBacked(kind string) bool {
+	return kind == string(gql.InstanceGroupValueSourceKindSecret) || kind == string(gql.InstanceGroupValueSourceKindNais)
+}
+
+// envVar returns the value of an environment variable, which is read from its secret if it is secret-backed. ok is
+// false if the key is not found in the secret.
+func (s *sourceValues) envVar(ctx context.Context, v EnvVar) (value string, ok bool, err error) {
+	if !secretBacked(v.Source.Kind) {
+		return v.Value.Value, true, nil
+	}
+
+	values, err := s.secret(ctx, v.Source.Name)
+	if err != nil {
+		return "", false, err
+	}
+	sv, ok := values[v.Name]
+	return sv.Value, ok, nil
+}
+
+// file returns the content of a mounted file, which is the value of the key with the name of the file in its source.
+func (s *sourceValues) file(ctx context.Context, f MountedFile) ([]byte, error) {
+	key := filepath.Base(f.Path)
+
+	var value string
+	var encoding gql.ValueEncoding
+	switch {
+	case secretBacked(f.Source.Kind):
+		values, err := s.secret(ctx, f.Source.Name)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := values[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %s", key, f.Source)
+		}
+		value, encoding = v.Value, v.Encoding
+	case f.Source.Kind == string(gql.InstanceGroupValueSourceKindConfig):
+		values, err := s.config(ctx, f.Source.Name)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := values[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found in %s", key, f.Source)
+		}
+		value, encoding = v.Value, v.Encoding
+	default:
+		return nil, fmt.Errorf("files from %s cannot be read", f.Source)
+	}
+
+	if encoding == gql.ValueEncodingBase64 {
+		return base64.StdEncoding.DecodeString(value)
+	}
+	return []byte(value), nil
+}
+
+// rewritePaths prefixes every path in value that is in one of the mount directories with root. Only whole paths are
+// rewritten, at the start of the value or after '=', ':' or ',', so that a mount directory such as /app does not
+// rewrite https://host/app/api. Longer directories are tried first, so that nested mounts are only rewritten once.
+func rewritePaths(value string, mountDirs []string, root string) string {
+	dirs := slices.Clone(mountDirs)
+	slices.SortFunc(dirs, func(a, b string) int { return len(b) - len(a) })
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if i == 0 || strings.IndexByte("=:,", value[i-1]) >= 0 {
+			if d, ok := mountDirAt(value[i:], dirs); ok {
+				b.WriteString(filepath.ToSlash(filepath.Join(root, filepath.FromSlash(d))))
+				i += len(d) - 1
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// mountDirAt returns the mount directory that s starts with, if the directory is followed by the end of a path.
+func mountDirAt(s string, dirs []string) (string, bool) {
+	for _, d := range dirs {
+		if !strings.HasPrefix(s, d) {
+			continue
+		}
+		if rest := s[len(d):]; rest == "" || strings.IndexByte("/=:,&; ", rest[0]) >= 0 {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// PointPostgresAt rewrites the NAIS_DATABASE_* variables to connect to a local proxy on the given port, without TLS as
+// the proxy encrypts the connection.
+func (l *LocalEnvironment) PointPostgresAt(port int) {
+	for k, v := range l.Env {
+		if !strings.HasPrefix(k, "NAIS_DATABASE_") {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(k, "_HOST"):
+			l.Env[k] = "localhost"
+		case strings.HasSuffix(k, "_PORT"):
+			l.Env[k] = strconv.Itoa(port)
+		case strings.HasSuffix(k, "_SSLMODE"):
+			l.Env[k] = "disable"
+		case strings.HasSuffix(k, "_JDBC_URL"):
+			l.Env[k] = "jdbc:" + rewriteURL(strings.TrimPrefix(v, "jdbc:"), port, "")
+		case strings.HasSuffix(k, "_URL"):
+			l.Env[k] = rewriteURL(v, port, "")
+		}
+	}
+}
+
+// ValkeyInstances returns the host and port of each Valkey instance in the VALKEY_HOST_* and VALKEY_PORT_* variables,
+// keyed by the instance suffix.
+func (l *LocalEnvironment) ValkeyInstances() map[string]string {
+	ret := map[string]string{}
+	for k, v := range l.Env {
+		instance, ok := strings.CutPrefix(k, "VALKEY_HOST_")
+		if !ok {
+			continue
+		}
+		if port, ok := l.Env["VALKEY_PORT_"+instance]; ok {
+			ret[instance] = net.JoinHostPort(v, port)
+		}
+	}
+	return ret
+}
+
+// PointValkeyAt rewrites the variables of a Valkey instance to connect to a local proxy on the given port, without TLS
+// as the proxy encrypts the connection.
+func (l *LocalEnvironment) PointValkeyAt(instance string, port int) {
+	l.Env["VALKEY_HOST_"+instance] = "localhost"
+	l.Env["VALKEY_PORT_"+instance] = strconv.Itoa(port)
+	if v, ok := l.Env["VALKEY_URI_"+instance]; ok {
+		l.Env["VALKEY_URI_"+instance] = rewriteURL(v, port, "redis")
+	}
+}
+
+// rewriteURL points a URL at localhost on the given port, and disables TLS in its query. The scheme is replaced if
+// scheme is set. Values that are not URLs are returned as is.
+func rewriteURL(value string, port int, scheme string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value
+	}
+
+	u.Host = net.JoinHostPort("localhost", strconv.Itoa(port))
+	if scheme != "" {
+		u.Scheme = scheme
+	}
+	if q := u.Query(); q.Has("sslmode") {
+		q.Set("sslmode", "disable")
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
@@ -14,6 +14,7 @@ import (
 	authCommand "github.com/nais/cli/internal/auth/command"
 	configCommand "github.com/nais/cli/internal/config/command"
 	debugCommand "github.com/nais/cli/internal/debug/command"
+	devCommand "github.com/nais/cli/internal/dev/command"
 	"github.com/nais/cli/internal/flags"
 	issuesCommand "github.com/nais/cli/internal/issues/command"
 	jobCommand "github.com/nais/cli/internal/job/command"
@@ -26,8 +27,10 @@ import (
 	naisapiauth "github.com/nais/cli/internal/naisapi/auth"
 	naisapiCommand "github.com/nais/cli/internal/naisapi/command"
 	naisdeviceCommand "github.com/nais/cli/internal/naisdevice/command"
+	netpolCommand "github.com/nais/cli/internal/netpol/command"
 	opensearchCommand "github.com/nais/cli/internal/opensearch/command"
 	postgresCommand "github.com/nais/cli/internal/postgres/command"
+	renderCommand "github.com/nais/cli/internal/render/command"
 	secretCommand "github.com/nais/cli/internal/secret/command"
 	statusCommand "github.com/nais/cli/internal/status/command"
 	validateCommand "github.com/nais/cli/internal/validate/command"
@@ -76,6 +79,7 @@ func New(w io.Writer) (*Application, *flags.GlobalFlags, error) {
 		authCommand.Auth(globalFlags),
 		configCommand.Config(globalFlags),
 		debugCommand.Debug(globalFlags),
+		devCommand.Dev(globalFlags),
 		issuesCommand.Issues(globalFlags),
 		jobCommand.Job(globalFlags),
 		kafkaCommand.Kafka(globalFlags),
@@ -84,8 +88,10 @@ func New(w io.Writer) (*Application, *flags.GlobalFlags, error) {
 		memberCommand.Members(globalFlags),
 		naisapiCommand.Api(globalFlags),
 		naisdeviceCommand.Naisdevice(globalFlags),
+		netpolCommand.Netpol(globalFlags),
 		opensearchCommand.OpenSearch(globalFlags),
 		postgresCommand.Postgres(globalFlags),
+		renderCommand.Render(globalFlags),
 		secretCommand.Secrets(globalFlags),
 		statusCommand.Status(globalFlags),
 		validateCommand.Validate(globalFlags),
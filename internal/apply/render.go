@@ -25,6 +25,18 @@ import (
 // If mixinPath is empty, an adjacent "<base>.<env>.yaml" file is auto-loaded when
 // it exists.
 func render(basePath, mixinPath, environment string, sets []string, out *naistrix.OutputWriter) ([]byte, error) {
+	return Render(basePath, mixinPath, environment, sets, nil, out)
+}
+
+// Render is like render, but passes the content of the base and mixin files
+// through expand before they are decoded. This lets callers expand templates in
+// the files, which would otherwise not survive decoding. A nil expand leaves the
+// files as they are.
+func Render(basePath, mixinPath, environment string, sets []string, expand func([]byte) ([]byte, error), out *naistrix.OutputWriter) ([]byte, error) {
+	if expand == nil {
+		expand = func(data []byte) ([]byte, error) { return data, nil }
+	}
+
 	if basePath == "" {
 		return nil, fmt.Errorf("file path cannot be empty")
 	}
@@ -36,6 +48,10 @@ func render(basePath, mixinPath, environment string, sets []string, out *naistri
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", basePath, err)
 	}
+	baseData, err = expand(baseData)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", basePath, err)
+	}
 
 	if mixinPath == "" {
 		if auto := autoMixinPath(basePath, environment); auto != "" {
@@ -61,6 +77,10 @@ func render(basePath, mixinPath, environment string, sets []string, out *naistri
 		if err != nil {
 			return nil, fmt.Errorf("failed to read mixin %s: %w", mixinPath, err)
 		}
+		mixinData, err = expand(mixinData)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", mixinPath, err)
+		}
 		mixin, err := decodeSingleDocument(mixinData, mixinPath)
 		if err != nil {
 			return nil, err
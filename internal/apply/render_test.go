@@ -65,6 +65,28 @@ func TestRender_ExplicitMixinDeepMerges(t *testing.T) {
 	}
 }
 
+func TestRender_ExpandsFilesBeforeDecoding(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "nais.yaml", "kind: Application\nspec:\n  image: IMAGE\n  replicas: 1\n")
+	mixin := writeFile(t, dir, "dev.yaml", "spec:\n  replicas: REPLICAS\n")
+
+	expand := func(data []byte) ([]byte, error) {
+		return []byte(strings.NewReplacer("IMAGE", "expanded", "REPLICAS", "3").Replace(string(data))), nil
+	}
+	got, err := Render(base, mixin, "", nil, expand, discardWriter())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec := renderToMap(t, got)["spec"].(map[string]any)
+	if spec["image"] != "expanded" {
+		t.Errorf("image = %v, want expanded", spec["image"])
+	}
+	if spec["replicas"] != 3 {
+		t.Errorf("replicas = %v, want 3", spec["replicas"])
+	}
+}
+
 func TestRender_AutoLoadsEnvMixin(t *testing.T) {
 	dir := t.TempDir()
 	base := writeFile(t, dir, "nais.yaml", "kind: Application\nspec:\n  image: old\n")
@@ -29,6 +29,8 @@ func Config(parentFlags *flags.GlobalFlags) *naistrix.Command {
 			deleteConfig(f),
 			set(f),
 			unset(f),
+			copyConfig(f),
+			diff(f),
 		},
 	}
 }
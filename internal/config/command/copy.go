@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/config"
+	"github.com/nais/cli/internal/config/command/flag"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func copyConfig(parentFlags *flag.Config) *naistrix.Command {
+	f := &flag.Copy{Config: parentFlags}
+	return &naistrix.Command{
+		Name:  "copy",
+		Title: "Copy a config to another environment.",
+		Description: heredoc.Doc(`
+			Copies the values of a config from one environment to another. The config is created in the target environment if it does not exist.
+
+			The keys that will be added, changed and removed are shown before anything is changed, with the values masked. Values that are equal in both environments are left as is. With --replace, keys in the target config that are not copied are removed.
+
+			Updating a config will cause a restart of workloads referencing the config.
+		`),
+		Flags: f,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validateArgs,
+			func(_ context.Context, args *naistrix.Arguments) error {
+				if f.From == "" || f.To == "" {
+					return fmt.Errorf("--from and --to are required")
+				}
+				if f.From == f.To && (f.Rename == "" || f.Rename == args.Get("name")) {
+					return fmt.Errorf("--from and --to must be different environments, unless --rename is used")
+				}
+				if f.Replace && f.Keys != "" {
+					return fmt.Errorf("--replace and --keys are mutually exclusive")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: autoCompleteConfigNames(parentFlags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Copy the config my-config from dev to prod.",
+				Command:     "my-config --from dev --to prod",
+			},
+			{
+				Description: "Only copy some keys, to a config with another name.",
+				Command:     "my-config --from dev --to prod --keys API_URL,LOG_LEVEL --rename other-config",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			from := metadataFromArgs(args, f.Team, string(f.From))
+			to := metadataFromArgs(args, f.Team, string(f.To))
+			if f.Rename != "" {
+				to.Name = f.Rename
+			}
+
+			if _, err := resolveConfigEnvironment(ctx, f.Team, from.Name, from.EnvironmentName); err != nil {
+				return err
+			}
+
+			source, err := config.Get(ctx, from)
+			if err != nil {
+				return fmt.Errorf("fetching config: %w", err)
+			}
+
+			targetEnvs, err := config.ConfigEnvironments(ctx, f.Team, to.Name)
+			if err != nil {
+				return fmt.Errorf("fetching environments for config %q: %w", to.Name, err)
+			}
+			create := !slices.Contains(targetEnvs, to.EnvironmentName)
+
+			var target []gql.GetConfigTeamEnvironmentConfigValuesConfigValue
+			if !create {
+				existing, err := config.Get(ctx, to)
+				if err != nil {
+					return fmt.Errorf("fetching config: %w", err)
+				}
+				target = existing.Values
+			}
+
+			plan, err := config.PlanCopy(source.Values, target, splitKeys(f.Keys), f.Replace)
+			if err != nil {
+				return err
+			}
+
+			if !plan.Changed() && !create {
+				out.Infof("Config %q in %q is already up to date.\n", to.Name, to.EnvironmentName)
+				return nil
+			}
+
+			if create {
+				out.Printf("Config %q will be created in %q:\n", to.Name, to.EnvironmentName)
+			} else {
+				out.Printf("Config %q in %q:\n", to.Name, to.EnvironmentName)
+			}
+			for _, v := range plan.Add {
+				out.Printf("  + %s %s\n", v.Name, config.MaskedSize(v))
+			}
+			for _, v := range plan.Update {
+				out.Printf("  ~ %s %s\n", v.Name, config.MaskedSize(v))
+			}
+			for _, k := range plan.Remove {
+				out.Printf("  - %s\n", k)
+			}
+
+			if !f.Yes {
+				if result, err := input.Confirm(fmt.Sprintf("Copy config %q from %q to %q?", from.Name, from.EnvironmentName, to.EnvironmentName)); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if err := config.ApplyCopy(ctx, to, plan, create); err != nil {
+				return fmt.Errorf("copying values: %w", err)
+			}
+
+			out.Successf("Copied config %q from %q to %q in %q\n", from.Name, from.EnvironmentName, to.Name, to.EnvironmentName)
+			return nil
+		},
+	}
+}
+
+// splitKeys splits a comma-separated list of keys.
+func splitKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
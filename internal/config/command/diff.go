@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/config"
+	"github.com/nais/cli/internal/config/command/flag"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+)
+
+func diff(parentFlags *flag.Config) *naistrix.Command {
+	f := &flag.Diff{Config: parentFlags, Output: "table"}
+	return &naistrix.Command{
+		Name:  "diff",
+		Title: "Compare configs across environments.",
+		Description: heredoc.Doc(`
+			Compares the keys and values of a config in every environment it exists in, and shows a row per key with a column per environment. Keys that are missing in an environment are marked with -, and equal values are labelled with the same letter.
+
+			Use --all to compare every config of the team.
+		`),
+		Flags: f,
+		Args:  []naistrix.Argument{{Name: "name", Repeatable: true}},
+		ValidateFunc: func(_ context.Context, args *naistrix.Arguments) error {
+			if f.All && args.Len() > 0 {
+				return fmt.Errorf("config names cannot be given together with --all")
+			}
+			if !f.All && args.Len() == 0 {
+				return fmt.Errorf("expected a config name, or --all")
+			}
+			return nil
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Compare the config my-config across environments.",
+				Command:     "my-config",
+			},
+			{
+				Description: "Compare all configs of the team.",
+				Command:     "--all",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			names := args.GetRepeatable("name")
+
+			var filter gql.ConfigFilter
+			if len(names) == 1 {
+				filter.Name = names[0]
+			}
+			all, err := config.GetAll(ctx, f.Team, filter)
+			if err != nil {
+				return fmt.Errorf("fetching configs: %w", err)
+			}
+
+			values := map[string]map[string][]gql.GetConfigTeamEnvironmentConfigValuesConfigValue{}
+			var environments []string
+			for _, c := range all {
+				if !f.All && !slices.Contains(names, c.Name) {
+					continue
+				}
+
+				env := c.TeamEnvironment.Environment.Name
+				vs := make([]gql.GetConfigTeamEnvironmentConfigValuesConfigValue, 0, len(c.Values))
+				for _, v := range c.Values {
+					vs = append(vs, gql.GetConfigTeamEnvironmentConfigValuesConfigValue{Name: v.Name, Value: v.Value, Encoding: v.Encoding})
+				}
+
+				if values[c.Name] == nil {
+					values[c.Name] = map[string][]gql.GetConfigTeamEnvironmentConfigValuesConfigValue{}
+				}
+				values[c.Name][env] = vs
+				if !slices.Contains(environments, env) {
+					environments = append(environments, env)
+				}
+			}
+
+			for _, name := range names {
+				if _, ok := values[name]; !ok {
+					return fmt.Errorf("config %q not found in team %q", name, f.Team)
+				}
+			}
+
+			configNames := make([]string, 0, len(values))
+			for name := range values {
+				configNames = append(configNames, name)
+			}
+			slices.Sort(configNames)
+			slices.Sort(environments)
+
+			var rows []config.DiffRow
+			for _, name := range configNames {
+				rows = append(rows, config.Diff(name, values[name])...)
+			}
+
+			if f.Output == "json" {
+				return out.JSON(output.JSONWithPrettyOutput()).Render(rows)
+			}
+
+			if len(rows) == 0 {
+				out.Infoln("No config keys found.")
+				return nil
+			}
+			return out.Table().Render(config.FormatDiff(rows, environments, len(configNames) > 1 || f.All))
+		},
+	}
+}
@@ -70,3 +70,19 @@ type Unset struct {
 	Key string `name:"key" usage:"Name of the key to unset."`
 	Yes bool   `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
 }
+
+type Copy struct {
+	*Config
+	From    flags.Environment `name:"from" usage:"|ENVIRONMENT| to copy the config from."`
+	To      flags.Environment `name:"to" usage:"|ENVIRONMENT| to copy the config to."`
+	Keys    string            `name:"keys" usage:"Comma-separated list of |KEYS| to copy. All keys are copied by default."`
+	Rename  string            `name:"rename" usage:"Copy to a config with another |NAME| in the target environment."`
+	Replace bool              `name:"replace" usage:"Remove keys in the target config that are not copied. Cannot be used with --keys."`
+	Yes     bool              `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type Diff struct {
+	*Config
+	Output Output `name:"output" short:"o" usage:"Format output (table or json)."`
+	All    bool   `name:"all" usage:"Compare all configs of the team."`
+}
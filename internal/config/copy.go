@@ -0,0 +1,67 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nais/cli/internal/keyvalue"
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+// CopyPlan is the changes needed to copy values into a config from the same config in another environment.
+type CopyPlan = keyvalue.Plan[gql.ConfigValueInput]
+
+// PlanCopy returns the changes needed to copy the source values into the target values. Only the given keys are copied,
+// or all keys if none are given. Target values that are equal are left as is, and target keys that are not copied are
+// removed when replace is true.
+func PlanCopy(source, target []gql.GetConfigTeamEnvironmentConfigValuesConfigValue, keys []string, replace bool) (CopyPlan, error) {
+	return keyvalue.PlanCopy(valueInputs(source), valueInputs(target), func(v gql.ConfigValueInput) string { return v.Name }, keys, replace)
+}
+
+func valueInputs(values []gql.GetConfigTeamEnvironmentConfigValuesConfigValue) []gql.ConfigValueInput {
+	ret := make([]gql.ConfigValueInput, 0, len(values))
+	for _, v := range values {
+		ret = append(ret, gql.ConfigValueInput{Name: v.Name, Value: v.Value, Encoding: v.Encoding})
+	}
+	return ret
+}
+
+// ApplyCopy applies the changes of a copy plan to a config. The config is created with the added values if create is
+// true, and updated one key at a time otherwise.
+func ApplyCopy(ctx context.Context, metadata Metadata, plan CopyPlan, create bool) error {
+	if create {
+		if err := CreateWithValues(ctx, metadata, plan.Add, nil); err != nil {
+			return fmt.Errorf("creating config: %w", err)
+		}
+		return nil
+	}
+
+	for _, v := range plan.Add {
+		if err := addValue(ctx, metadata, v.Name, v.Value, v.Encoding); err != nil {
+			return fmt.Errorf("adding key %q: %w", v.Name, err)
+		}
+	}
+	for _, v := range plan.Update {
+		if err := updateValue(ctx, metadata, v.Name, v.Value, v.Encoding); err != nil {
+			return fmt.Errorf("updating key %q: %w", v.Name, err)
+		}
+	}
+	for _, k := range plan.Remove {
+		if err := RemoveValue(ctx, metadata, k); err != nil {
+			return fmt.Errorf("removing key %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// MaskedSize describes the size of a value without revealing it.
+func MaskedSize(v gql.ConfigValueInput) string {
+	if v.Encoding == gql.ValueEncodingBase64 {
+		if raw, err := base64.StdEncoding.DecodeString(v.Value); err == nil {
+			return fmt.Sprintf("<binary, %d bytes>", len(raw))
+		}
+		return "<binary>"
+	}
+	return fmt.Sprintf("<%d bytes>", len(v.Value))
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func TestPlanCopy(t *testing.T) {
+	t.Parallel()
+
+	type value = gql.GetConfigTeamEnvironmentConfigValuesConfigValue
+	source := []value{
+		{Name: "SAME", Value: "1", Encoding: gql.ValueEncodingPlainText},
+		{Name: "CHANGED", Value: "new", Encoding: gql.ValueEncodingPlainText},
+		{Name: "NEW", Value: "x", Encoding: gql.ValueEncodingPlainText},
+	}
+	target := []value{
+		{Name: "SAME", Value: "1", Encoding: gql.ValueEncodingPlainText},
+		{Name: "CHANGED", Value: "old", Encoding: gql.ValueEncodingPlainText},
+		{Name: "EXTRA", Value: "y", Encoding: gql.ValueEncodingPlainText},
+	}
+
+	got, err := PlanCopy(source, target, nil, true)
+	if err != nil {
+		t.Fatalf("PlanCopy() error = %v", err)
+	}
+	want := CopyPlan{
+		Add:    []gql.ConfigValueInput{{Name: "NEW", Value: "x", Encoding: gql.ValueEncodingPlainText}},
+		Update: []gql.ConfigValueInput{{Name: "CHANGED", Value: "new", Encoding: gql.ValueEncodingPlainText}},
+		Remove: []string{"EXTRA"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanCopy() = %#v, want %#v", got, want)
+	}
+
+	got, err = PlanCopy(source, target, []string{"SAME"}, false)
+	if err != nil {
+		t.Fatalf("PlanCopy() error = %v", err)
+	}
+	if got.Changed() {
+		t.Errorf("PlanCopy() = %#v, want no changes", got)
+	}
+
+	if _, err := PlanCopy(source, target, []string{"MISSING"}, false); err == nil {
+		t.Errorf("PlanCopy() error = nil, want error for missing key")
+	}
+}
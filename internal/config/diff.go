@@ -0,0 +1,38 @@
+package config
+
+import (
+	"github.com/nais/cli/internal/keyvalue"
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+// DiffRow is a key of a config and its state in each environment the config exists in. Equal values have the same
+// label in every environment.
+type DiffRow struct {
+	Config string `json:"config"`
+	keyvalue.DiffRow
+}
+
+// Diff compares the keys and values of a config across environments. configValues maps each environment the config
+// exists in to its values. The rows are sorted by key.
+func Diff(name string, configValues map[string][]gql.GetConfigTeamEnvironmentConfigValuesConfigValue) []DiffRow {
+	rows := keyvalue.Diff(configValues, func(v gql.GetConfigTeamEnvironmentConfigValuesConfigValue) string { return v.Name }, true)
+	ret := make([]DiffRow, 0, len(rows))
+	for _, r := range rows {
+		ret = append(ret, DiffRow{Config: name, DiffRow: r})
+	}
+	return ret
+}
+
+// FormatDiff formats diff rows as a matrix with a column per environment for table rendering. The cell is empty in
+// environments the config does not exist in. The config name is only included when withConfig is true.
+func FormatDiff(rows []DiffRow, environments []string, withConfig bool) [][]string {
+	diff := make([]keyvalue.DiffRow, 0, len(rows))
+	var names []string
+	for _, r := range rows {
+		diff = append(diff, r.DiffRow)
+		if withConfig {
+			names = append(names, r.Config)
+		}
+	}
+	return keyvalue.FormatDiff(diff, environments, "Config", names)
+}
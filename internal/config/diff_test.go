@@ -0,0 +1,29 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	type value = gql.GetConfigTeamEnvironmentConfigValuesConfigValue
+	values := map[string][]value{
+		"dev":  {{Name: "LOG_LEVEL", Value: "debug"}, {Name: "URL", Value: "https://example.com"}},
+		"prod": {{Name: "LOG_LEVEL", Value: "info"}, {Name: "URL", Value: "https://example.com"}, {Name: "PROD_ONLY", Value: "p"}},
+	}
+
+	got := FormatDiff(Diff("my-config", values), []string{"dev", "prod"}, false)
+	want := [][]string{
+		{"Key", "dev", "prod", "Status"},
+		{"LOG_LEVEL", "A", "B", "2 different values"},
+		{"PROD_ONLY", "-", "A", "missing in dev"},
+		{"URL", "A", "A", "same"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FormatDiff() = %v, want %v", got, want)
+	}
+}
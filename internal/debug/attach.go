@@ -0,0 +1,56 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nais/cli/internal/k8s"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// Attacher connects the local terminal to a running container.
+type Attacher interface {
+	Attach(ctx context.Context, namespace, podName, containerName string) error
+}
+
+type remoteAttacher struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+// NewAttacher returns an [Attacher] that streams the session through the Kubernetes API.
+func NewAttacher(client kubernetes.Interface, config *rest.Config) Attacher {
+	return &remoteAttacher{
+		client: client,
+		config: config,
+	}
+}
+
+func (a *remoteAttacher) Attach(ctx context.Context, namespace, podName, containerName string) error {
+	req := a.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("attach").
+		VersionedParams(&core_v1.PodAttachOptions{
+			Container: containerName,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, scheme.ParameterCodec)
+
+	exec, err := k8s.NewRemoteExecutor(a.config, req.URL())
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.StreamTerminal(ctx, exec, os.Stdin, os.Stdout); err != nil {
+		return fmt.Errorf("attaching to %s/%s: %w", podName, containerName, err)
+	}
+
+	return nil
+}
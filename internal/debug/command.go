@@ -1,23 +1,29 @@
 package debug
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nais/cli/internal/debug/command/flag"
 	"github.com/nais/cli/internal/k8s"
+	"github.com/nais/cli/internal/naisapi"
 	"github.com/nais/naistrix"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 const debugImageDefault = "europe-north1-docker.pkg.dev/nais-io/nais/images/debug:latest"
 
-func Run(workloadName, team, environment string, flags *flag.Debug, out *naistrix.OutputWriter) error {
-	clientSet, err := SetupClient(team, environment)
+func Run(ctx context.Context, workloadName, environment string, command []string, flags *flag.Debug, out *naistrix.OutputWriter) error {
+	clientSet, config, err := SetupClient(environment)
 	if err != nil {
 		return err
 	}
 
-	dg := Setup(clientSet, flags.DebugSticky, workloadName, debugImageDefault, flags.ByPod, out)
+	dg := Setup(ctx, clientSet, NewAttacher(clientSet, config), flags, workloadName, command, out)
+	if user, err := naisapi.GetAuthenticatedUser(ctx); err == nil {
+		dg.createdBy = user.Email()
+	}
 	if err := dg.Debug(); err != nil {
 		return fmt.Errorf("debugging instance: %w", err)
 	}
@@ -25,13 +31,16 @@ func Run(workloadName, team, environment string, flags *flag.Debug, out *naistri
 	return nil
 }
 
-func SetupClient(team, environment string) (kubernetes.Interface, error) {
-	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
-	client.CurrentNamespace = team
-	clientSet, err := k8s.SetupClientGo(environment)
+func SetupClient(environment string) (kubernetes.Interface, *rest.Config, error) {
+	config, err := k8s.SetupRestConfig(environment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("load kubeclient configuration: %w", err)
 	}
 
-	return clientSet, nil
+	return clientSet, config, nil
 }
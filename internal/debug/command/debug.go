@@ -13,7 +13,10 @@ import (
 
 func Debug(parentFlags *flags.GlobalFlags) *naistrix.Command {
 	stickyFlags := &flag.DebugSticky{GlobalFlags: parentFlags}
-	debugFlags := &flag.Debug{DebugSticky: stickyFlags}
+	debugFlags := &flag.Debug{
+		DebugSticky: stickyFlags,
+		Profile:     flag.ProfileRestricted,
+	}
 	return &naistrix.Command{
 		Name:  "debug",
 		Title: "Create and attach to a debug container.",
@@ -23,15 +26,49 @@ func Debug(parentFlags *flags.GlobalFlags) *naistrix.Command {
 			To debug a live pod, run the command without the "--copy" flag.
 
 			You can only reconnect to the debug session if the pod is running.
+
+			The debug container is created through the Kubernetes API, so kubectl is not required.
+
+			To run a command instead of the default entrypoint of the image, give the command and its arguments after "--".
+
+			Use "nais debug list" to see active debug sessions, and "nais debug tidy" to delete pod copies that are no longer needed.
 		`),
 		Args: []naistrix.Argument{
 			{Name: "app_name"},
+			{Name: "command", Repeatable: true},
+		},
+		Flags:       debugFlags,
+		StickyFlags: stickyFlags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(debugFlags),
+			func(context.Context, *naistrix.Arguments) error {
+				return debugFlags.Validate()
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Attach a debug container to a running instance of my-app.",
+				Command:     "my-app --environment dev",
+			},
+			{
+				Description: "Debug a copy of the pod, leaving the original untouched.",
+				Command:     "my-app --environment dev --copy",
+			},
+			{
+				Description: "Debug a copy of the pod that stops after two hours and is removed by 'nais debug tidy'.",
+				Command:     "my-app --environment dev --copy --ttl 2h",
+			},
+			{
+				Description: "Use a custom image and the netadmin profile for network troubleshooting.",
+				Command:     "my-app --environment dev --image nicolaka/netshoot --profile netadmin -- bash",
+			},
 		},
-		Flags:        debugFlags,
-		StickyFlags:  stickyFlags,
-		ValidateFunc: validation.RequireTeamAndEnvironment(debugFlags),
 		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
-			return debug.Run(args.Get("app_name"), debugFlags.Team, string(debugFlags.Environment), debugFlags, out)
+			return debug.Run(ctx, args.Get("app_name"), string(debugFlags.Environment), args.GetRepeatable("command"), debugFlags, out)
+		},
+		SubCommands: []*naistrix.Command{
+			list(stickyFlags),
+			tidy(stickyFlags),
 		},
 	}
 }
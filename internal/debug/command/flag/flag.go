@@ -1,7 +1,13 @@
 package flag
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
 )
 
 type (
@@ -13,9 +19,75 @@ type (
 
 type Debug struct {
 	*DebugSticky
-	ByPod bool `name:"by-pod" short:"b" usage:"Attach to a specific |BY-POD| in a workload."`
+	ByPod   bool          `name:"by-pod" short:"b" usage:"Attach to a specific |BY-POD| in a workload."`
+	Image   string        `name:"image" usage:"Container |IMAGE| to use for the debug container. Defaults to the Nais debug image."`
+	Profile Profile       `name:"profile" usage:"Security |PROFILE| for the debug container (general, restricted or netadmin). Defaults to restricted."`
+	TTL     time.Duration `name:"ttl" usage:"Stop the pod copy after |DURATION| and mark it for removal by 'nais debug tidy'. Only used with --copy. Examples: 30m, 2h."`
+}
+
+func (d *Debug) Validate() error {
+	if !d.Profile.IsValid() {
+		return fmt.Errorf("invalid profile %q, must be one of: %v", d.Profile, AllProfiles)
+	}
+	if d.TTL < 0 {
+		return fmt.Errorf("--ttl must be a positive duration")
+	}
+	if d.TTL > 0 && !d.Copy {
+		return fmt.Errorf("--ttl can only be used together with --copy")
+	}
+	return nil
+}
+
+type DebugList struct {
+	*DebugSticky
+	Output Output `name:"output" short:"o" usage:"Format output (table or json)."`
 }
 
 type DebugTidy struct {
 	*DebugSticky
+	OlderThan time.Duration `name:"older-than" usage:"Also delete pod copies older than |DURATION| that have not expired. Expired copies are always deleted. Examples: 30m, 2h."`
+	Yes       bool          `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+func (d *DebugTidy) Validate() error {
+	if d.OlderThan < 0 {
+		return fmt.Errorf("--older-than must be a positive duration")
+	}
+	return nil
+}
+
+type Output string
+
+var _ naistrix.FlagAutoCompleter = (*Output)(nil)
+
+func (o *Output) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"table", "json"}, "Available output formats."
+}
+
+// Profile is the security profile applied to the debug container, mirroring the profiles of `kubectl debug`.
+type Profile string
+
+const (
+	// ProfileGeneral adds the SYS_PTRACE capability, allowing debuggers and tracers to inspect other processes.
+	ProfileGeneral Profile = "general"
+	// ProfileRestricted runs the container as a non-root user without any capabilities.
+	ProfileRestricted Profile = "restricted"
+	// ProfileNetAdmin adds the NET_ADMIN and NET_RAW capabilities for network troubleshooting.
+	ProfileNetAdmin Profile = "netadmin"
+)
+
+var AllProfiles = []Profile{ProfileGeneral, ProfileRestricted, ProfileNetAdmin}
+
+var _ naistrix.FlagAutoCompleter = (*Profile)(nil)
+
+func (p *Profile) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	profiles := make([]string, 0, len(AllProfiles))
+	for _, profile := range AllProfiles {
+		profiles = append(profiles, string(profile))
+	}
+	return profiles, "Available debug profiles."
+}
+
+func (p *Profile) IsValid() bool {
+	return slices.Contains(AllProfiles, *p)
 }
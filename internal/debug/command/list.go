@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nais/cli/internal/debug"
+	"github.com/nais/cli/internal/debug/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+)
+
+func list(parentFlags *flag.DebugSticky) *naistrix.Command {
+	flags := &flag.DebugList{DebugSticky: parentFlags}
+	return &naistrix.Command{
+		Name:        "list",
+		Title:       "List debug sessions.",
+		Description: "Lists all debug pod copies and ephemeral debug containers in the team namespace, with their age and who created them.",
+		Flags:       flags,
+		Args: []naistrix.Argument{
+			{Name: "app_name", Repeatable: true},
+		},
+		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
+		Examples: []naistrix.Example{
+			{
+				Description: "List all debug sessions for the team in dev.",
+				Command:     "--environment dev",
+			},
+			{
+				Description: "List debug sessions for my-app.",
+				Command:     "my-app --environment dev",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			client, _, err := debug.SetupClient(string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			sessions, err := debug.ListSessions(ctx, client, flags.Team, args.GetRepeatable("app_name")...)
+			if err != nil {
+				return fmt.Errorf("fetching debug sessions: %w", err)
+			}
+
+			if flags.Output == "json" {
+				return out.JSON(output.JSONWithPrettyOutput()).Render(sessions)
+			}
+
+			if len(sessions) == 0 {
+				out.Println("No debug sessions found.")
+				return nil
+			}
+
+			return out.Table().Render(sessions)
+		},
+	}
+}
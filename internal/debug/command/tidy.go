@@ -0,0 +1,91 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/debug"
+	"github.com/nais/cli/internal/debug/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func tidy(parentFlags *flag.DebugSticky) *naistrix.Command {
+	flags := &flag.DebugTidy{DebugSticky: parentFlags}
+	return &naistrix.Command{
+		Name:  "tidy",
+		Title: "Delete debug pod copies.",
+		Description: heredoc.Doc(`
+			Deletes the pod copies created by "nais debug --copy". By default, only copies that were created with "--ttl" and have expired are deleted. Use "--older-than" to also delete copies older than the given duration, which may still be in use by someone else.
+
+			Ephemeral debug containers cannot be removed from a running pod. They disappear when the pod is replaced, e.g. by "nais app restart".
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "app_name", Repeatable: true},
+		},
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Delete expired debug pod copies for the team in dev.",
+				Command:     "--environment dev",
+			},
+			{
+				Description: "Delete copies of my-app that are older than two hours.",
+				Command:     "my-app --environment dev --older-than 2h",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			client, _, err := debug.SetupClient(string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			sessions, err := debug.ListSessions(ctx, client, flags.Team, args.GetRepeatable("app_name")...)
+			if err != nil {
+				return fmt.Errorf("fetching debug sessions: %w", err)
+			}
+
+			copies := debug.StaleCopies(sessions, flags.OlderThan, time.Now())
+			if len(copies) == 0 {
+				if flags.OlderThan == 0 {
+					out.Println("No expired debug pod copies to delete. Use --older-than to delete copies that have not expired.")
+					return nil
+				}
+				out.Println("No debug pod copies to delete.")
+				return nil
+			}
+
+			out.Println("The following debug pod copies will be deleted:")
+			for _, c := range copies {
+				out.Printf("  %s (age %s, created by %s)\n", c.Pod, c.Age, cmp.Or(c.CreatedBy, "unknown"))
+			}
+
+			if !flags.Yes {
+				ok, err := input.Confirm(fmt.Sprintf("Delete %d debug pod copies?", len(copies)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if err := debug.DeleteCopies(ctx, client, flags.Team, copies); err != nil {
+				return err
+			}
+
+			out.Successf("Deleted %d debug pod copies.\n", len(copies))
+			return nil
+		},
+	}
+}
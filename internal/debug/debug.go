@@ -1,11 +1,11 @@
 package debug
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"strings"
+	"maps"
 	"time"
 
 	"github.com/nais/cli/internal/debug/command/flag"
@@ -14,32 +14,47 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 )
 
 const (
 	debuggerSuffix               = "nais-debugger"
 	debuggerContainerDefaultName = "debugger"
+
+	containerPollInterval = time.Second
+	containerStartTimeout = 2 * time.Minute
 )
 
 type Debug struct {
 	ctx          context.Context
 	client       kubernetes.Interface
+	attacher     Attacher
 	flags        *flag.DebugSticky
 	workloadName string
 	debugImage   string
+	profile      flag.Profile
+	command      []string
 	byPod        bool
+	ttl          time.Duration
+	createdBy    string
 	out          *naistrix.OutputWriter
 }
 
-func Setup(client kubernetes.Interface, flags *flag.DebugSticky, workloadName, debugImage string, byPod bool, out *naistrix.OutputWriter) *Debug {
+func Setup(ctx context.Context, client kubernetes.Interface, attacher Attacher, flags *flag.Debug, workloadName string, command []string, out *naistrix.OutputWriter) *Debug {
 	return &Debug{
-		ctx:          context.Background(),
+		ctx:          ctx,
 		client:       client,
-		flags:        flags,
+		attacher:     attacher,
+		flags:        flags.DebugSticky,
 		workloadName: workloadName,
-		debugImage:   debugImage,
-		byPod:        byPod,
+		debugImage:   cmp.Or(flags.Image, debugImageDefault),
+		profile:      cmp.Or(flags.Profile, flag.ProfileRestricted),
+		command:      command,
+		byPod:        flags.ByPod,
+		ttl:          flags.TTL,
 		out:          out,
 	}
 }
@@ -51,7 +66,7 @@ func (d *Debug) getPodsForWorkload() (*core_v1.PodList, error) {
 	podList, err = d.client.CoreV1().Pods(d.flags.Team).List(d.ctx, metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("app.kubernetes.io/name=%s", d.workloadName),
 	})
-	if len(podList.Items) == 0 {
+	if err == nil && len(podList.Items) == 0 {
 		podList, err = d.client.CoreV1().Pods(d.flags.Team).List(d.ctx, metav1.ListOptions{
 			LabelSelector: fmt.Sprintf("app=%s", d.workloadName),
 		})
@@ -67,142 +82,220 @@ func debuggerContainerName(podName string) string {
 }
 
 func (d *Debug) debugPod(podName string) error {
-	const maxRetries = 6
-	const pollInterval = 5
-
 	if d.flags.Copy {
 		pN := debuggerContainerName(podName)
 		_, err := d.client.CoreV1().Pods(d.flags.Team).Get(d.ctx, pN, metav1.GetOptions{})
 		if err == nil {
 			d.out.Infof("%s already exists, trying to attach...\n", pN)
-
-			// Polling loop to check if the debugger container is running
-			for i := range maxRetries {
-				d.out.Infof("Attempt %d/%d: Time remaining: %d seconds\n", i+1, maxRetries, (maxRetries-i)*pollInterval)
-				pod, err := d.client.CoreV1().Pods(d.flags.Team).Get(d.ctx, pN, metav1.GetOptions{})
-				if err != nil {
-					return fmt.Errorf("failed to get debug pod copy %s: %v", pN, err)
-				}
-
-				for _, c := range pod.Status.ContainerStatuses {
-					if c.Name == debuggerContainerDefaultName && c.State.Running != nil {
-						d.out.Successln("Container is running. Attaching...")
-						return d.attachToExistingDebugContainer(pN)
-					}
-				}
-				time.Sleep(time.Duration(pollInterval) * time.Second)
+			if err := d.waitForContainer(pN, debuggerContainerDefaultName); err != nil {
+				return err
 			}
-
-			// If the loop finishes without finding the running container
-			return fmt.Errorf("container did not start within the expected time")
+			return d.attach(pN, debuggerContainerDefaultName)
 		} else if !k8serrors.IsNotFound(err) {
 			return fmt.Errorf("failed to check for existing debug pod copy %s: %v", pN, err)
 		}
-	} else {
-		pod, err := d.client.CoreV1().Pods(d.flags.Team).Get(d.ctx, podName, metav1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to get pod %s: %v", podName, err)
-		}
 
-		if len(pod.Spec.EphemeralContainers) > 0 {
-			d.out.Warnf("The container %s already has %d terminated debug containers.\n", podName, len(pod.Spec.EphemeralContainers))
-			d.out.Infof("Please consider using 'nais debug tidy %s' to clean up\n", d.workloadName)
-		}
+		return d.createDebugPodCopy(podName)
 	}
 
-	return d.createDebugPod(podName)
+	pod, err := d.client.CoreV1().Pods(d.flags.Team).Get(d.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s: %v", podName, err)
+	}
+
+	if len(pod.Spec.EphemeralContainers) > 0 {
+		d.out.Warnf("The container %s already has %d terminated debug containers.\n", podName, len(pod.Spec.EphemeralContainers))
+		d.out.Infoln("Ephemeral containers are removed when the pod is replaced, e.g. by 'nais app restart'. Run 'nais debug list' to see all debug sessions.")
+	}
+
+	return d.createEphemeralContainer(pod)
 }
 
-func (d *Debug) attachToExistingDebugContainer(podName string) error {
-	cmd := exec.Command(
-		"kubectl",
-		"attach",
-		"-n", d.flags.Team,
-		fmt.Sprintf("pod/%s", podName),
-		"-c", debuggerContainerDefaultName,
-		"-i",
-		"-t",
-	)
+// createEphemeralContainer adds a debug container to a running pod by patching its ephemeralcontainers subresource,
+// and attaches to it once it is running.
+func (d *Debug) createEphemeralContainer(pod *core_v1.Pod) error {
+	container := core_v1.EphemeralContainer{
+		EphemeralContainerCommon: core_v1.EphemeralContainerCommon{
+			Name:                     fmt.Sprintf("%s-%s", debuggerContainerDefaultName, utilrand.String(5)),
+			Image:                    d.debugImage,
+			Command:                  d.command,
+			ImagePullPolicy:          core_v1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: core_v1.TerminationMessageFallbackToLogsOnError,
+			SecurityContext:          securityContext(d.profile),
+			Env:                      d.debuggerEnv(),
+		},
+		TargetContainerName: d.workloadName,
+	}
 
-	if d.flags.Environment != "" {
-		cmd.Args = append(cmd.Args, "--context", string(d.flags.Environment))
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"ephemeralContainers": []core_v1.EphemeralContainer{container},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating debug container patch: %w", err)
 	}
 
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	_, err = d.client.CoreV1().Pods(pod.Namespace).Patch(d.ctx, pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "ephemeralcontainers")
+	if err != nil {
+		return fmt.Errorf("failed to add debug container to pod %s: %w", pod.Name, err)
+	}
+
+	d.out.Infoln("Debugging container created...")
+	d.out.Infof("Using debugger image %s\n", d.debugImage)
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start attach command: %v", err)
+	if err := d.waitForContainer(pod.Name, container.Name); err != nil {
+		return err
 	}
-	d.out.Successf("Attached to pod %s\n", podName)
 
-	if err := cmd.Wait(); err != nil {
-		return fmt.Errorf("attach command failed: %v", err)
+	if err := d.attach(pod.Name, container.Name); err != nil {
+		return err
 	}
 
+	d.out.Infoln("Debugging container exited")
 	return nil
 }
 
-func (d *Debug) createDebugPod(podName string) error {
-	args := []string{
-		"debug",
-		"-n", d.flags.Team,
-		fmt.Sprintf("pod/%s", podName),
-		"-it",
-		"--stdin",
-		"--tty",
-		"--profile=restricted",
-		"-q",
-		"--image", d.debugImage,
+// createDebugPodCopy creates a copy of the pod with an extra debug container sharing its process namespace, and
+// attaches to the debug container once it is running.
+func (d *Debug) createDebugPodCopy(podName string) error {
+	pod, err := d.client.CoreV1().Pods(d.flags.Team).Get(d.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s: %v", podName, err)
 	}
 
-	if d.flags.Environment != "" {
-		args = append(args, "--context", string(d.flags.Environment))
+	annotations := maps.Clone(pod.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
-
-	if d.flags.Copy {
-		args = append(
-			args,
-			"--copy-to", debuggerContainerName(podName),
-			"-c", "debugger",
-		)
-	} else {
-		args = append(args,
-			"--target", d.workloadName)
+	if d.createdBy != "" {
+		annotations[annotationCreatedBy] = d.createdBy
 	}
 
-	cmd := exec.Command("kubectl", args...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	podCopy := &core_v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      debuggerContainerName(podName),
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				labelCopy:     "true",
+				labelWorkload: d.workloadName,
+			},
+			Annotations: annotations,
+		},
+		Spec: *pod.Spec.DeepCopy(),
+	}
+	prepareCopy(&podCopy.Spec)
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start debug command: %v", err)
+	// With a TTL the copy stops running, and releases its quota, when the deadline is reached. The pod itself is
+	// removed by `nais debug tidy`, which deletes expired copies regardless of their age.
+	if d.ttl > 0 {
+		podCopy.Spec.ActiveDeadlineSeconds = new(int64(d.ttl.Seconds()))
+		podCopy.Annotations[annotationExpiresAt] = time.Now().Add(d.ttl).UTC().Format(time.RFC3339)
 	}
+	podCopy.Spec.Containers = append(podCopy.Spec.Containers, core_v1.Container{
+		Name:                     debuggerContainerDefaultName,
+		Image:                    d.debugImage,
+		Command:                  d.command,
+		ImagePullPolicy:          core_v1.PullIfNotPresent,
+		Stdin:                    true,
+		TTY:                      true,
+		TerminationMessagePolicy: core_v1.TerminationMessageFallbackToLogsOnError,
+		SecurityContext:          securityContext(d.profile),
+		Env:                      d.debuggerEnv(),
+	})
 
-	if d.flags.Copy {
-		d.out.Infof("Debugging pod copy created, enable process namespace sharing in %s\n", debuggerContainerName(podName))
-	} else {
-		d.out.Infoln("Debugging container created...")
+	if _, err := d.client.CoreV1().Pods(d.flags.Team).Create(d.ctx, podCopy, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create debug pod copy %s: %w", podCopy.Name, err)
 	}
+
+	d.out.Infof("Debugging pod copy %s created with process namespace sharing enabled\n", podCopy.Name)
 	d.out.Infof("Using debugger image %s\n", d.debugImage)
 
-	if err := cmd.Wait(); err != nil {
-		if strings.Contains(err.Error(), "exit status 1") {
-			d.out.Infoln("Debugging container exited")
-			return nil
-		}
-		return fmt.Errorf("debug command failed: %v", err)
+	if err := d.waitForContainer(podCopy.Name, debuggerContainerDefaultName); err != nil {
+		return err
 	}
 
-	if d.flags.Copy {
-		d.out.Infof("Run 'nais debug -cp %s' command to attach to the debug pod\n", d.workloadName)
+	if err := d.attach(podCopy.Name, debuggerContainerDefaultName); err != nil {
+		return err
 	}
 
+	d.out.Infof("Run 'nais debug --copy %s' command to attach to the debug pod\n", d.workloadName)
 	return nil
 }
 
+// debuggerEnv records who created the debug container, so that forgotten sessions can be traced by `nais debug list`.
+func (d *Debug) debuggerEnv() []core_v1.EnvVar {
+	if d.createdBy == "" {
+		return nil
+	}
+	return []core_v1.EnvVar{{Name: envCreatedBy, Value: d.createdBy}}
+}
+
+// waitForContainer polls the pod until the named container, regular or ephemeral, is running.
+func (d *Debug) waitForContainer(podName, containerName string) error {
+	d.out.Infof("Waiting for container %s in %s to start...\n", containerName, podName)
+
+	err := wait.PollUntilContextTimeout(d.ctx, containerPollInterval, containerStartTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := d.client.CoreV1().Pods(d.flags.Team).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get pod %s: %w", podName, err)
+		}
+
+		statuses := append(pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name != containerName {
+				continue
+			}
+
+			switch {
+			case status.State.Running != nil:
+				return true, nil
+			case status.State.Terminated != nil:
+				return false, fmt.Errorf("container %s terminated: %s", containerName, status.State.Terminated.Reason)
+			case status.State.Waiting != nil && isImagePullFailure(status.State.Waiting.Reason):
+				return false, fmt.Errorf("unable to pull image for container %s: %s", containerName, status.State.Waiting.Message)
+			case status.State.Waiting != nil && isCreateFailure(status.State.Waiting.Reason):
+				return false, fmt.Errorf("unable to create container %s: %s", containerName, status.State.Waiting.Message)
+			}
+		}
+
+		return false, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("container did not start within the expected time")
+	}
+
+	return err
+}
+
+func isImagePullFailure(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+		return true
+	default:
+		return false
+	}
+}
+
+// isCreateFailure returns true if the container cannot be created as configured, e.g. when the restricted profile
+// requires a non-root user and the image runs as root. The kubelet keeps retrying, so the container never starts.
+func isCreateFailure(reason string) bool {
+	switch reason {
+	case "CreateContainerConfigError", "CreateContainerError":
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *Debug) attach(podName, containerName string) error {
+	d.out.Successf("Attaching to %s in pod %s\n", containerName, podName)
+	d.out.Infoln("If you don't see a command prompt, try pressing enter.")
+	return d.attacher.Attach(d.ctx, d.flags.Team, podName, containerName)
+}
+
 func (d *Debug) Debug() error {
 	pods, err := d.getPodsForWorkload()
 	if err != nil {
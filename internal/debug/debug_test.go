@@ -0,0 +1,204 @@
+package debug
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nais/cli/internal/debug/command/flag"
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+type attachCall struct {
+	namespace, pod, container string
+}
+
+type fakeAttacher struct {
+	calls []attachCall
+}
+
+func (f *fakeAttacher) Attach(_ context.Context, namespace, podName, containerName string) error {
+	f.calls = append(f.calls, attachCall{namespace: namespace, pod: podName, container: containerName})
+	return nil
+}
+
+// fakeClient returns a clientset where every container in a fetched pod is reported as running.
+func fakeClient(objects ...runtime.Object) *fake.Clientset {
+	client := fake.NewClientset(objects...)
+	client.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+		obj, err := client.Tracker().Get(get.GetResource(), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+
+		pod := obj.(*core_v1.Pod).DeepCopy()
+		running := core_v1.ContainerState{Running: &core_v1.ContainerStateRunning{}}
+		pod.Status.ContainerStatuses = nil
+		for _, c := range pod.Spec.Containers {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, core_v1.ContainerStatus{Name: c.Name, State: running})
+		}
+		pod.Status.EphemeralContainerStatuses = nil
+		for _, c := range pod.Spec.EphemeralContainers {
+			pod.Status.EphemeralContainerStatuses = append(pod.Status.EphemeralContainerStatuses, core_v1.ContainerStatus{Name: c.Name, State: running})
+		}
+		return true, pod, nil
+	})
+	return client
+}
+
+func appPod() *core_v1.Pod {
+	return &core_v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-abc123",
+			Namespace: "my-team",
+			Labels:    map[string]string{"app": "my-app"},
+		},
+		Spec: core_v1.PodSpec{
+			NodeName: "node-1",
+			Containers: []core_v1.Container{{
+				Name:          "my-app",
+				Image:         "my-app:1",
+				LivenessProbe: &core_v1.Probe{},
+			}},
+		},
+	}
+}
+
+func debugFlags(copy bool, profile flag.Profile) *flag.Debug {
+	return &flag.Debug{
+		DebugSticky: &flag.DebugSticky{
+			GlobalFlags: &flags.GlobalFlags{AdditionalFlags: &flags.AdditionalFlags{Team: "my-team"}},
+			Copy:        copy,
+		},
+		Profile: profile,
+	}
+}
+
+func TestDebugEphemeralContainer(t *testing.T) {
+	ctx := context.Background()
+	client := fakeClient(appPod())
+	attacher := &fakeAttacher{}
+	out := naistrix.NewOutputWriter(io.Discard, new(naistrix.Count))
+
+	d := Setup(ctx, client, attacher, debugFlags(false, flag.ProfileNetAdmin), "my-app", []string{"sh", "-c", "ls /tmp"}, out)
+	if err := d.debugPod("my-app-abc123"); err != nil {
+		t.Fatalf("debugPod() error = %v", err)
+	}
+
+	pod, err := client.Tracker().Get(core_v1.SchemeGroupVersion.WithResource("pods"), "my-team", "my-app-abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ephemeral := pod.(*core_v1.Pod).Spec.EphemeralContainers
+	if len(ephemeral) != 1 {
+		t.Fatalf("expected 1 ephemeral container, got %d", len(ephemeral))
+	}
+
+	c := ephemeral[0]
+	if c.Image != debugImageDefault {
+		t.Errorf("expected image %q, got %q", debugImageDefault, c.Image)
+	}
+	if c.TargetContainerName != "my-app" {
+		t.Errorf("expected target container %q, got %q", "my-app", c.TargetContainerName)
+	}
+	if want := []string{"sh", "-c", "ls /tmp"}; !slices.Equal(c.Command, want) {
+		t.Errorf("unexpected command %v", c.Command)
+	}
+	if caps := c.SecurityContext.Capabilities.Add; len(caps) != 2 || caps[0] != "NET_ADMIN" {
+		t.Errorf("unexpected capabilities %v", caps)
+	}
+
+	if len(attacher.calls) != 1 {
+		t.Fatalf("expected 1 attach call, got %d", len(attacher.calls))
+	}
+	if want := (attachCall{namespace: "my-team", pod: "my-app-abc123", container: c.Name}); attacher.calls[0] != want {
+		t.Errorf("expected attach call %+v, got %+v", want, attacher.calls[0])
+	}
+}
+
+func TestDebugPodCopy(t *testing.T) {
+	ctx := context.Background()
+	client := fakeClient(appPod())
+	attacher := &fakeAttacher{}
+	out := naistrix.NewOutputWriter(io.Discard, new(naistrix.Count))
+
+	f := debugFlags(true, flag.ProfileRestricted)
+	f.Image = "busybox"
+	d := Setup(ctx, client, attacher, f, "my-app", nil, out)
+	if err := d.debugPod("my-app-abc123"); err != nil {
+		t.Fatalf("debugPod() error = %v", err)
+	}
+
+	copied, err := client.CoreV1().Pods("my-team").Get(ctx, "my-app-abc123-nais-debugger", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected pod copy to be created: %v", err)
+	}
+
+	if _, ok := copied.Labels["app"]; ok {
+		t.Errorf("expected pod copy not to match the workload's selectors, got labels %v", copied.Labels)
+	}
+	if copied.Labels[labelCopy] != "true" || copied.Labels[labelWorkload] != "my-app" {
+		t.Errorf("expected pod copy to be labelled as a debug copy of my-app, got %v", copied.Labels)
+	}
+	if copied.Spec.NodeName != "" {
+		t.Errorf("expected node name to be cleared, got %q", copied.Spec.NodeName)
+	}
+	if copied.Spec.ShareProcessNamespace == nil || !*copied.Spec.ShareProcessNamespace {
+		t.Error("expected process namespace sharing to be enabled")
+	}
+	if copied.Spec.Containers[0].LivenessProbe != nil {
+		t.Error("expected probes to be removed from the copy")
+	}
+
+	if len(copied.Spec.Containers) != 2 {
+		t.Fatalf("expected 2 containers, got %d", len(copied.Spec.Containers))
+	}
+	debugger := copied.Spec.Containers[1]
+	if debugger.Name != debuggerContainerDefaultName || debugger.Image != "busybox" {
+		t.Errorf("unexpected debugger container %s (%s)", debugger.Name, debugger.Image)
+	}
+	if sc := debugger.SecurityContext; sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+		t.Error("expected restricted profile to run as non-root")
+	}
+
+	want := attachCall{namespace: "my-team", pod: "my-app-abc123-nais-debugger", container: debuggerContainerDefaultName}
+	if len(attacher.calls) != 1 || attacher.calls[0] != want {
+		t.Errorf("expected attach call %+v, got %+v", want, attacher.calls)
+	}
+
+	// Debugging the same pod again reattaches to the existing copy.
+	if err := d.debugPod("my-app-abc123"); err != nil {
+		t.Fatalf("debugPod() error = %v", err)
+	}
+	if len(attacher.calls) != 2 || attacher.calls[1] != want {
+		t.Errorf("expected reattach call %+v, got %+v", want, attacher.calls)
+	}
+}
+
+func TestWaitForContainerCreateFailure(t *testing.T) {
+	pod := appPod()
+	pod.Status.EphemeralContainerStatuses = []core_v1.ContainerStatus{{
+		Name: "debugger-abcde",
+		State: core_v1.ContainerState{Waiting: &core_v1.ContainerStateWaiting{
+			Reason:  "CreateContainerConfigError",
+			Message: "container has runAsNonRoot and image will run as root",
+		}},
+	}}
+	out := naistrix.NewOutputWriter(io.Discard, new(naistrix.Count))
+
+	d := Setup(context.Background(), fake.NewClientset(pod), &fakeAttacher{}, debugFlags(false, flag.ProfileRestricted), "my-app", nil, out)
+	err := d.waitForContainer(pod.Name, "debugger-abcde")
+	if err == nil || !strings.Contains(err.Error(), "image will run as root") {
+		t.Errorf("waitForContainer() error = %v, want the container message", err)
+	}
+}
@@ -0,0 +1,50 @@
+package debug
+
+import (
+	"github.com/nais/cli/internal/debug/command/flag"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// securityContext returns the security context for a debug container using the given profile.
+func securityContext(profile flag.Profile) *core_v1.SecurityContext {
+	switch profile {
+	case flag.ProfileGeneral:
+		return &core_v1.SecurityContext{
+			Capabilities: &core_v1.Capabilities{
+				Add: []core_v1.Capability{"SYS_PTRACE"},
+			},
+		}
+	case flag.ProfileNetAdmin:
+		return &core_v1.SecurityContext{
+			Capabilities: &core_v1.Capabilities{
+				Add: []core_v1.Capability{"NET_ADMIN", "NET_RAW"},
+			},
+		}
+	default:
+		return &core_v1.SecurityContext{
+			RunAsNonRoot:             new(true),
+			AllowPrivilegeEscalation: new(false),
+			Capabilities: &core_v1.Capabilities{
+				Drop: []core_v1.Capability{"ALL"},
+			},
+			SeccompProfile: &core_v1.SeccompProfile{
+				Type: core_v1.SeccompProfileTypeRuntimeDefault,
+			},
+		}
+	}
+}
+
+// prepareCopy modifies a pod spec copy so that it is suitable for debugging: the copy must not be scheduled on the
+// original node by name, must share its process namespace with the debugger, and must not be restarted by failing
+// probes while someone is poking around in it.
+func prepareCopy(spec *core_v1.PodSpec) {
+	spec.NodeName = ""
+	spec.ShareProcessNamespace = new(true)
+	spec.EphemeralContainers = nil
+
+	for i := range spec.Containers {
+		spec.Containers[i].LivenessProbe = nil
+		spec.Containers[i].ReadinessProbe = nil
+		spec.Containers[i].StartupProbe = nil
+	}
+}
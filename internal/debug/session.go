@@ -0,0 +1,201 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// labelCopy marks pods created by `nais debug --copy`, so that they can be found and garbage-collected.
+	labelCopy = "nais.io/debug-copy"
+	// labelWorkload holds the name of the workload a debug pod copy was made from.
+	labelWorkload = "nais.io/debug-workload"
+	// annotationCreatedBy holds the email of the user who created a debug pod copy.
+	annotationCreatedBy = "nais.io/debug-created-by"
+	// annotationExpiresAt holds the time, in RFC3339, after which a debug pod copy may be deleted.
+	annotationExpiresAt = "nais.io/debug-expires-at"
+
+	// envCreatedBy is set on every debug container, as ephemeral containers cannot be annotated.
+	envCreatedBy = "NAIS_DEBUG_CREATED_BY"
+)
+
+type SessionKind string
+
+const (
+	SessionKindCopy      SessionKind = "copy"
+	SessionKindEphemeral SessionKind = "ephemeral"
+)
+
+// Age is a time.Time that renders as relative time in table output (e.g. "3h", "in 20m") and as RFC3339 in JSON
+// output.
+type Age time.Time
+
+func (a Age) String() string {
+	if time.Time(a).IsZero() {
+		return ""
+	}
+	d := time.Since(time.Time(a))
+	if d < 0 {
+		return "in " + duration.HumanDuration(-d)
+	}
+	return duration.HumanDuration(d)
+}
+
+func (a Age) MarshalJSON() ([]byte, error) {
+	if time.Time(a).IsZero() {
+		return []byte(`""`), nil
+	}
+	return fmt.Appendf(nil, "%q", time.Time(a).Format(time.RFC3339)), nil
+}
+
+// Session is a debug pod copy or an ephemeral debug container in a running pod.
+type Session struct {
+	Kind      SessionKind `heading:"Kind" json:"kind"`
+	Workload  string      `heading:"Workload" json:"workload"`
+	Pod       string      `heading:"Pod" json:"pod"`
+	Container string      `heading:"Container" json:"container"`
+	State     string      `heading:"State" json:"state"`
+	Age       Age         `heading:"Age" json:"created"`
+	CreatedBy string      `heading:"Created By" json:"created_by"`
+	ExpiresAt Age         `heading:"Expires" json:"expires_at"`
+}
+
+// Expired reports whether the session has passed the expiry set with `nais debug --ttl`.
+func (s Session) Expired(now time.Time) bool {
+	expires := time.Time(s.ExpiresAt)
+	return !expires.IsZero() && now.After(expires)
+}
+
+// ListSessions returns all debug sessions in the team namespace. If workloads are given, only sessions belonging to
+// those workloads are returned.
+func ListSessions(ctx context.Context, client kubernetes.Interface, team string, workloads ...string) ([]Session, error) {
+	pods, err := client.CoreV1().Pods(team).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+
+	var sessions []Session
+	for _, pod := range pods.Items {
+		for _, session := range podSessions(&pod) {
+			if len(workloads) > 0 && !slices.Contains(workloads, session.Workload) {
+				continue
+			}
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// podSessions returns the debug sessions found in a pod. A pod copy is a single session, while a regular pod has one
+// session per ephemeral debug container.
+func podSessions(pod *core_v1.Pod) []Session {
+	if isCopy(pod) {
+		session := Session{
+			Kind:      SessionKindCopy,
+			Workload:  pod.Labels[labelWorkload],
+			Pod:       pod.Name,
+			Container: debuggerContainerDefaultName,
+			State:     string(pod.Status.Phase),
+			Age:       Age(pod.CreationTimestamp.Time),
+			CreatedBy: pod.Annotations[annotationCreatedBy],
+		}
+		if expires, err := time.Parse(time.RFC3339, pod.Annotations[annotationExpiresAt]); err == nil {
+			session.ExpiresAt = Age(expires)
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name == debuggerContainerDefaultName && session.CreatedBy == "" {
+				session.CreatedBy = createdBy(c.Env)
+			}
+		}
+		return []Session{session}
+	}
+
+	var sessions []Session
+	for _, c := range pod.Spec.EphemeralContainers {
+		if !strings.HasPrefix(c.Name, debuggerContainerDefaultName) {
+			continue
+		}
+
+		session := Session{
+			Kind:      SessionKindEphemeral,
+			Workload:  workloadName(pod),
+			Pod:       pod.Name,
+			Container: c.Name,
+			State:     "Waiting",
+			CreatedBy: createdBy(c.Env),
+		}
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != c.Name {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				session.State = "Running"
+				session.Age = Age(status.State.Running.StartedAt.Time)
+			case status.State.Terminated != nil:
+				session.State = "Terminated"
+				session.Age = Age(status.State.Terminated.StartedAt.Time)
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// isCopy reports whether the pod is a debug pod copy. Copies made before they were labelled are recognised by name.
+func isCopy(pod *core_v1.Pod) bool {
+	return pod.Labels[labelCopy] == "true" || strings.HasSuffix(pod.Name, "-"+debuggerSuffix)
+}
+
+func workloadName(pod *core_v1.Pod) string {
+	if name, ok := pod.Labels["app.kubernetes.io/name"]; ok {
+		return name
+	}
+	return pod.Labels["app"]
+}
+
+func createdBy(env []core_v1.EnvVar) string {
+	for _, e := range env {
+		if e.Name == envCreatedBy {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// StaleCopies returns the debug pod copies that should be removed by `nais debug tidy`: copies that have expired, and
+// copies older than olderThan. A zero olderThan selects only expired copies, as the others may still be in use.
+func StaleCopies(sessions []Session, olderThan time.Duration, now time.Time) []Session {
+	var stale []Session
+	for _, s := range sessions {
+		if s.Kind != SessionKindCopy {
+			continue
+		}
+		if s.Expired(now) || (olderThan > 0 && now.Sub(time.Time(s.Age)) >= olderThan) {
+			stale = append(stale, s)
+		}
+	}
+	return stale
+}
+
+// DeleteCopies deletes the given debug pod copies.
+func DeleteCopies(ctx context.Context, client kubernetes.Interface, team string, copies []Session) error {
+	for _, s := range copies {
+		if s.Kind != SessionKindCopy {
+			return fmt.Errorf("refusing to delete %s: not a debug pod copy", s.Pod)
+		}
+		if err := client.CoreV1().Pods(team).Delete(ctx, s.Pod, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("deleting debug pod copy %s: %w", s.Pod, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package debug
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/nais/naistrix"
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListAndTidySessions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	pod := appPod()
+	pod.Spec.EphemeralContainers = []core_v1.EphemeralContainer{{
+		EphemeralContainerCommon: core_v1.EphemeralContainerCommon{
+			Name: "debugger-abcde",
+			Env:  []core_v1.EnvVar{{Name: envCreatedBy, Value: "alice@example.com"}},
+		},
+	}}
+	pod.Status.EphemeralContainerStatuses = []core_v1.ContainerStatus{{
+		Name:  "debugger-abcde",
+		State: core_v1.ContainerState{Running: &core_v1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-time.Hour))}},
+	}}
+
+	oldCopy := &core_v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "my-app-old-nais-debugger",
+		Namespace:         "my-team",
+		CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+	}}
+	expiredCopy := &core_v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "my-app-expired-nais-debugger",
+		Namespace:         "my-team",
+		CreationTimestamp: metav1.NewTime(now.Add(-30 * time.Minute)),
+		Labels:            map[string]string{labelCopy: "true", labelWorkload: "my-app"},
+		Annotations: map[string]string{
+			annotationCreatedBy: "bob@example.com",
+			annotationExpiresAt: now.Add(-time.Minute).Format(time.RFC3339),
+		},
+	}}
+	freshCopy := &core_v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "my-app-fresh-nais-debugger",
+		Namespace:         "my-team",
+		CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute)),
+		Labels:            map[string]string{labelCopy: "true", labelWorkload: "my-app"},
+	}}
+
+	client := fakeClient(pod, oldCopy, expiredCopy, freshCopy)
+
+	sessions, err := ListSessions(ctx, client, "my-team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 4 {
+		t.Fatalf("expected 4 sessions, got %d: %+v", len(sessions), sessions)
+	}
+
+	byPod := map[string]Session{}
+	for _, s := range sessions {
+		byPod[s.Pod] = s
+	}
+	if s := byPod["my-app-abc123"]; s.Kind != SessionKindEphemeral || s.CreatedBy != "alice@example.com" || s.State != "Running" || s.Workload != "my-app" {
+		t.Errorf("unexpected ephemeral session %+v", s)
+	}
+	if s := byPod["my-app-expired-nais-debugger"]; s.CreatedBy != "bob@example.com" || !s.Expired(now) {
+		t.Errorf("unexpected expired copy session %+v", s)
+	}
+
+	filtered, err := ListSessions(ctx, client, "my-team", "my-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 3 {
+		t.Errorf("expected unlabelled copy to be excluded when filtering by workload, got %+v", filtered)
+	}
+
+	if expired := StaleCopies(sessions, 0, now); len(expired) != 1 || expired[0].Pod != "my-app-expired-nais-debugger" {
+		t.Fatalf("expected only the expired copy to be stale without --older-than, got %+v", expired)
+	}
+
+	stale := StaleCopies(sessions, 2*time.Hour, now)
+	names := map[string]bool{}
+	for _, s := range stale {
+		names[s.Pod] = true
+	}
+	if len(stale) != 2 || !names["my-app-old-nais-debugger"] || !names["my-app-expired-nais-debugger"] {
+		t.Fatalf("expected old and expired copies to be stale, got %+v", stale)
+	}
+
+	if err := DeleteCopies(ctx, client, "my-team", stale); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := ListSessions(ctx, client, "my-team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 2 {
+		t.Errorf("expected 2 remaining sessions, got %+v", remaining)
+	}
+
+	if err := DeleteCopies(ctx, client, "my-team", []Session{byPod["my-app-abc123"]}); err == nil {
+		t.Error("expected deleting an ephemeral session to fail")
+	}
+}
+
+func TestDebugPodCopyWithTTL(t *testing.T) {
+	ctx := context.Background()
+	client := fakeClient(appPod())
+	out := naistrix.NewOutputWriter(io.Discard, new(naistrix.Count))
+
+	f := debugFlags(true, "")
+	f.TTL = 2 * time.Hour
+	d := Setup(ctx, client, &fakeAttacher{}, f, "my-app", nil, out)
+	d.createdBy = "alice@example.com"
+	if err := d.debugPod("my-app-abc123"); err != nil {
+		t.Fatalf("debugPod() error = %v", err)
+	}
+
+	sessions, err := ListSessions(ctx, client, "my-team", "my-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %+v", sessions)
+	}
+
+	s := sessions[0]
+	if s.CreatedBy != "alice@example.com" {
+		t.Errorf("expected creator to be recorded, got %q", s.CreatedBy)
+	}
+	if expires := time.Time(s.ExpiresAt); expires.Before(time.Now().Add(time.Hour)) {
+		t.Errorf("expected expiry about two hours from now, got %v", expires)
+	}
+
+	copied, err := client.CoreV1().Pods("my-team").Get(ctx, s.Pod, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := copied.Spec.ActiveDeadlineSeconds; d == nil || *d != 7200 {
+		t.Errorf("expected active deadline of 7200 seconds, got %v", d)
+	}
+}
@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/nais/cli/internal/dev/command/flag"
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
+)
+
+func Dev(parentFlags *flags.GlobalFlags) *naistrix.Command {
+	f := &flag.Dev{GlobalFlags: parentFlags}
+	return &naistrix.Command{
+		Name:        "dev",
+		Title:       "Set up local development.",
+		Description: "Commands for running the dependencies of an application on your own machine.",
+		StickyFlags: f,
+		SubCommands: []*naistrix.Command{
+			initSetup(f),
+		},
+	}
+}
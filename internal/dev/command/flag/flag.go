@@ -0,0 +1,27 @@
+package flag
+
+import (
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
+)
+
+type Dev struct {
+	*flags.GlobalFlags
+}
+
+type varsFilePath string
+
+var _ naistrix.FileAutoCompleter = (*varsFilePath)(nil)
+
+func (varsFilePath) FileExtensions() (extensions []string) {
+	return []string{"json", "yaml", "yml"}
+}
+
+type Init struct {
+	*Dev
+	VarsFilePath varsFilePath `name:"vars-file" short:"f" usage:"Path to the |FILE| containing template variables in JSON or YAML format."`
+	Vars         []string     `name:"var" usage:"Template variable in |KEY=VALUE| form. Can be repeated."`
+	OutputDir    string       `name:"output-dir" short:"o" usage:"|DIRECTORY| to write the files to."`
+	Devcontainer bool         `name:"devcontainer" usage:"Also generate a .devcontainer/devcontainer.json that starts the services."`
+	Force        bool         `name:"force" usage:"Overwrite existing files."`
+}
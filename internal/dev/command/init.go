@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/dev"
+	"github.com/nais/cli/internal/dev/command/flag"
+	"github.com/nais/cli/internal/validate"
+	"github.com/nais/naistrix"
+)
+
+func initSetup(parentFlags *flag.Dev) *naistrix.Command {
+	flags := &flag.Init{
+		Dev:       parentFlags,
+		OutputDir: ".",
+	}
+	return &naistrix.Command{
+		Name:  "init",
+		Title: "Generate a local setup for the dependencies of an application.",
+		Description: heredoc.Doc(`
+			Reads the Application in a Nais manifest and generates a docker-compose file with local stand-ins for its dependencies: Postgres at the major version of each SQL instance, Valkey, OpenSearch, and Kafka with the topics declared in the given files.
+
+			A .env file with the environment variables the platform injects, such as NAIS_DATABASE_* and KAFKA_BROKERS, points the application at the local services. The local services use fixed credentials and no TLS.
+
+			Nothing is read from the cluster or the Nais API, so the command works offline.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "file", Repeatable: true},
+		},
+		AutoCompleteExtensions: []string{"yaml", "yml", "json"},
+		Flags:                  flags,
+		Examples: []naistrix.Example{
+			{
+				Description: "Generate a local setup from the manifest and topics of an application.",
+				Command:     ".nais/app.yaml .nais/topics.yaml",
+			},
+			{
+				Description: "Also generate a devcontainer.json, with template variables from a file.",
+				Command:     ".nais/app.yaml --vars-file .nais/dev.yaml --devcontainer",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			vars := validate.TemplateVariables{}
+			if flags.VarsFilePath != "" {
+				v, err := validate.TemplateVariablesFromFile(string(flags.VarsFilePath))
+				if err != nil {
+					return fmt.Errorf("load template variables: %w", err)
+				}
+				vars = v
+			}
+			maps.Copy(vars, validate.TemplateVariablesFromSlice(flags.Vars))
+
+			manifest, err := dev.LoadManifest(args.All(), vars, out)
+			if err != nil {
+				return err
+			}
+
+			setup, err := dev.NewSetup(manifest)
+			if err != nil {
+				return err
+			}
+
+			files, err := dev.Files(setup, flags.Devcontainer)
+			if err != nil {
+				return err
+			}
+
+			written, err := dev.WriteFiles(flags.OutputDir, files, flags.Force)
+			if err != nil {
+				return err
+			}
+			for _, name := range written {
+				out.Successf("Wrote %s\n", filepath.Join(flags.OutputDir, name))
+			}
+
+			if len(dev.Env(setup)) == 0 {
+				out.Warnf("The application does not use Postgres, Valkey, OpenSearch or Kafka, so no services were generated.\n")
+			}
+			if setup.Kafka != nil {
+				out.Infof("The local Kafka broker does not use TLS, so only KAFKA_BROKERS is set. Configure the client without SSL when running locally.\n")
+			}
+			out.Printf("Start the services with: docker compose -f %s up -d\n", filepath.Join(flags.OutputDir, dev.ComposeFile))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package dev
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type compose struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image       string             `yaml:"image"`
+	Command     []string           `yaml:"command,omitempty"`
+	Environment map[string]string  `yaml:"environment,omitempty"`
+	Ports       []string           `yaml:"ports,omitempty"`
+	Volumes     []string           `yaml:"volumes,omitempty"`
+	Healthcheck *composeHealth     `yaml:"healthcheck,omitempty"`
+	DependsOn   map[string]depends `yaml:"depends_on,omitempty"`
+	Restart     string             `yaml:"restart,omitempty"`
+}
+
+type composeHealth struct {
+	Test     []string `yaml:"test"`
+	Interval string   `yaml:"interval"`
+	Retries  int      `yaml:"retries"`
+}
+
+type depends struct {
+	Condition string `yaml:"condition"`
+}
+
+// composeFile returns the docker-compose file with a service for each local service, and adds the init scripts of
+// Postgres instances with more than one database to files.
+func composeFile(s *Setup, files map[string][]byte) ([]byte, error) {
+	c := compose{Services: map[string]composeService{}}
+	for _, pg := range s.Postgres {
+		service := composeService{
+			Image: "postgres:" + strconv.Itoa(pg.Version),
+			Environment: map[string]string{
+				"POSTGRES_USER":     pg.User,
+				"POSTGRES_PASSWORD": localPassword,
+				"POSTGRES_DB":       pg.Databases[0].Name,
+			},
+			Ports: []string{fmt.Sprintf("%d:5432", pg.Port)},
+			Healthcheck: &composeHealth{
+				Test:     []string{"CMD", "pg_isready", "-U", pg.User},
+				Interval: "5s",
+				Retries:  10,
+			},
+		}
+
+		// The image only creates one database, the rest are created by an init script.
+		if len(pg.Databases) > 1 {
+			script := "postgres/" + pg.Name + ".sql"
+			var b strings.Builder
+			for _, db := range pg.Databases[1:] {
+				fmt.Fprintf(&b, "CREATE DATABASE %q;\n", db.Name)
+			}
+			files[script] = []byte(b.String())
+			service.Volumes = []string{"./" + script + ":/docker-entrypoint-initdb.d/" + pg.Name + ".sql:ro"}
+		}
+		c.Services["postgres-"+pg.Name] = service
+	}
+
+	for _, v := range s.Valkey {
+		c.Services["valkey-"+v.Name] = composeService{
+			Image:   valkeyImage,
+			Command: []string{"valkey-server", "--requirepass", localPassword},
+			Ports:   []string{fmt.Sprintf("%d:6379", v.Port)},
+		}
+	}
+
+	if s.OpenSearch != nil {
+		c.Services["opensearch-"+s.OpenSearch.Name] = composeService{
+			Image: openSearchImage,
+			Environment: map[string]string{
+				"discovery.type":              "single-node",
+				"DISABLE_SECURITY_PLUGIN":     "true",
+				"DISABLE_INSTALL_DEMO_CONFIG": "true",
+				"OPENSEARCH_JAVA_OPTS":        "-Xms512m -Xmx512m",
+			},
+			Ports: []string{fmt.Sprintf("%d:9200", s.OpenSearch.Port)},
+		}
+	}
+
+	if s.Kafka != nil {
+		c.Services["kafka"] = composeService{
+			Image: kafkaImage,
+			Environment: map[string]string{
+				"KAFKA_NODE_ID":                          "1",
+				"KAFKA_PROCESS_ROLES":                    "broker,controller",
+				"KAFKA_LISTENERS":                        "INTERNAL://:29092,CONTROLLER://:9093,EXTERNAL://:9092",
+				"KAFKA_ADVERTISED_LISTENERS":             fmt.Sprintf("INTERNAL://kafka:29092,EXTERNAL://localhost:%d", s.Kafka.Port),
+				"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP":   "INTERNAL:PLAINTEXT,CONTROLLER:PLAINTEXT,EXTERNAL:PLAINTEXT",
+				"KAFKA_INTER_BROKER_LISTENER_NAME":       "INTERNAL",
+				"KAFKA_CONTROLLER_LISTENER_NAMES":        "CONTROLLER",
+				"KAFKA_CONTROLLER_QUORUM_VOTERS":         "1@localhost:9093",
+				"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR": "1",
+				"KAFKA_AUTO_CREATE_TOPICS_ENABLE":        "false",
+			},
+			Ports: []string{fmt.Sprintf("%d:9092", s.Kafka.Port)},
+			Healthcheck: &composeHealth{
+				Test:     []string{"CMD", "/opt/kafka/bin/kafka-broker-api-versions.sh", "--bootstrap-server", "localhost:29092"},
+				Interval: "5s",
+				Retries:  20,
+			},
+		}
+
+		if len(s.Kafka.Topics) > 0 {
+			create := make([]string, 0, len(s.Kafka.Topics))
+			for _, t := range s.Kafka.Topics {
+				create = append(create, "/opt/kafka/bin/kafka-topics.sh --bootstrap-server kafka:29092 --create --if-not-exists --topic "+t)
+			}
+			c.Services["kafka-topics"] = composeService{
+				Image:     kafkaImage,
+				Command:   []string{"sh", "-c", strings.Join(create, " && ")},
+				DependsOn: map[string]depends{"kafka": {Condition: "service_healthy"}},
+				Restart:   "no",
+			}
+		}
+	}
+
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(fmt.Sprintf("# Generated by nais dev init for the application %s.\n", s.Application)), b...), nil
+}
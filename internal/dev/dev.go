@@ -0,0 +1,73 @@
+package dev
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// ComposeFile is the name of the generated docker-compose file.
+	ComposeFile = "docker-compose.yaml"
+	// EnvFile is the name of the generated .env file.
+	EnvFile = ".env"
+	// DevcontainerFile is the name of the generated devcontainer.json file.
+	DevcontainerFile = ".devcontainer/devcontainer.json"
+)
+
+// Files returns the files of the local setup by their path relative to the output directory: the docker-compose file,
+// the .env file, init scripts for Postgres instances with more than one database, and optionally a devcontainer.json.
+func Files(s *Setup, devcontainer bool) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	c, err := composeFile(s, files)
+	if err != nil {
+		return nil, err
+	}
+	files[ComposeFile] = c
+	files[EnvFile] = FormatEnv(Env(s))
+
+	if devcontainer {
+		d, err := Devcontainer(s)
+		if err != nil {
+			return nil, err
+		}
+		files[DevcontainerFile] = d
+	}
+	return files, nil
+}
+
+// WriteFiles writes files to dir and returns their paths. Existing files are only overwritten when force is true, and
+// otherwise nothing is written.
+func WriteFiles(dir string, files map[string][]byte, force bool) ([]string, error) {
+	paths := make([]string, 0, len(files))
+	for name := range files {
+		paths = append(paths, name)
+	}
+	slices.Sort(paths)
+
+	if !force {
+		var existing []string
+		for _, name := range paths {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				existing = append(existing, name)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("refusing to overwrite existing files, use --force to overwrite: %s", strings.Join(existing, ", "))
+		}
+	}
+
+	for _, name := range paths {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil { // #nosec G306 -- the files contain no secrets
+			return nil, err
+		}
+	}
+	return paths, nil
+}
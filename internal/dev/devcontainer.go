@@ -0,0 +1,27 @@
+package dev
+
+import "encoding/json"
+
+const devcontainerImage = "mcr.microsoft.com/devcontainers/base:ubuntu"
+
+type devcontainer struct {
+	Name              string   `json:"name"`
+	Image             string   `json:"image"`
+	InitializeCommand string   `json:"initializeCommand"`
+	RunArgs           []string `json:"runArgs"`
+}
+
+// Devcontainer returns a devcontainer.json that starts the services in the docker-compose file before the container,
+// and runs the container in the host network with the .env file, so that the services are reachable on localhost.
+func Devcontainer(s *Setup) ([]byte, error) {
+	b, err := json.MarshalIndent(devcontainer{
+		Name:              s.Application,
+		Image:             devcontainerImage,
+		InitializeCommand: "docker compose -f " + ComposeFile + " up -d",
+		RunArgs:           []string{"--network=host", "--env-file=${localWorkspaceFolder}/" + EnvFile},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,74 @@
+package dev
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nais/cli/internal/aiven/aiven_config"
+)
+
+// EnvVar is an environment variable with the same name as the platform injects into the application.
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// Env returns the environment variables that point the application at the local services, grouped by service.
+func Env(s *Setup) []EnvVar {
+	var env []EnvVar
+	add := func(key, value string) {
+		env = append(env, EnvVar{Key: key, Value: value})
+	}
+
+	for _, pg := range s.Postgres {
+		port := strconv.Itoa(pg.Port)
+		for _, db := range pg.Databases {
+			add(db.EnvPrefix+"_HOST", "localhost")
+			add(db.EnvPrefix+"_PORT", port)
+			add(db.EnvPrefix+"_DATABASE", db.Name)
+			add(db.EnvPrefix+"_USERNAME", pg.User)
+			add(db.EnvPrefix+"_PASSWORD", localPassword)
+			add(db.EnvPrefix+"_URL", fmt.Sprintf("postgresql://%s:%s@localhost:%s/%s", pg.User, localPassword, port, db.Name))
+			add(db.EnvPrefix+"_JDBC_URL", fmt.Sprintf("jdbc:postgresql://localhost:%s/%s?user=%s&password=%s", port, db.Name, pg.User, localPassword))
+		}
+	}
+
+	for _, v := range s.Valkey {
+		instance := EnvName(v.Name)
+		port := strconv.Itoa(v.Port)
+		add("VALKEY_HOST_"+instance, "localhost")
+		add("VALKEY_PORT_"+instance, port)
+		add("VALKEY_URI_"+instance, "redis://localhost:"+port)
+		add("VALKEY_USERNAME_"+instance, valkeyUser)
+		add("VALKEY_PASSWORD_"+instance, localPassword)
+	}
+
+	if s.OpenSearch != nil {
+		port := strconv.Itoa(s.OpenSearch.Port)
+		add("OPEN_SEARCH_URI", "http://localhost:"+port)
+		add("OPEN_SEARCH_HOST", "localhost")
+		add("OPEN_SEARCH_PORT", port)
+		add("OPEN_SEARCH_USERNAME", openSearchUser)
+		add("OPEN_SEARCH_PASSWORD", localPassword)
+	}
+
+	if s.Kafka != nil {
+		add(aiven_config.KafkaBrokersKey, "localhost:"+strconv.Itoa(s.Kafka.Port))
+	}
+
+	return env
+}
+
+// FormatEnv formats environment variables as a .env file.
+func FormatEnv(env []EnvVar) []byte {
+	var b strings.Builder
+	b.WriteString("# Generated by nais dev init. Points the application at the services in docker-compose.yaml.\n")
+	if len(env) == 0 {
+		b.WriteString("# The application does not use any services that can run locally.\n")
+	}
+	for _, e := range env {
+		fmt.Fprintf(&b, "%s=%s\n", e.Key, e.Value)
+	}
+	return []byte(b.String())
+}
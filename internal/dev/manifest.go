@@ -0,0 +1,79 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/nais/cli/internal/validate"
+	nais_kafka "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/naistrix"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Manifest is the resources of a Nais manifest that a local setup is generated from.
+type Manifest struct {
+	Application *nais_io_v1alpha1.Application
+	Topics      []nais_kafka.Topic
+}
+
+// LoadManifest reads an Application, and the Kafka topics it uses, from one or more manifest files. Templates in the
+// files are expanded with vars. Resources of other kinds are ignored.
+func LoadManifest(paths []string, vars validate.TemplateVariables, out *naistrix.OutputWriter) (*Manifest, error) {
+	m := &Manifest{}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading file %s: %w", path, err)
+		}
+
+		templated, err := validate.ExecTemplate(raw, vars, out)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if err := m.add(templated); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if m.Application == nil {
+		return nil, fmt.Errorf("no Application found in %v", paths)
+	}
+	return m, nil
+}
+
+// add adds the resources of a multi-document YAML file to the manifest.
+func (m *Manifest) add(data []byte) error {
+	docs, err := validate.YAMLToJSONMessages(data)
+	if err != nil {
+		return fmt.Errorf("parsing YAML: %w", err)
+	}
+
+	for _, doc := range docs {
+		var meta metav1.TypeMeta
+		if err := json.Unmarshal(doc, &meta); err != nil {
+			return err
+		}
+
+		switch meta.Kind {
+		case "Application":
+			if m.Application != nil {
+				return fmt.Errorf("more than one Application found, only one is supported")
+			}
+			app := &nais_io_v1alpha1.Application{}
+			if err := json.Unmarshal(doc, app); err != nil {
+				return fmt.Errorf("parsing Application: %w", err)
+			}
+			m.Application = app
+		case "Topic":
+			var topic nais_kafka.Topic
+			if err := json.Unmarshal(doc, &topic); err != nil {
+				return fmt.Errorf("parsing Topic: %w", err)
+			}
+			m.Topics = append(m.Topics, topic)
+		}
+	}
+	return nil
+}
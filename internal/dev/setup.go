@@ -0,0 +1,169 @@
+package dev
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+// Images of the local services. The tags are pinned to major versions, so that the setup is reproducible without
+// following the latest release.
+const (
+	valkeyImage     = "valkey/valkey:8"
+	openSearchImage = "opensearchproject/opensearch:2"
+	kafkaImage      = "apache/kafka:3.9.1"
+
+	// defaultPostgresVersion is used for SQL instances without a type.
+	defaultPostgresVersion = 17
+)
+
+// Credentials of the local services. They are only reachable from localhost, and are not secret.
+const (
+	localPassword  = "local"
+	valkeyUser     = "default"
+	openSearchUser = "admin"
+)
+
+// Setup is the local services needed to run an application, derived from its manifest.
+type Setup struct {
+	Application string
+	Postgres    []PostgresInstance
+	Valkey      []ValkeyInstance
+	OpenSearch  *OpenSearchInstance
+	Kafka       *KafkaBroker
+}
+
+// PostgresInstance is a local Postgres server standing in for a Cloud SQL instance.
+type PostgresInstance struct {
+	Name      string
+	Version   int
+	Port      int
+	User      string
+	Databases []PostgresDatabase
+}
+
+// PostgresDatabase is a database in a Postgres instance, with the prefix of its environment variables.
+type PostgresDatabase struct {
+	Name      string
+	EnvPrefix string
+}
+
+// ValkeyInstance is a local Valkey server standing in for a Valkey instance.
+type ValkeyInstance struct {
+	Name string
+	Port int
+}
+
+// OpenSearchInstance is a local single-node OpenSearch cluster standing in for an OpenSearch instance.
+type OpenSearchInstance struct {
+	Name string
+	Port int
+}
+
+// KafkaBroker is a local single-node Kafka cluster with the topics of the application.
+type KafkaBroker struct {
+	Port   int
+	Topics []string
+}
+
+// NewSetup returns the local services needed to run the application in the manifest.
+func NewSetup(m *Manifest) (*Setup, error) {
+	app := m.Application
+	name := app.GetName()
+	if name == "" {
+		return nil, fmt.Errorf("the Application has no name")
+	}
+
+	s := &Setup{Application: name}
+
+	if app.Spec.GCP != nil {
+		for i, instance := range app.Spec.GCP.SqlInstances {
+			pg, err := newPostgresInstance(name, instance, 5432+i)
+			if err != nil {
+				return nil, err
+			}
+			s.Postgres = append(s.Postgres, pg)
+		}
+	}
+
+	for i, v := range app.Spec.Valkey {
+		s.Valkey = append(s.Valkey, ValkeyInstance{Name: v.Instance, Port: 6379 + i})
+	}
+
+	if app.Spec.OpenSearch != nil {
+		s.OpenSearch = &OpenSearchInstance{Name: app.Spec.OpenSearch.Instance, Port: 9200}
+	}
+
+	if app.Spec.Kafka != nil || len(m.Topics) > 0 {
+		s.Kafka = &KafkaBroker{Port: 9092}
+		for _, t := range m.Topics {
+			topic := t.GetName()
+			if ns := cmp.Or(t.GetNamespace(), app.GetNamespace()); ns != "" {
+				topic = ns + "." + topic
+			}
+			if !slices.Contains(s.Kafka.Topics, topic) {
+				s.Kafka.Topics = append(s.Kafka.Topics, topic)
+			}
+		}
+		slices.Sort(s.Kafka.Topics)
+	}
+
+	return s, nil
+}
+
+func newPostgresInstance(app string, instance nais_io_v1.CloudSqlInstance, port int) (PostgresInstance, error) {
+	version, err := postgresVersion(instance.Type)
+	if err != nil {
+		return PostgresInstance{}, err
+	}
+
+	pg := PostgresInstance{
+		Name:    cmp.Or(instance.Name, app),
+		Version: version,
+		Port:    port,
+		User:    app,
+	}
+
+	databases := instance.Databases
+	if len(databases) == 0 {
+		databases = []nais_io_v1.CloudSqlDatabase{{Name: app}}
+	}
+	for _, db := range databases {
+		pg.Databases = append(pg.Databases, PostgresDatabase{Name: db.Name, EnvPrefix: DatabaseEnvPrefix(app, db)})
+	}
+	return pg, nil
+}
+
+// DatabaseEnvPrefix returns the prefix of the environment variables the platform injects for a Cloud SQL database,
+// such as NAIS_DATABASE_MY_APP_MY_DB for the database my-db of the application my-app.
+func DatabaseEnvPrefix(app string, db nais_io_v1.CloudSqlDatabase) string {
+	if db.EnvVarPrefix != "" {
+		return db.EnvVarPrefix
+	}
+	return "NAIS_DATABASE_" + EnvName(app) + "_" + EnvName(db.Name)
+}
+
+// EnvName returns a name as used in environment variables, such as MY_APP for my-app.
+func EnvName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// postgresVersion returns the major version of a Cloud SQL instance type, such as 17 for POSTGRES_17.
+func postgresVersion(t nais_io_v1.CloudSqlInstanceType) (int, error) {
+	if t == "" {
+		return defaultPostgresVersion, nil
+	}
+	v, ok := strings.CutPrefix(string(t), "POSTGRES_")
+	if !ok {
+		return 0, fmt.Errorf("unsupported SQL instance type %q", t)
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported SQL instance type %q", t)
+	}
+	return version, nil
+}
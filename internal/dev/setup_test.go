@@ -0,0 +1,183 @@
+package dev
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+)
+
+const manifest = `
+apiVersion: nais.io/v1alpha1
+kind: Application
+metadata:
+  name: my-app
+  namespace: my-team
+spec:
+  image: example.com/my-app:1
+  gcp:
+    sqlInstances:
+      - type: POSTGRES_15
+        databases:
+          - name: my-app
+          - name: reports
+            envVarPrefix: DB_REPORTS
+  valkey:
+    - instance: sessions
+  openSearch:
+    instance: search
+  kafka:
+    pool: nav-dev
+---
+apiVersion: kafka.nais.io/v1
+kind: Topic
+metadata:
+  name: events
+spec:
+  pool: nav-dev
+`
+
+func parse(t *testing.T, data string) *Setup {
+	t.Helper()
+	m := &Manifest{}
+	if err := m.add([]byte(data)); err != nil {
+		t.Fatalf("add() error = %v", err)
+	}
+	s, err := NewSetup(m)
+	if err != nil {
+		t.Fatalf("NewSetup() error = %v", err)
+	}
+	return s
+}
+
+func TestNewSetup(t *testing.T) {
+	s := parse(t, manifest)
+
+	wantPostgres := []PostgresInstance{{
+		Name:    "my-app",
+		Version: 15,
+		Port:    5432,
+		User:    "my-app",
+		Databases: []PostgresDatabase{
+			{Name: "my-app", EnvPrefix: "NAIS_DATABASE_MY_APP_MY_APP"},
+			{Name: "reports", EnvPrefix: "DB_REPORTS"},
+		},
+	}}
+	if !reflect.DeepEqual(s.Postgres, wantPostgres) {
+		t.Errorf("Postgres = %+v, want %+v", s.Postgres, wantPostgres)
+	}
+	if want := []ValkeyInstance{{Name: "sessions", Port: 6379}}; !reflect.DeepEqual(s.Valkey, want) {
+		t.Errorf("Valkey = %+v, want %+v", s.Valkey, want)
+	}
+	if s.OpenSearch == nil || s.OpenSearch.Name != "search" {
+		t.Errorf("OpenSearch = %+v, want instance search", s.OpenSearch)
+	}
+	if s.Kafka == nil || !reflect.DeepEqual(s.Kafka.Topics, []string{"my-team.events"}) {
+		t.Errorf("Kafka = %+v, want topic my-team.events", s.Kafka)
+	}
+}
+
+func TestNewSetup_defaults(t *testing.T) {
+	s := parse(t, `
+apiVersion: nais.io/v1alpha1
+kind: Application
+metadata:
+  name: my-app
+spec:
+  gcp:
+    sqlInstances:
+      - name: db
+`)
+
+	want := []PostgresInstance{{
+		Name:      "db",
+		Version:   defaultPostgresVersion,
+		Port:      5432,
+		User:      "my-app",
+		Databases: []PostgresDatabase{{Name: "my-app", EnvPrefix: "NAIS_DATABASE_MY_APP_MY_APP"}},
+	}}
+	if !reflect.DeepEqual(s.Postgres, want) {
+		t.Errorf("Postgres = %+v, want %+v", s.Postgres, want)
+	}
+	if s.Kafka != nil || s.OpenSearch != nil || len(s.Valkey) > 0 {
+		t.Errorf("expected no other services, got %+v", s)
+	}
+}
+
+func TestPostgresVersion(t *testing.T) {
+	tests := []struct {
+		typ     string
+		want    int
+		wantErr bool
+	}{
+		{typ: "POSTGRES_12", want: 12},
+		{typ: "POSTGRES_17", want: 17},
+		{typ: "", want: defaultPostgresVersion},
+		{typ: "MYSQL_8", wantErr: true},
+		{typ: "POSTGRES_X", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := postgresVersion(nais_io_v1.CloudSqlInstanceType(tt.typ))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("postgresVersion(%q) error = %v, wantErr %v", tt.typ, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("postgresVersion(%q) = %d, want %d", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	env := map[string]string{}
+	for _, e := range Env(parse(t, manifest)) {
+		env[e.Key] = e.Value
+	}
+
+	want := map[string]string{
+		"NAIS_DATABASE_MY_APP_MY_APP_HOST":     "localhost",
+		"NAIS_DATABASE_MY_APP_MY_APP_PORT":     "5432",
+		"NAIS_DATABASE_MY_APP_MY_APP_JDBC_URL": "jdbc:postgresql://localhost:5432/my-app?user=my-app&password=local",
+		"DB_REPORTS_DATABASE":                  "reports",
+		"VALKEY_URI_SESSIONS":                  "redis://localhost:6379",
+		"OPEN_SEARCH_URI":                      "http://localhost:9200",
+		"KAFKA_BROKERS":                        "localhost:9092",
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("%s = %q, want %q", k, env[k], v)
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files, err := Files(parse(t, manifest), true)
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{EnvFile, DevcontainerFile, ComposeFile, "postgres/my-app.sql"}
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		t.Errorf("Files() = %v, want %v", names, want)
+	}
+
+	if got := string(files["postgres/my-app.sql"]); got != "CREATE DATABASE \"reports\";\n" {
+		t.Errorf("init script = %q", got)
+	}
+
+	compose := string(files[ComposeFile])
+	for _, s := range []string{"postgres-my-app:", "image: postgres:15", "valkey-sessions:", "opensearch-search:", "kafka-topics:", "--topic my-team.events"} {
+		if !strings.Contains(compose, s) {
+			t.Errorf("%s does not contain %q:\n%s", ComposeFile, s, compose)
+		}
+	}
+}
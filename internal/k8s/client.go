@@ -81,7 +81,9 @@ func SetupControllerRuntimeClient(overrides ...ClientOverride) *Client {
 	return &Client{client, namespace}
 }
 
-func SetupClientGo(context string) (kubernetes.Interface, error) {
+// SetupRestConfig returns the REST config for the given kube context. It is needed for operations that stream data
+// directly to and from the cluster, such as attach, exec and port-forward.
+func SetupRestConfig(context string) (*rest.Config, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	configOverrides := &clientcmd.ConfigOverrides{
 		CurrentContext: string(context),
@@ -92,6 +94,15 @@ func SetupClientGo(context string) (kubernetes.Interface, error) {
 		return nil, fmt.Errorf("unable to get kubeconfig: %w", err)
 	}
 
+	return config, nil
+}
+
+func SetupClientGo(context string) (kubernetes.Interface, error) {
+	config, err := SetupRestConfig(context)
+	if err != nil {
+		return nil, err
+	}
+
 	k8sClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("load kubeclient configuration: %w", err)
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// NewRemoteExecutor returns an executor for an attach or exec URL. WebSocket is preferred, with SPDY as a fallback for
+// API servers that do not support streaming over WebSocket.
+func NewRemoteExecutor(config *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+	websocketExec, err := remotecommand.NewWebSocketExecutor(config, "GET", u.String())
+	if err != nil {
+		return nil, fmt.Errorf("creating websocket executor: %w", err)
+	}
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(config, "POST", u)
+	if err != nil {
+		return nil, fmt.Errorf("creating spdy executor: %w", err)
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// StreamTerminal streams an interactive session with a TTY using the given executor. When stdin is a terminal it is
+// put in raw mode for the duration of the session, and the remote TTY is resized whenever the local terminal is.
+func StreamTerminal(ctx context.Context, exec remotecommand.Executor, stdin *os.File, stdout io.Writer) error {
+	opts := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Tty:    true,
+	}
+
+	fd := int(stdin.Fd()) // #nosec G115
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("setting terminal to raw mode: %w", err)
+		}
+		defer func() { _ = term.Restore(fd, state) }()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		opts.TerminalSizeQueue = newTerminalSizeQueue(ctx, fd)
+	}
+
+	return exec.StreamWithContext(ctx, opts)
+}
+
+// terminalSizeQueue polls the size of a local terminal and emits a value whenever it changes. Polling is used instead
+// of SIGWINCH to behave the same on all platforms.
+type terminalSizeQueue struct {
+	ctx   context.Context
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue(ctx context.Context, fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{
+		ctx:   ctx,
+		sizes: make(chan remotecommand.TerminalSize, 1),
+	}
+
+	go func() {
+		var last remotecommand.TerminalSize
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			width, height, err := term.GetSize(fd)
+			if err == nil {
+				size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)} // #nosec G115
+				if size != last {
+					last = size
+					select {
+					case q.sizes <- size:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return q
+}
+
+// Next returns the next terminal size, or nil when the session has ended.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.ctx.Done():
+		return nil
+	}
+}
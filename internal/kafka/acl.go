@@ -0,0 +1,245 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nais/cli/internal/aiven"
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/naisapi/gql"
+	nais_kafka "github.com/nais/liberator/pkg/apis/kafka.nais.io/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ACL is an entry in the access control list of a topic, giving an application of a team access to the topic.
+type ACL struct {
+	Team        string `heading:"Team" json:"team" yaml:"team"`
+	Application string `heading:"Application" json:"application" yaml:"application"`
+	Access      string `heading:"Access" json:"access" yaml:"access"`
+}
+
+func (a ACL) String() string {
+	return fmt.Sprintf("%s/%s (%s)", a.Team, a.Application, a.Access)
+}
+
+func (a ACL) topicACL() nais_kafka.TopicACL {
+	return nais_kafka.TopicACL{Team: a.Team, Application: a.Application, Access: a.Access}
+}
+
+func fromTopicACL(a nais_kafka.TopicACL) ACL {
+	return ACL{Team: a.Team, Application: a.Application, Access: a.Access}
+}
+
+// Validate checks that the entry has a team, an application and a valid access level.
+func (a ACL) Validate() error {
+	if a.Team == "" || a.Application == "" {
+		return fmt.Errorf("ACL entry %s must have both team and application", a)
+	}
+	if err := aiven.ValidAclPermission(a.Access); err != nil {
+		return fmt.Errorf("ACL entry %s: %w", a, err)
+	}
+	return nil
+}
+
+// ParseApplication parses an application given as TEAM/APPLICATION, or as APPLICATION in the given team.
+func ParseApplication(team, s string) (string, string, error) {
+	if t, app, ok := strings.Cut(s, "/"); ok {
+		if t == "" || app == "" || strings.Contains(app, "/") {
+			return "", "", fmt.Errorf("invalid application %q, expected TEAM/APPLICATION or APPLICATION", s)
+		}
+		return t, app, nil
+	}
+	if s == "" {
+		return "", "", fmt.Errorf("application cannot be empty")
+	}
+	return team, s, nil
+}
+
+// ResourceName returns the name of the Topic resource for a topic, which is the name in Kafka without the team prefix.
+func ResourceName(team, topic string) string {
+	return strings.TrimPrefix(topic, team+".")
+}
+
+// ListACLs returns the ACL of a topic of the team. It is read through the Nais API, or from the cluster if the API
+// cannot be reached. The returned bool is true if the cluster was used.
+func ListACLs(ctx context.Context, team, environment, topic string) ([]ACL, bool, error) {
+	_ = `# @genqlient
+		query GetKafkaTopicAcl($team: Slug!, $environment: String!, $topic: String!) {
+			team(slug: $team) {
+				environment(name: $environment) {
+					kafkaTopic(name: $topic) {
+						acl(first: 1000) {
+							nodes {
+								teamName
+								workloadName
+								access
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	topic = ResourceName(team, topic)
+	acls, apiErr := listACLsFromAPI(ctx, team, environment, topic)
+	if apiErr == nil {
+		return acls, false, nil
+	}
+
+	topicACLs, err := aiven.GetTopicACLs(ctx, team, topic, environment)
+	if err != nil {
+		return nil, true, fmt.Errorf("listing ACL through the Nais API: %w, and from the cluster: %w", apiErr, err)
+	}
+
+	acls = make([]ACL, 0, len(topicACLs))
+	for _, a := range topicACLs {
+		acls = append(acls, fromTopicACL(a))
+	}
+	return acls, true, nil
+}
+
+func listACLsFromAPI(ctx context.Context, team, environment, topic string) ([]ACL, error) {
+	client, err := naisapi.GraphqlClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gql.GetKafkaTopicAcl(ctx, client, team, environment, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := resp.Team.Environment.KafkaTopic.Acl.Nodes
+	ret := make([]ACL, 0, len(nodes))
+	for _, n := range nodes {
+		ret = append(ret, ACL{Team: n.TeamName, Application: n.WorkloadName, Access: n.Access})
+	}
+	return ret, nil
+}
+
+// GrantACL adds an entry to the ACL of a topic. It returns false if the topic already has the entry.
+func GrantACL(ctx context.Context, team, environment, topic string, acl ACL) (bool, error) {
+	result, err := aiven.GrantAccessToTopic(ctx, team, ResourceName(team, topic), environment, acl.topicACL())
+	if err != nil {
+		return false, err
+	}
+	return !result.AlreadyAdded, nil
+}
+
+// RevokeACL removes the entries of an application from the ACL of a topic, and returns the number of entries removed.
+// Only the entry with the access of acl is removed, or all entries of the application if the access is empty.
+func RevokeACL(ctx context.Context, team, environment, topic string, acl ACL) (int, error) {
+	return aiven.RevokeAccessToTopic(ctx, team, ResourceName(team, topic), environment, acl.Team, acl.Application, acl.Access)
+}
+
+// TopicACLs is the declared ACL of a topic in an ACL file.
+type TopicACLs struct {
+	Topic string `yaml:"topic"`
+	ACL   []ACL  `yaml:"acl"`
+}
+
+// ReadACLFile reads and validates the declared ACLs of topics from a YAML file.
+func ReadACLFile(path string) ([]TopicACLs, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []TopicACLs
+	if err := yaml.Unmarshal(b, &topics); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no topics declared in %s", path)
+	}
+
+	seen := map[string]bool{}
+	for _, t := range topics {
+		if t.Topic == "" {
+			return nil, fmt.Errorf("%s: topic must have a name", path)
+		}
+		if seen[t.Topic] {
+			return nil, fmt.Errorf("%s: topic %q is declared more than once", path, t.Topic)
+		}
+		seen[t.Topic] = true
+
+		for _, a := range t.ACL {
+			if err := a.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: topic %q: %w", path, t.Topic, err)
+			}
+		}
+	}
+	return topics, nil
+}
+
+// ACLChange is the change needed to converge the ACL of a topic to the declared ACL.
+type ACLChange struct {
+	Topic   string
+	Current []ACL
+	Add     []ACL
+	Remove  []ACL
+}
+
+// Changed returns true if the ACL of the topic must be updated.
+func (c ACLChange) Changed() bool {
+	return len(c.Add) > 0 || len(c.Remove) > 0
+}
+
+// DiffACLs returns the entries that must be added to and removed from the current ACL to get the declared ACL.
+func DiffACLs(topic string, current, declared []ACL) ACLChange {
+	change := ACLChange{Topic: topic, Current: current}
+	for _, a := range declared {
+		if !slices.Contains(current, a) && !slices.Contains(change.Add, a) {
+			change.Add = append(change.Add, a)
+		}
+	}
+	for _, a := range current {
+		if !slices.Contains(declared, a) && !slices.Contains(change.Remove, a) {
+			change.Remove = append(change.Remove, a)
+		}
+	}
+
+	slices.SortFunc(change.Add, compareACL)
+	slices.SortFunc(change.Remove, compareACL)
+	return change
+}
+
+func compareACL(a, b ACL) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+// PlanACLSync reads the current ACL of each declared topic from the cluster, and returns the changes needed.
+func PlanACLSync(ctx context.Context, team, environment string, declared []TopicACLs) ([]ACLChange, error) {
+	ret := make([]ACLChange, 0, len(declared))
+	for _, t := range declared {
+		topic := ResourceName(team, t.Topic)
+		topicACLs, err := aiven.GetTopicACLs(ctx, team, topic, environment)
+		if err != nil {
+			return nil, fmt.Errorf("topic %q: %w", topic, err)
+		}
+
+		current := make([]ACL, 0, len(topicACLs))
+		for _, a := range topicACLs {
+			current = append(current, fromTopicACL(a))
+		}
+		ret = append(ret, DiffACLs(topic, current, t.ACL))
+	}
+	return ret, nil
+}
+
+// ApplyACLChange updates the ACL of a topic. The change is applied to the ACL of the topic at the time of the update.
+func ApplyACLChange(ctx context.Context, team, environment string, change ACLChange) error {
+	return aiven.ChangeTopicACLs(ctx, team, change.Topic, environment, topicACLs(change.Add), topicACLs(change.Remove))
+}
+
+func topicACLs(acls []ACL) []nais_kafka.TopicACL {
+	ret := make([]nais_kafka.TopicACL, 0, len(acls))
+	for _, a := range acls {
+		ret = append(ret, a.topicACL())
+	}
+	return ret
+}
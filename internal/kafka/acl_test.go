@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseApplication(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantTeam string
+		wantApp  string
+		wantErr  bool
+	}{
+		{input: "my-app", wantTeam: "myteam", wantApp: "my-app"},
+		{input: "other/my-app", wantTeam: "other", wantApp: "my-app"},
+		{input: "*/*", wantTeam: "*", wantApp: "*"},
+		{input: "", wantErr: true},
+		{input: "other/", wantErr: true},
+		{input: "a/b/c", wantErr: true},
+	}
+	for _, tt := range tests {
+		team, app, err := ParseApplication("myteam", tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseApplication(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if team != tt.wantTeam || app != tt.wantApp {
+			t.Errorf("ParseApplication(%q) = %q, %q, want %q, %q", tt.input, team, app, tt.wantTeam, tt.wantApp)
+		}
+	}
+}
+
+func TestDiffACLs(t *testing.T) {
+	a := ACL{Team: "a", Application: "app", Access: "read"}
+	b := ACL{Team: "b", Application: "app", Access: "read"}
+	c := ACL{Team: "c", Application: "app", Access: "write"}
+	d := ACL{Team: "d", Application: "app", Access: "readwrite"}
+
+	change := DiffACLs("my-topic", []ACL{c, a, b}, []ACL{d, a, b, d})
+	if !change.Changed() {
+		t.Fatal("Changed() = false, want true")
+	}
+	if !slices.Equal(change.Add, []ACL{d}) {
+		t.Errorf("Add = %v, want %v", change.Add, []ACL{d})
+	}
+	if !slices.Equal(change.Remove, []ACL{c}) {
+		t.Errorf("Remove = %v, want %v", change.Remove, []ACL{c})
+	}
+
+	if DiffACLs("my-topic", []ACL{a, b}, []ACL{b, a}).Changed() {
+		t.Error("Changed() = true for the same entries in another order, want false")
+	}
+}
+
+func TestReadACLFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `
+- topic: my-topic
+  acl:
+    - team: other
+      application: my-app
+      access: read
+- topic: empty-topic
+  acl: []
+`,
+		},
+		{name: "no topics", content: "[]", wantErr: true},
+		{name: "missing name", content: "- acl: []", wantErr: true},
+		{name: "duplicate topic", content: "- topic: a\n- topic: a", wantErr: true},
+		{name: "invalid access", content: "- topic: a\n  acl:\n    - {team: t, application: a, access: admin}", wantErr: true},
+		{name: "missing application", content: "- topic: a\n  acl:\n    - {team: t, access: read}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "acls.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			topics, err := ReadACLFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadACLFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (len(topics) != 2 || topics[0].ACL[0] != (ACL{Team: "other", Application: "my-app", Access: "read"})) {
+				t.Errorf("ReadACLFile() = %+v", topics)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Credentials are temporary credentials for the Kafka pool of an environment.
+type Credentials = gql.CreateKafkaCredentialsCreateKafkaCredentialsCreateKafkaCredentialsPayloadCredentialsKafkaCredentials
+
+// TopicName returns the name of a topic in Kafka, which is prefixed with the team. Names that already have the prefix
+// are returned as is.
+func TopicName(team, topic string) string {
+	if strings.HasPrefix(topic, team+".") {
+		return topic
+	}
+	return team + "." + topic
+}
+
+// tlsConfig returns a TLS configuration that authenticates with the client certificate of the credentials.
+func tlsConfig(creds *Credentials) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair([]byte(creds.AccessCert), []byte(creds.AccessKey))
+	if err != nil {
+		return nil, fmt.Errorf("parsing client certificate: %w", err)
+	}
+
+	ca := x509.NewCertPool()
+	if !ca.AppendCertsFromPEM([]byte(creds.CaCert)) {
+		return nil, fmt.Errorf("parsing CA certificate: no certificates found")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      ca,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func brokers(creds *Credentials) []string {
+	var ret []string
+	for b := range strings.SplitSeq(creds.Brokers, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			ret = append(ret, b)
+		}
+	}
+	return ret
+}
+
+// newClient creates a client that connects to Kafka with the credentials.
+func newClient(creds *Credentials, opts ...kgo.Opt) (*kgo.Client, error) {
+	tc, err := tlsConfig(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds := brokers(creds)
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("no Kafka brokers in credentials")
+	}
+
+	client, err := kgo.NewClient(append([]kgo.Opt{kgo.SeedBrokers(seeds...), kgo.DialTLSConfig(tc)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kafka client: %w", err)
+	}
+	return client, nil
+}
+
+// topicPartitions returns the partitions of a topic, and checks that the credentials have access to it.
+func topicPartitions(ctx context.Context, adm *kadm.Client, team, topic, username string) ([]int32, error) {
+	topics, err := adm.ListTopics(ctx, topic)
+	if err != nil {
+		return nil, accessError(err, team, topic, username)
+	}
+
+	detail, ok := topics[topic]
+	if !ok {
+		return nil, fmt.Errorf("topic %q not found", topic)
+	}
+	if detail.Err != nil {
+		return nil, accessError(detail.Err, team, topic, username)
+	}
+	return detail.Partitions.Numbers(), nil
+}
+
+// accessError adds a hint about granting access to the error if the credentials are not allowed to use the topic.
+func accessError(err error, team, topic, username string) error {
+	switch {
+	case errors.Is(err, kerr.UnknownTopicOrPartition):
+		return fmt.Errorf("topic %q not found", topic)
+	case errors.Is(err, kerr.TopicAuthorizationFailed), errors.Is(err, kerr.GroupAuthorizationFailed):
+		return fmt.Errorf("%w\nThe temporary credentials have no access to topic %q, grant access with: nais kafka grant-access %s %s --team %s", err, topic, username, strings.TrimPrefix(topic, team+"."), team)
+	default:
+		return err
+	}
+}
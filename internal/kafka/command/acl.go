@@ -0,0 +1,298 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/aiven"
+	"github.com/nais/cli/internal/kafka"
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+	"github.com/nais/naistrix/output"
+)
+
+func acl(parentFlags *flag.Kafka) *naistrix.Command {
+	flags := &flag.ACL{Kafka: parentFlags}
+	return &naistrix.Command{
+		Name:        "acl",
+		Title:       "Manage access control lists of Kafka topics.",
+		Description: "Commands for listing, granting, revoking and synchronizing the access other applications have to the Kafka topics of the team.",
+		StickyFlags: flags,
+		SubCommands: []*naistrix.Command{
+			aclGrant(flags),
+			aclList(flags),
+			aclRevoke(flags),
+			aclSync(flags),
+		},
+	}
+}
+
+var aclArgs = []naistrix.Argument{
+	{Name: "topic"},
+	{Name: "application"},
+}
+
+func autoCompleteACLTopic(ctx context.Context, args *naistrix.Arguments, flags *flag.ACL) ([]string, string) {
+	if args.Len() != 0 {
+		return nil, ""
+	}
+	return autoCompleteTopicNames(ctx, flags.Team, string(flags.Environment))
+}
+
+func aclList(parentFlags *flag.ACL) *naistrix.Command {
+	flags := &flag.ACLList{
+		ACL:    parentFlags,
+		Output: "table",
+	}
+	return &naistrix.Command{
+		Name:         "list",
+		Title:        "List the access control list of a Kafka topic.",
+		Description:  "Lists the applications that have access to a topic owned by the team. The list is read through the Nais API, or from the cluster if the API cannot be reached.",
+		Flags:        flags,
+		Args:         topicArgs,
+		ValidateFunc: validation.RequireEnvironment(flags),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			return autoCompleteACLTopic(ctx, args, parentFlags)
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "List the applications with access to the topic my-topic in environment dev.",
+				Command:     "my-topic --environment dev",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			acls, fromCluster, err := kafka.ListACLs(ctx, flags.Team, string(flags.Environment), args.Get("topic"))
+			if err != nil {
+				return err
+			}
+			if fromCluster {
+				out.Warnf("Unable to list the ACL through the Nais API, it was read from the cluster instead.\n")
+			}
+
+			if flags.Output == "json" {
+				return out.JSON(output.JSONWithPrettyOutput()).Render(acls)
+			}
+
+			if len(acls) == 0 {
+				out.Println("No applications have access to the topic.")
+				return nil
+			}
+			return out.Table().Render(acls)
+		},
+	}
+}
+
+func aclGrant(parentFlags *flag.ACL) *naistrix.Command {
+	flags := &flag.ACLGrant{
+		ACL:    parentFlags,
+		Access: "read",
+	}
+	return &naistrix.Command{
+		Name:  "grant",
+		Title: "Grant an application access to a Kafka topic.",
+		Description: heredoc.Doc(`
+			Adds an entry to the access control list of a topic owned by the team. The application is given as TEAM/APPLICATION, or as APPLICATION for an application in the team. Use * as the team or application to match all.
+
+			The Topic resource is updated in the cluster, which requires access to the cluster.
+		`),
+		Flags: flags,
+		Args:  aclArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return aiven.ValidAclPermission(flags.Access)
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			return autoCompleteACLTopic(ctx, args, parentFlags)
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Give the application my-app in the team other-team read access to the topic my-topic.",
+				Command:     "my-topic other-team/my-app --environment dev",
+			},
+			{
+				Description: "Give the application my-app in the team read and write access to the topic my-topic.",
+				Command:     "my-topic my-app --environment dev --access readwrite",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			team, app, err := kafka.ParseApplication(flags.Team, args.Get("application"))
+			if err != nil {
+				return err
+			}
+
+			entry := kafka.ACL{Team: team, Application: app, Access: flags.Access}
+			added, err := kafka.GrantACL(ctx, flags.Team, string(flags.Environment), args.Get("topic"), entry)
+			if err != nil {
+				return err
+			}
+
+			if !added {
+				out.Infof("Topic %q already has the ACL entry %s.\n", args.Get("topic"), entry)
+				return nil
+			}
+			out.Successf("Granted %s on topic %q.\n", entry, args.Get("topic"))
+			return nil
+		},
+	}
+}
+
+func aclRevoke(parentFlags *flag.ACL) *naistrix.Command {
+	flags := &flag.ACLRevoke{ACL: parentFlags}
+	return &naistrix.Command{
+		Name:  "revoke",
+		Title: "Revoke the access of an application to a Kafka topic.",
+		Description: heredoc.Doc(`
+			Removes the entries of an application from the access control list of a topic owned by the team. The application is given as TEAM/APPLICATION, or as APPLICATION for an application in the team.
+
+			The Topic resource is updated in the cluster, which requires access to the cluster.
+		`),
+		Flags: flags,
+		Args:  aclArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.Access == "" {
+					return nil
+				}
+				return aiven.ValidAclPermission(flags.Access)
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			return autoCompleteACLTopic(ctx, args, parentFlags)
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Revoke all access the application my-app in the team other-team has to the topic my-topic.",
+				Command:     "my-topic other-team/my-app --environment dev",
+			},
+			{
+				Description: "Only revoke write access.",
+				Command:     "my-topic other-team/my-app --environment dev --access write",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			team, app, err := kafka.ParseApplication(flags.Team, args.Get("application"))
+			if err != nil {
+				return err
+			}
+
+			out.Warnf("You are about to revoke the access %s/%s has to topic %q in %q.\n", team, app, args.Get("topic"), flags.Environment)
+			if !flags.Yes {
+				if result, err := input.Confirm("Are you sure you want to continue?"); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			entry := kafka.ACL{Team: team, Application: app, Access: flags.Access}
+			revoked, err := kafka.RevokeACL(ctx, flags.Team, string(flags.Environment), args.Get("topic"), entry)
+			if err != nil {
+				return err
+			}
+
+			if revoked == 0 {
+				out.Infof("Topic %q has no matching ACL entries for %s/%s.\n", args.Get("topic"), team, app)
+				return nil
+			}
+			out.Successf("Revoked %d ACL entries for %s/%s on topic %q.\n", revoked, team, app, args.Get("topic"))
+			return nil
+		},
+	}
+}
+
+func aclSync(parentFlags *flag.ACL) *naistrix.Command {
+	flags := &flag.ACLSync{ACL: parentFlags}
+	return &naistrix.Command{
+		Name:  "sync",
+		Title: "Synchronize the access control lists of Kafka topics with a file.",
+		Description: heredoc.Doc(`
+			Updates the access control list of each topic in the file to match the declared entries. Entries that are not declared are removed. The changes are shown before they are applied.
+
+			The file is a YAML list of topics:
+
+			  - topic: my-topic
+			    acl:
+			      - team: other-team
+			        application: my-app
+			        access: read
+
+			The Topic resources are updated in the cluster, which requires access to the cluster.
+		`),
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.File == "" {
+					return fmt.Errorf("file is required, set using --file flag")
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Show the changes needed to match acls.yaml in environment dev.",
+				Command:     "--environment dev --file acls.yaml --dry-run",
+			},
+			{
+				Description: "Apply the changes.",
+				Command:     "--environment dev --file acls.yaml",
+			},
+		},
+		RunFunc: func(ctx context.Context, _ *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			declared, err := kafka.ReadACLFile(flags.File)
+			if err != nil {
+				return err
+			}
+
+			changes, err := kafka.PlanACLSync(ctx, flags.Team, string(flags.Environment), declared)
+			if err != nil {
+				return err
+			}
+
+			changed := 0
+			for _, c := range changes {
+				if !c.Changed() {
+					out.Printf("Topic %q is up to date.\n", c.Topic)
+					continue
+				}
+				changed++
+				out.Printf("Topic %q:\n", c.Topic)
+				for _, a := range c.Add {
+					out.Printf("  + %s\n", a)
+				}
+				for _, a := range c.Remove {
+					out.Printf("  - %s\n", a)
+				}
+			}
+
+			if changed == 0 || flags.DryRun {
+				return nil
+			}
+
+			if !flags.Yes {
+				if result, err := input.Confirm(fmt.Sprintf("Apply the changes to %d topics in %q?", changed, flags.Environment)); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			for _, c := range changes {
+				if !c.Changed() {
+					continue
+				}
+				if err := kafka.ApplyACLChange(ctx, flags.Team, string(flags.Environment), c); err != nil {
+					return fmt.Errorf("topic %q: %w", c.Topic, err)
+				}
+				out.Successf("Updated the ACL of topic %q.\n", c.Topic)
+			}
+			return nil
+		},
+	}
+}
@@ -1,7 +1,12 @@
 package command
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
 	"github.com/nais/cli/internal/flags"
+	"github.com/nais/cli/internal/kafka"
 	"github.com/nais/cli/internal/kafka/command/flag"
 	"github.com/nais/cli/internal/validation"
 	"github.com/nais/naistrix"
@@ -17,9 +22,44 @@ func Kafka(parentFlags *flags.GlobalFlags) *naistrix.Command {
 		StickyFlags:  flags,
 		ValidateFunc: validation.RequireTeam(flags),
 		SubCommands: []*naistrix.Command{
+			acl(flags),
+			consume(flags),
 			credentials(flags),
 			grantAccess(flags),
+			lag(flags),
 			list(flags),
+			produce(flags),
 		},
 	}
 }
+
+var topicArgs = []naistrix.Argument{
+	{Name: "topic"},
+}
+
+func autoCompleteTopicNames(ctx context.Context, team, environment string) ([]string, string) {
+	if team == "" {
+		return nil, "Please provide team to auto-complete Kafka topic names. 'nais defaults set team <team>', or '--team <team>' flag."
+	}
+
+	if environment == "" {
+		return nil, "Please provide environment to auto-complete Kafka topic names. '-e, --environment <environment>' flag."
+	}
+
+	topics, err := kafka.GetTeamTopics(ctx, team, environment, nil)
+	if err != nil {
+		return nil, "Unable to fetch Kafka topics."
+	}
+
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, topic.Name)
+	}
+	names = slices.Compact(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Sprintf("No Kafka topics found in environment %q.", environment)
+	}
+
+	return names, "Select a Kafka topic."
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/kafka"
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func consume(parentFlags *flag.Kafka) *naistrix.Command {
+	flags := &flag.Consume{
+		Kafka:     parentFlags,
+		TTL:       "1h",
+		Offset:    -1,
+		Partition: -1,
+		Output:    "text",
+	}
+	return &naistrix.Command{
+		Name:  "consume",
+		Title: "Consume records from a Kafka topic.",
+		Description: heredoc.Doc(`
+			Creates temporary credentials for Kafka and prints the records of a topic owned by the team.
+
+			New records are printed as they are produced, unless --from-beginning, --offset or --since is given. No consumer group is used, so no offsets are committed. Values serialized with an Avro or JSON schema are decoded with the schema registry, and JSON values are pretty-printed.
+
+			The temporary credentials must be granted read access to the topic with 'nais kafka grant-access'.
+		`),
+		Flags: flags,
+		Args:  topicArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() != 0 {
+				return nil, ""
+			}
+			return autoCompleteTopicNames(ctx, flags.Team, string(flags.Environment))
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Print new records on the topic my-topic in environment dev.",
+				Command:     "my-topic --environment dev",
+			},
+			{
+				Description: "Print the first 10 records on the topic.",
+				Command:     "my-topic --environment dev --from-beginning --max 10",
+			},
+			{
+				Description: "Print the records produced the last hour, one JSON object per line.",
+				Command:     "my-topic --environment dev --since 1h --output json",
+			},
+			{
+				Description: "Print the record at offset 1234 on partition 2.",
+				Command:     "my-topic --environment dev --partition 2 --offset 1234 --max 1",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return kafka.Consume(ctx, flags.Team, string(flags.Environment), args.Get("topic"), flags, out)
+		},
+	}
+}
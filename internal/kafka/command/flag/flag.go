@@ -2,6 +2,8 @@ package flag
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/nais/cli/internal/flags"
 	"github.com/nais/cli/internal/labels"
@@ -46,3 +48,92 @@ type GrantAccess struct {
 	*Kafka
 	Access string `name:"access" short:"a" usage:"Access |LEVEL| (readwrite, read and write)."`
 }
+
+type ConsumeOutput string
+
+var _ naistrix.FlagAutoCompleter = (*ConsumeOutput)(nil)
+
+func (o *ConsumeOutput) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"text", "json"}, "Available output formats."
+}
+
+type Consume struct {
+	*Kafka
+	TTL           string        `name:"ttl" usage:"Time-to-live for the temporary credentials used to connect (e.g. '1h', '1d')."`
+	FromBeginning bool          `name:"from-beginning" usage:"Consume from the oldest record on each partition."`
+	Offset        int           `name:"offset" usage:"Consume from |OFFSET| on each partition, or on the partition given with --partition."`
+	Since         time.Duration `name:"since" short:"s" usage:"Consume records produced within |DURATION|. Examples: 30m, 2h."`
+	Partition     int           `name:"partition" short:"p" usage:"Only consume from |PARTITION|."`
+	Max           int           `name:"max" short:"n" usage:"Stop after |NUMBER| records. 0 consumes until interrupted."`
+	Output        ConsumeOutput `name:"output" short:"o" usage:"Format output (text or json). json prints one record per line."`
+}
+
+func (c *Consume) Validate() error {
+	starts := 0
+	for _, set := range []bool{c.FromBeginning, c.Offset >= 0, c.Since > 0} {
+		if set {
+			starts++
+		}
+	}
+	if starts > 1 {
+		return fmt.Errorf("only one of --from-beginning, --offset and --since can be used")
+	}
+	if c.Since < 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	if c.Max < 0 {
+		return fmt.Errorf("--max must be positive")
+	}
+	if c.Output != "text" && c.Output != "json" {
+		return fmt.Errorf("invalid output format %q, must be one of: text, json", c.Output)
+	}
+	return nil
+}
+
+type Produce struct {
+	*Kafka
+	TTL          string `name:"ttl" usage:"Time-to-live for the temporary credentials used to connect (e.g. '1h', '1d')."`
+	Key          string `name:"key" short:"k" usage:"|KEY| of every record."`
+	KeySeparator string `name:"key-separator" short:"K" usage:"Split each line at the first |SEPARATOR| into the key and value of the record."`
+}
+
+func (p *Produce) Validate() error {
+	if p.Key != "" && p.KeySeparator != "" {
+		return fmt.Errorf("only one of --key and --key-separator can be used")
+	}
+	return nil
+}
+
+type Lag struct {
+	*Kafka
+	TTL    string `name:"ttl" usage:"Time-to-live for the temporary credentials used to connect (e.g. '1h', '1d')."`
+	Group  string `name:"group" short:"g" usage:"Only show the lag of the consumer |GROUP|."`
+	Output Output `name:"output" short:"o" usage:"Format output (table or json)."`
+}
+
+type ACL struct {
+	*Kafka
+}
+
+type ACLList struct {
+	*ACL
+	Output Output `name:"output" short:"o" usage:"Format output (table or json)."`
+}
+
+type ACLGrant struct {
+	*ACL
+	Access string `name:"access" short:"a" usage:"Access |LEVEL| (readwrite, read and write)."`
+}
+
+type ACLRevoke struct {
+	*ACL
+	Access string `name:"access" short:"a" usage:"Only revoke the entry with access |LEVEL| (readwrite, read and write). Defaults to all entries of the application."`
+	Yes    bool   `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type ACLSync struct {
+	*ACL
+	File   string `name:"file" short:"f" usage:"|FILE| with the declared ACL of each topic."`
+	DryRun bool   `name:"dry-run" usage:"Show the changes without applying them."`
+	Yes    bool   `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
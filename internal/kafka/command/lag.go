@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/kafka"
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func lag(parentFlags *flag.Kafka) *naistrix.Command {
+	flags := &flag.Lag{
+		Kafka: parentFlags,
+		TTL:   "1h",
+	}
+	return &naistrix.Command{
+		Name:  "lag",
+		Title: "Show consumer group lag on a Kafka topic.",
+		Description: heredoc.Doc(`
+			Creates temporary credentials for Kafka and shows, per partition, how far each consumer group that consumes a topic owned by the team is behind the end of the partition.
+
+			Only consumer groups the temporary credentials are allowed to describe are shown.
+		`),
+		Flags:        flags,
+		Args:         topicArgs,
+		ValidateFunc: validation.RequireEnvironment(flags),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() != 0 {
+				return nil, ""
+			}
+			return autoCompleteTopicNames(ctx, flags.Team, string(flags.Environment))
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Show the lag of all consumer groups on the topic my-topic in environment dev.",
+				Command:     "my-topic --environment dev",
+			},
+			{
+				Description: "Show the lag of a single consumer group.",
+				Command:     "my-topic --environment dev --group my-app",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return kafka.Lag(ctx, flags.Team, string(flags.Environment), args.Get("topic"), flags, out)
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/kafka"
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func produce(parentFlags *flag.Kafka) *naistrix.Command {
+	flags := &flag.Produce{
+		Kafka: parentFlags,
+		TTL:   "1h",
+	}
+	return &naistrix.Command{
+		Name:  "produce",
+		Title: "Produce records to a Kafka topic.",
+		Description: heredoc.Doc(`
+			Creates temporary credentials for Kafka and produces a record to a topic owned by the team for each line read from stdin. Empty lines are skipped.
+
+			Values are sent as is, they are not serialized with a schema. The temporary credentials must be granted write access to the topic with 'nais kafka grant-access'.
+		`),
+		Flags: flags,
+		Args:  topicArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() != 0 {
+				return nil, ""
+			}
+			return autoCompleteTopicNames(ctx, flags.Team, string(flags.Environment))
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Produce a single record to the topic my-topic in environment dev.",
+				Command:     `my-topic --environment dev <<< '{"id": 1}'`,
+			},
+			{
+				Description: "Produce the records in a file, with the key before the first colon of each line.",
+				Command:     "my-topic --environment dev --key-separator : < records.txt",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return kafka.Produce(ctx, flags.Team, string(flags.Environment), args.Get("topic"), flags, os.Stdin, out)
+		},
+	}
+}
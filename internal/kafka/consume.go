@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Record is a consumed record with its key and value decoded for output.
+type Record struct {
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Timestamp time.Time         `json:"timestamp"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Key       json.RawMessage   `json:"key"`
+	Value     json.RawMessage   `json:"value"`
+}
+
+// Consume prints the records of a topic of the team, starting at the end of each partition unless another start is
+// given. Records are consumed without a consumer group, so no offsets are committed. Consuming stops after fl.Max
+// records, or when interrupted.
+func Consume(ctx context.Context, team, environment, topic string, fl *flag.Consume, out *naistrix.OutputWriter) error {
+	topic = TopicName(team, topic)
+
+	creds, err := CreateCredentials(ctx, team, environment, fl.TTL)
+	if err != nil {
+		return fmt.Errorf("creating Kafka credentials: %w", err)
+	}
+
+	if err := checkPartition(ctx, creds, team, topic, fl.Partition); err != nil {
+		return err
+	}
+
+	offset := startOffset(fl, time.Now())
+	opts := []kgo.Opt{kgo.ConsumeTopics(topic), kgo.ConsumeResetOffset(offset)}
+	if fl.Partition >= 0 {
+		opts = []kgo.Opt{kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{
+			topic: {int32(fl.Partition): offset}, // #nosec G115
+		})}
+	}
+
+	client, err := newClient(creds, opts...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tc, err := tlsConfig(creds)
+	if err != nil {
+		return err
+	}
+	d := &decoder{}
+	if creds.SchemaRegistry != "" {
+		d.registry = newSchemaRegistry(creds.SchemaRegistry, tc)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	out.Verbosef("Consuming %s from %s\n", topic, offset)
+	warned := map[string]bool{}
+	consumed := 0
+	for {
+		fetches := client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var fetchErr error
+		fetches.EachError(func(_ string, _ int32, err error) {
+			if fetchErr == nil {
+				fetchErr = err
+			}
+		})
+		if fetchErr != nil {
+			return accessError(fetchErr, team, topic, creds.Username)
+		}
+
+		for _, r := range fetches.Records() {
+			record, errs := decodeRecord(ctx, d, r)
+			for _, err := range errs {
+				if !warned[err.Error()] {
+					warned[err.Error()] = true
+					out.Warnf("Showing undecoded values: %v\n", err)
+				}
+			}
+
+			if fl.Output == "json" {
+				b, err := json.Marshal(record)
+				if err != nil {
+					return err
+				}
+				out.Println(string(b))
+			} else {
+				printRecord(out, record)
+			}
+
+			consumed++
+			if fl.Max > 0 && consumed >= fl.Max {
+				return nil
+			}
+		}
+	}
+}
+
+// checkPartition checks that the credentials have access to the topic, and that the partition exists if one is given.
+func checkPartition(ctx context.Context, creds *Credentials, team, topic string, partition int) error {
+	client, err := newClient(creds)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	partitions, err := topicPartitions(ctx, kadm.NewClient(client), team, topic, creds.Username)
+	if err != nil {
+		return err
+	}
+	if partition >= 0 && !slices.Contains(partitions, int32(partition)) { // #nosec G115
+		return fmt.Errorf("topic %q has no partition %d", topic, partition)
+	}
+	return nil
+}
+
+// startOffset returns where to start consuming each partition.
+func startOffset(fl *flag.Consume, now time.Time) kgo.Offset {
+	switch {
+	case fl.FromBeginning:
+		return kgo.NewOffset().AtStart()
+	case fl.Offset >= 0:
+		return kgo.NewOffset().At(int64(fl.Offset))
+	case fl.Since > 0:
+		return kgo.NewOffset().AfterMilli(now.Add(-fl.Since).UnixMilli())
+	default:
+		return kgo.NewOffset().AtEnd()
+	}
+}
+
+// decodeRecord decodes the key and value of a record. The errors explain keys and values that could not be decoded.
+func decodeRecord(ctx context.Context, d *decoder, r *kgo.Record) (Record, []error) {
+	var errs []error
+	key, err := d.decode(ctx, r.Key)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	value, err := d.decode(ctx, r.Value)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	var headers map[string]string
+	if len(r.Headers) > 0 {
+		headers = make(map[string]string, len(r.Headers))
+		for _, h := range r.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+	}
+
+	return Record{
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Timestamp: r.Timestamp,
+		Headers:   headers,
+		Key:       key,
+		Value:     value,
+	}, errs
+}
+
+func printRecord(out *naistrix.OutputWriter, r Record) {
+	out.Printf("Partition: %d  Offset: %d  Timestamp: %s  Key: %s\n", r.Partition, r.Offset, r.Timestamp.Local().Format(time.DateTime), formatText(r.Key, false))
+	if len(r.Headers) > 0 {
+		keys := make([]string, 0, len(r.Headers))
+		for k := range r.Headers {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			out.Printf("Header: %s=%s\n", k, r.Headers[k])
+		}
+	}
+	out.Println(formatText(r.Value, true))
+	out.Println()
+}
+
+// formatText formats a decoded key or value for text output. Strings are shown without quotes, and other JSON values
+// are indented if pretty is set.
+func formatText(value json.RawMessage, pretty bool) string {
+	var s string
+	if bytes.HasPrefix(value, []byte(`"`)) && json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	if !pretty {
+		return string(value)
+	}
+
+	var b bytes.Buffer
+	if err := json.Indent(&b, value, "", "  "); err != nil {
+		return string(value)
+	}
+	return b.String()
+}
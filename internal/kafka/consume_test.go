@@ -0,0 +1,129 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestTopicName(t *testing.T) {
+	for topic, want := range map[string]string{
+		"events":           "myteam.events",
+		"myteam.events":    "myteam.events",
+		"otherteam.events": "myteam.otherteam.events",
+	} {
+		if got := TopicName("myteam", topic); got != want {
+			t.Errorf("TopicName(%q) = %q, want %q", topic, got, want)
+		}
+	}
+}
+
+func TestStartOffset(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		flags flag.Consume
+		want  kgo.Offset
+	}{
+		{name: "default", flags: flag.Consume{Offset: -1}, want: kgo.NewOffset().AtEnd()},
+		{name: "from beginning", flags: flag.Consume{Offset: -1, FromBeginning: true}, want: kgo.NewOffset().AtStart()},
+		{name: "offset", flags: flag.Consume{Offset: 42}, want: kgo.NewOffset().At(42)},
+		{name: "since", flags: flag.Consume{Offset: -1, Since: time.Hour}, want: kgo.NewOffset().AfterMilli(now.Add(-time.Hour).UnixMilli())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startOffset(&tt.flags, now); got != tt.want {
+				t.Errorf("startOffset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		flags     flag.Produce
+		wantKey   string
+		wantValue string
+	}{
+		{name: "value", line: "a:b", wantValue: "a:b"},
+		{name: "key", line: "a:b", flags: flag.Produce{Key: "k"}, wantKey: "k", wantValue: "a:b"},
+		{name: "separator", line: "a:b:c", flags: flag.Produce{KeySeparator: ":"}, wantKey: "a", wantValue: "b:c"},
+		{name: "separator missing", line: "abc", flags: flag.Produce{KeySeparator: ":"}, wantValue: "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := lineRecord(tt.line, &tt.flags)
+			if string(r.Key) != tt.wantKey || string(r.Value) != tt.wantValue {
+				t.Errorf("lineRecord() = %q, %q, want %q, %q", r.Key, r.Value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	tests := []struct {
+		value  string
+		pretty bool
+		want   string
+	}{
+		{value: `"hello"`, pretty: true, want: "hello"},
+		{value: `null`, want: "null"},
+		{value: `{"id":1}`, want: `{"id":1}`},
+		{value: `{"id":1}`, pretty: true, want: "{\n  \"id\": 1\n}"},
+	}
+	for _, tt := range tests {
+		if got := formatText([]byte(tt.value), tt.pretty); got != tt.want {
+			t.Errorf("formatText(%s, %v) = %q, want %q", tt.value, tt.pretty, got, tt.want)
+		}
+	}
+}
+
+func TestTopicLag(t *testing.T) {
+	member := &kadm.DescribedGroupMember{ClientID: "consumer-1", ClientHost: "/10.0.0.1"}
+	lags := kadm.DescribedGroupLags{
+		"b-group": {
+			Group: "b-group",
+			State: "Stable",
+			Lag: kadm.GroupLag{
+				"myteam.events": {
+					1: {Member: member, Partition: 1, Commit: kadm.Offset{At: 5}, End: kadm.ListedOffset{Offset: 10}, Lag: 5},
+					0: {Member: member, Partition: 0, Commit: kadm.Offset{At: -1}, End: kadm.ListedOffset{Offset: 3}, Lag: 3},
+				},
+			},
+		},
+		"a-group": {
+			Group: "a-group",
+			State: "Empty",
+			Lag: kadm.GroupLag{
+				"myteam.events": {
+					0: {Partition: 0, Commit: kadm.Offset{At: 3}, End: kadm.ListedOffset{Offset: 3}},
+					1: {Partition: 1, Commit: kadm.Offset{At: -1}, End: kadm.ListedOffset{Offset: 10}, Lag: 10},
+				},
+				"myteam.other": {
+					0: {Partition: 0, Commit: kadm.Offset{At: 1}, End: kadm.ListedOffset{Offset: 1}},
+				},
+			},
+		},
+	}
+
+	want := []PartitionLag{
+		{Group: "a-group", State: "Empty", Partition: 0, Committed: 3, End: 3, Lag: 0, Member: "-"},
+		{Group: "b-group", State: "Stable", Partition: 0, Committed: -1, End: 3, Lag: 3, Member: "consumer-1@/10.0.0.1"},
+		{Group: "b-group", State: "Stable", Partition: 1, Committed: 5, End: 10, Lag: 5, Member: "consumer-1@/10.0.0.1"},
+	}
+
+	got := topicLag(lags, "myteam.events")
+	if len(got) != len(want) {
+		t.Fatalf("topicLag() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("topicLag()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
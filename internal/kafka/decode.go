@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+// wireFormatMagic is the first byte of a value serialized with the schema registry wire format, followed by a 4 byte
+// schema ID and the serialized value.
+const wireFormatMagic = 0
+
+// schemaRegistry fetches and caches the schemas that values were serialized with.
+type schemaRegistry struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	schemas map[uint32]*registeredSchema
+}
+
+type registeredSchema struct {
+	schemaType string
+	codec      *goavro.Codec
+	err        error
+}
+
+func newSchemaRegistry(url string, tc *tls.Config) *schemaRegistry {
+	return &schemaRegistry{
+		url: strings.TrimSuffix(url, "/"),
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tc},
+		},
+		schemas: map[uint32]*registeredSchema{},
+	}
+}
+
+// schema returns the schema with the given ID. Failures are cached as well, so that a schema that cannot be fetched is
+// only attempted once.
+func (r *schemaRegistry) schema(ctx context.Context, id uint32) (*registeredSchema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.schemas[id]; ok {
+		return s, s.err
+	}
+
+	s := &registeredSchema{}
+	s.schemaType, s.codec, s.err = r.fetch(ctx, id)
+	r.schemas[id] = s
+	return s, s.err
+}
+
+func (r *schemaRegistry) fetch(ctx context.Context, id uint32) (string, *goavro.Codec, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/schemas/ids/%d", r.url, id), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("fetching schema %d: %w", id, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("fetching schema %d: schema registry responded with %s", id, resp.Status)
+	}
+
+	var body struct {
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", nil, fmt.Errorf("decoding schema %d: %w", id, err)
+	}
+
+	// The schema type is left out for Avro schemas.
+	schemaType := body.SchemaType
+	if schemaType == "" {
+		schemaType = "AVRO"
+	}
+	if schemaType != "AVRO" {
+		return schemaType, nil, nil
+	}
+
+	codec, err := goavro.NewCodec(body.Schema)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing schema %d: %w", id, err)
+	}
+	return schemaType, codec, nil
+}
+
+// decoder formats keys and values of records for output.
+type decoder struct {
+	registry *schemaRegistry
+}
+
+// decode returns the value as JSON if it is JSON, or serialized with a JSON or Avro schema from the schema registry.
+// Other values are returned as a JSON string, hex encoded if they are not valid UTF-8. The returned error explains why a
+// value that was serialized with a schema could not be decoded.
+func (d *decoder) decode(ctx context.Context, value []byte) (json.RawMessage, error) {
+	if value == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	if id, payload, ok := splitWireFormat(value); ok && d.registry != nil {
+		decoded, err := d.decodeWithSchema(ctx, id, payload)
+		if err == nil {
+			return decoded, nil
+		}
+		return rawValue(value), err
+	}
+
+	if json.Valid(value) {
+		return compactJSON(value), nil
+	}
+	return rawValue(value), nil
+}
+
+func (d *decoder) decodeWithSchema(ctx context.Context, id uint32, payload []byte) (json.RawMessage, error) {
+	s, err := d.registry.schema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.schemaType {
+	case "AVRO":
+		native, _, err := s.codec.NativeFromBinary(payload)
+		if err != nil {
+			return nil, fmt.Errorf("decoding value with schema %d: %w", id, err)
+		}
+		textual, err := s.codec.TextualFromNative(nil, native)
+		if err != nil {
+			return nil, fmt.Errorf("decoding value with schema %d: %w", id, err)
+		}
+		return sortedJSON(textual)
+	case "JSON":
+		if !json.Valid(payload) {
+			return nil, fmt.Errorf("value with JSON schema %d is not valid JSON", id)
+		}
+		return compactJSON(payload), nil
+	default:
+		return nil, fmt.Errorf("decoding %s values is not supported", strings.ToLower(s.schemaType))
+	}
+}
+
+// splitWireFormat returns the schema ID and payload of a value serialized with the schema registry wire format.
+func splitWireFormat(value []byte) (uint32, []byte, bool) {
+	if len(value) < 5 || value[0] != wireFormatMagic {
+		return 0, nil, false
+	}
+	return binary.BigEndian.Uint32(value[1:5]), value[5:], true
+}
+
+// sortedJSON sorts the keys of the objects in a JSON value, as the fields of decoded Avro records are in random order.
+func sortedJSON(value []byte) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func compactJSON(value []byte) json.RawMessage {
+	var b bytes.Buffer
+	if err := json.Compact(&b, value); err != nil {
+		return rawValue(value)
+	}
+	return b.Bytes()
+}
+
+func rawValue(value []byte) json.RawMessage {
+	s := string(value)
+	if !utf8.Valid(value) {
+		s = hex.EncodeToString(value)
+	}
+	b, _ := json.Marshal(s)
+	return b
+}
@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+func wireFormat(id uint32, payload []byte) []byte {
+	b := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(b[1:], id)
+	return append(b, payload...)
+}
+
+func TestDecode(t *testing.T) {
+	const schema = `{"type": "record", "name": "Event", "fields": [{"name": "id", "type": "long"}, {"name": "name", "type": "string"}]}`
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	avro, err := codec.BinaryFromNative(nil, map[string]any{"id": int64(1), "name": "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/ids/1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"schema": schema})
+		case "/schemas/ids/2":
+			_, _ = w.Write([]byte(`{"schemaType": "JSON", "schema": "{}"}`))
+		case "/schemas/ids/3":
+			_, _ = w.Write([]byte(`{"schemaType": "PROTOBUF", "schema": ""}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	d := &decoder{registry: newSchemaRegistry(server.URL+"/", nil)}
+
+	tests := []struct {
+		name    string
+		value   []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "nil", value: nil, want: `null`},
+		{name: "json", value: []byte(`{"id": 1,  "name": "test"}`), want: `{"id":1,"name":"test"}`},
+		{name: "text", value: []byte("hello"), want: `"hello"`},
+		{name: "binary", value: []byte{0xff, 0xfe}, want: `"fffe"`},
+		{name: "avro", value: wireFormat(1, avro), want: `{"id":1,"name":"test"}`},
+		{name: "json schema", value: wireFormat(2, []byte(`{"id": 1}`)), want: `{"id":1}`},
+		{name: "unsupported schema", value: wireFormat(3, []byte("x")), wantErr: true},
+		{name: "unknown schema", value: wireFormat(4, avro), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.decode(t.Context(), tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != "" && string(got) != tt.want {
+				t.Errorf("decode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitWireFormat(t *testing.T) {
+	id, payload, ok := splitWireFormat(wireFormat(42, []byte("payload")))
+	if !ok || id != 42 || string(payload) != "payload" {
+		t.Errorf("splitWireFormat() = %d, %q, %v", id, payload, ok)
+	}
+
+	for _, value := range [][]byte{[]byte("{}"), {0, 0, 0}, {1, 0, 0, 0, 1}} {
+		if _, _, ok := splitWireFormat(value); ok {
+			t.Errorf("splitWireFormat(%v) = ok, want not ok", value)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// PartitionLag is how far a consumer group is behind on a partition of a topic.
+type PartitionLag struct {
+	Group     string `heading:"Group" json:"group"`
+	State     string `heading:"State" json:"state"`
+	Partition int32  `heading:"Partition" json:"partition"`
+	Committed int64  `heading:"Committed" json:"committed"`
+	End       int64  `heading:"End" json:"end"`
+	Lag       int64  `heading:"Lag" json:"lag"`
+	Member    string `heading:"Member" json:"member"`
+}
+
+// Lag shows the lag of the consumer groups that consume a topic of the team, per partition.
+func Lag(ctx context.Context, team, environment, topic string, fl *flag.Lag, out *naistrix.OutputWriter) error {
+	topic = TopicName(team, topic)
+
+	creds, err := CreateCredentials(ctx, team, environment, fl.TTL)
+	if err != nil {
+		return fmt.Errorf("creating Kafka credentials: %w", err)
+	}
+
+	client, err := newClient(creds)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	adm := kadm.NewClient(client)
+	if _, err := topicPartitions(ctx, adm, team, topic, creds.Username); err != nil {
+		return err
+	}
+
+	groups := []string{fl.Group}
+	if fl.Group == "" {
+		listed, err := adm.ListGroups(ctx)
+		if err != nil {
+			return accessError(err, team, topic, creds.Username)
+		}
+		groups = listed.Groups()
+	}
+
+	ret := []PartitionLag{}
+	if len(groups) > 0 {
+		lags, err := adm.Lag(ctx, groups...)
+		if err != nil {
+			return accessError(err, team, topic, creds.Username)
+		}
+		lags.EachError(func(l kadm.DescribedGroupLag) {
+			out.Warnf("Unable to get lag of consumer group %q: %v\n", l.Group, l.Error())
+		})
+		ret = topicLag(lags, topic)
+	}
+
+	if fl.Output == "json" {
+		return out.JSON(output.JSONWithPrettyOutput()).Render(ret)
+	}
+
+	if len(ret) == 0 {
+		out.Printf("No consumer groups have committed offsets on %s.\n", topic)
+		return nil
+	}
+	return out.Table().Render(ret)
+}
+
+// topicLag returns the lag of each group that has committed offsets on, or is assigned partitions of, the topic.
+func topicLag(lags kadm.DescribedGroupLags, topic string) []PartitionLag {
+	ret := []PartitionLag{}
+	for _, l := range lags.Sorted() {
+		if l.Error() != nil {
+			continue
+		}
+
+		partitions := l.Lag[topic]
+		numbers := make([]int32, 0, len(partitions))
+		for p := range partitions {
+			numbers = append(numbers, p)
+		}
+		slices.Sort(numbers)
+
+		for _, p := range numbers {
+			ml := partitions[p]
+			if ml.Commit.At < 0 && ml.Member == nil {
+				continue
+			}
+
+			member := "-"
+			if ml.Member != nil {
+				member = ml.Member.ClientID + "@" + ml.Member.ClientHost
+			}
+
+			ret = append(ret, PartitionLag{
+				Group:     l.Group,
+				State:     l.State,
+				Partition: p,
+				Committed: ml.Commit.At,
+				End:       ml.End.Offset,
+				Lag:       ml.Lag,
+				Member:    member,
+			})
+		}
+	}
+	return ret
+}
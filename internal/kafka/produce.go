@@ -0,0 +1,94 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/nais/cli/internal/kafka/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// maxRecordSize is the largest record value read from input, which is the default maximum message size in Kafka.
+const maxRecordSize = 1024 * 1024
+
+// Produce produces a record to a topic of the team for each non-empty line read from in. Values are sent as is, they are
+// not serialized with a schema.
+func Produce(ctx context.Context, team, environment, topic string, fl *flag.Produce, in io.Reader, out *naistrix.OutputWriter) error {
+	topic = TopicName(team, topic)
+
+	creds, err := CreateCredentials(ctx, team, environment, fl.TTL)
+	if err != nil {
+		return fmt.Errorf("creating Kafka credentials: %w", err)
+	}
+
+	// Idempotent writes need access to the cluster, which the temporary credentials do not have.
+	client, err := newClient(creds, kgo.DefaultProduceTopic(topic), kgo.DisableIdempotentWrite())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if _, err := topicPartitions(ctx, kadm.NewClient(client), team, topic, creds.Username); err != nil {
+		return err
+	}
+
+	var (
+		mu         sync.Mutex
+		produceErr error
+		produced   int
+	)
+	callback := func(_ *kgo.Record, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if produceErr == nil {
+				produceErr = err
+			}
+			return
+		}
+		produced++
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		client.Produce(ctx, lineRecord(line, fl), callback)
+	}
+	readErr := scanner.Err()
+
+	if err := client.Flush(ctx); err != nil {
+		return err
+	}
+	if produceErr != nil {
+		return accessError(produceErr, team, topic, creds.Username)
+	}
+	if readErr != nil {
+		return fmt.Errorf("reading input: %w", readErr)
+	}
+
+	out.Infof("Produced %d records to %s\n", produced, topic)
+	return nil
+}
+
+// lineRecord returns the record for a line of input.
+func lineRecord(line string, fl *flag.Produce) *kgo.Record {
+	if fl.KeySeparator != "" {
+		if key, value, ok := strings.Cut(line, fl.KeySeparator); ok {
+			return kgo.KeyStringRecord(key, value)
+		}
+	}
+	if fl.Key != "" {
+		return kgo.KeyStringRecord(fl.Key, line)
+	}
+	return kgo.StringRecord(line)
+}
@@ -0,0 +1,70 @@
+// Package keyvalue compares and copies the key-value pairs of secrets and configs between environments.
+package keyvalue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Plan is the changes needed to bring the values of a secret or config in line with values from a file or from another
+// environment.
+type Plan[T any] struct {
+	Add    []T
+	Update []T
+	Remove []string
+}
+
+// Changed returns true if the secret or config must be updated.
+func (p Plan[T]) Changed() bool {
+	return len(p.Add) > 0 || len(p.Update) > 0 || len(p.Remove) > 0
+}
+
+// PlanCopy returns the changes needed to copy the source values into the target values, where key returns the key of a
+// value. Only the given keys are copied, or all keys if none are given. Target values that are == to the source are
+// left as is, and target keys that are not copied are removed when replace is true.
+func PlanCopy[T comparable](source, target []T, key func(T) string, keys []string, replace bool) (Plan[T], error) {
+	selected := source
+	if len(keys) > 0 {
+		selected = make([]T, 0, len(keys))
+		for _, k := range keys {
+			i := slices.IndexFunc(source, func(v T) bool { return key(v) == k })
+			if i < 0 {
+				return Plan[T]{}, fmt.Errorf("key %q not found in the source environment", k)
+			}
+			selected = append(selected, source[i])
+		}
+	}
+
+	existing := make(map[string]T, len(target))
+	for _, v := range target {
+		existing[key(v)] = v
+	}
+
+	var plan Plan[T]
+	copied := make(map[string]bool, len(selected))
+	for _, v := range selected {
+		copied[key(v)] = true
+		t, ok := existing[key(v)]
+		switch {
+		case !ok:
+			plan.Add = append(plan.Add, v)
+		case t != v:
+			plan.Update = append(plan.Update, v)
+		}
+	}
+
+	if replace {
+		for _, v := range target {
+			if !copied[key(v)] {
+				plan.Remove = append(plan.Remove, key(v))
+			}
+		}
+	}
+
+	compare := func(a, b T) int { return strings.Compare(key(a), key(b)) }
+	slices.SortFunc(plan.Add, compare)
+	slices.SortFunc(plan.Update, compare)
+	slices.Sort(plan.Remove)
+	return plan, nil
+}
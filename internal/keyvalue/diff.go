@@ -0,0 +1,124 @@
+package keyvalue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DiffRow is a key and its state in each environment the secret or config exists in. When values are compared, equal
+// values have the same label in every environment.
+type DiffRow struct {
+	Key          string            `json:"key"`
+	Environments map[string]string `json:"environments"`
+	Status       string            `json:"status"`
+}
+
+const (
+	diffStatusSame = "same"
+
+	// diffPresent is the cell of a key that is present when values are not compared.
+	diffPresent = "✓"
+	// diffMissing is the cell of a key that is missing in an environment the secret or config exists in.
+	diffMissing = "-"
+)
+
+// Diff compares the keys of a secret or config across environments, and their values when compareValues is true.
+// values maps each environment the secret or config exists in to its values, and key returns the key of a value. Values
+// are equal when they are ==. The rows are sorted by key.
+func Diff[T comparable](values map[string][]T, key func(T) string, compareValues bool) []DiffRow {
+	environments := make([]string, 0, len(values))
+	byKey := map[string]map[string]T{}
+	for env, vs := range values {
+		environments = append(environments, env)
+		for _, v := range vs {
+			if byKey[key(v)] == nil {
+				byKey[key(v)] = map[string]T{}
+			}
+			byKey[key(v)][env] = v
+		}
+	}
+
+	slices.Sort(environments)
+
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	rows := make([]DiffRow, 0, len(keys))
+	for _, k := range keys {
+		row := DiffRow{Key: k, Environments: map[string]string{}}
+
+		var missing []string
+		var distinct []T
+		for _, env := range environments {
+			v, ok := byKey[k][env]
+			if !ok {
+				row.Environments[env] = diffMissing
+				missing = append(missing, env)
+				continue
+			}
+			if !compareValues {
+				row.Environments[env] = diffPresent
+				continue
+			}
+
+			i := slices.Index(distinct, v)
+			if i < 0 {
+				distinct = append(distinct, v)
+				i = len(distinct) - 1
+			}
+			row.Environments[env] = valueLabel(i)
+		}
+
+		var status []string
+		if len(missing) > 0 {
+			status = append(status, "missing in "+strings.Join(missing, ", "))
+		}
+		if len(distinct) > 1 {
+			status = append(status, fmt.Sprintf("%d different values", len(distinct)))
+		}
+		if len(status) == 0 {
+			row.Status = diffStatusSame
+		} else {
+			row.Status = strings.Join(status, "; ")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// valueLabel labels the i-th distinct value of a key as A, B, ..., Z, AA, AB and so on.
+func valueLabel(i int) string {
+	label := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		label = string(rune('A'+(i-1)%26)) + label
+	}
+	return label
+}
+
+// FormatDiff formats diff rows as a matrix with a column per environment for table rendering. The cell is empty in
+// environments the secret or config does not exist in. When names is given, it holds the name of the secret or config
+// of each row, which is included in a column with the given heading.
+func FormatDiff(rows []DiffRow, environments []string, heading string, names []string) [][]string {
+	header := []string{"Key"}
+	if names != nil {
+		header = []string{heading, "Key"}
+	}
+	header = append(append(header, environments...), "Status")
+
+	data := [][]string{header}
+	for i, r := range rows {
+		row := []string{r.Key}
+		if names != nil {
+			row = []string{names[i], r.Key}
+		}
+		for _, env := range environments {
+			row = append(row, r.Environments[env])
+		}
+		data = append(data, append(row, r.Status))
+	}
+	return data
+}
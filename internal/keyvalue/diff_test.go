@@ -0,0 +1,15 @@
+package keyvalue
+
+import (
+	"testing"
+)
+
+func TestValueLabel(t *testing.T) {
+	t.Parallel()
+
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := valueLabel(i); got != want {
+			t.Errorf("valueLabel(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -8617,6 +8617,405 @@ func (v *GetApplicationActivityTeamApplicationsApplicationConnectionNodesApplica
 	return v.Name
 }
 
+// GetApplicationEndpointsResponse is returned by GetApplicationEndpoints on success.
+type GetApplicationEndpointsResponse struct {
+	// Get a team by its slug.
+	Team GetApplicationEndpointsTeam `json:"team"`
+}
+
+// GetTeam returns GetApplicationEndpointsResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsResponse) GetTeam() GetApplicationEndpointsTeam { return v.Team }
+
+// GetApplicationEndpointsTeam includes the requested fields of the GraphQL type Team.
+// The GraphQL type's documentation follows.
+//
+// The team type represents a team on the [Nais platform](https://nais.io/).
+//
+// Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
+//
+// External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
+type GetApplicationEndpointsTeam struct {
+	// Nais applications owned by the team.
+	Applications GetApplicationEndpointsTeamApplicationsApplicationConnection `json:"applications"`
+}
+
+// GetApplications returns GetApplicationEndpointsTeam.Applications, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeam) GetApplications() GetApplicationEndpointsTeamApplicationsApplicationConnection {
+	return v.Applications
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnection includes the requested fields of the GraphQL type ApplicationConnection.
+// The GraphQL type's documentation follows.
+//
+// Application connection.
+type GetApplicationEndpointsTeamApplicationsApplicationConnection struct {
+	// List of nodes.
+	Nodes []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication `json:"nodes"`
+}
+
+// GetNodes returns GetApplicationEndpointsTeamApplicationsApplicationConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnection) GetNodes() []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication {
+	return v.Nodes
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication includes the requested fields of the GraphQL type Application.
+// The GraphQL type's documentation follows.
+//
+// An application lets you run one or more instances of a container image on the [Nais platform](https://nais.io/).
+//
+// Learn more about how to create and configure your applications in the [Nais documentation](https://docs.nais.io/workloads/application/).
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication struct {
+	// The name of the application.
+	Name string `json:"name"`
+	// The team environment for the application.
+	TeamEnvironment GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment `json:"teamEnvironment"`
+	// List of ingresses for the application.
+	Ingresses []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress `json:"ingresses"`
+	// List of authentication and authorization for the application.
+	AuthIntegrations []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations `json:"-"`
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) GetName() string {
+	return v.Name
+}
+
+// GetTeamEnvironment returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.TeamEnvironment, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) GetTeamEnvironment() GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment {
+	return v.TeamEnvironment
+}
+
+// GetIngresses returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.Ingresses, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) GetIngresses() []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress {
+	return v.Ingresses
+}
+
+// GetAuthIntegrations returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.AuthIntegrations, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) GetAuthIntegrations() []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations {
+	return v.AuthIntegrations
+}
+
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication
+		AuthIntegrations []json.RawMessage `json:"authIntegrations"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.AuthIntegrations
+		src := firstPass.AuthIntegrations
+		*dst = make(
+			[]GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			if len(src) != 0 && string(src) != "null" {
+				err = __unmarshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations(
+					src, dst)
+				if err != nil {
+					return fmt.Errorf(
+						"unable to unmarshal GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.AuthIntegrations: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication struct {
+	Name string `json:"name"`
+
+	TeamEnvironment GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment `json:"teamEnvironment"`
+
+	Ingresses []GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress `json:"ingresses"`
+
+	AuthIntegrations []json.RawMessage `json:"authIntegrations"`
+}
+
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication) __premarshalJSON() (*__premarshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication, error) {
+	var retval __premarshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication
+
+	retval.Name = v.Name
+	retval.TeamEnvironment = v.TeamEnvironment
+	retval.Ingresses = v.Ingresses
+	{
+
+		dst := &retval.AuthIntegrations
+		src := v.AuthIntegrations
+		*dst = make(
+			[]json.RawMessage,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			var err error
+			*dst, err = __marshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations(
+				&src)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to marshal GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplication.AuthIntegrations: %w", err)
+			}
+		}
+	}
+	return &retval, nil
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations includes the requested fields of the GraphQL interface ApplicationAuthIntegrations.
+//
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations is implemented by the following types:
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration
+// The GraphQL type's documentation follows.
+//
+// Authentication integrations for the application.
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations interface {
+	implementsGraphQLInterfaceGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
+}
+
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration) implementsGraphQLInterfaceGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations() {
+}
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration) implementsGraphQLInterfaceGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations() {
+}
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration) implementsGraphQLInterfaceGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations() {
+}
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration) implementsGraphQLInterfaceGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations() {
+}
+
+func __unmarshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations(b []byte, v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "EntraIDAuthIntegration":
+		*v = new(GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration)
+		return json.Unmarshal(b, *v)
+	case "IDPortenAuthIntegration":
+		*v = new(GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration)
+		return json.Unmarshal(b, *v)
+	case "MaskinportenAuthIntegration":
+		*v = new(GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration)
+		return json.Unmarshal(b, *v)
+	case "TokenXAuthIntegration":
+		*v = new(GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing ApplicationAuthIntegrations.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalGetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations(v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration:
+		typename = "EntraIDAuthIntegration"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration:
+		typename = "IDPortenAuthIntegration"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration:
+		typename = "MaskinportenAuthIntegration"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration:
+		typename = "TokenXAuthIntegration"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrations: "%T"`, v)
+	}
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration includes the requested fields of the GraphQL type EntraIDAuthIntegration.
+// The GraphQL type's documentation follows.
+//
+// Entra ID (f.k.a. Azure AD) authentication.
+//
+// Read more: https://docs.nais.io/auth/entra-id/
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration struct {
+	Typename string `json:"__typename"`
+	// The name of the integration.
+	Name string `json:"name"`
+}
+
+// GetTypename returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration.Typename, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsEntraIDAuthIntegration) GetName() string {
+	return v.Name
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration includes the requested fields of the GraphQL type IDPortenAuthIntegration.
+// The GraphQL type's documentation follows.
+//
+// ID-porten authentication.
+//
+// Read more: https://docs.nais.io/auth/idporten/
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration struct {
+	Typename string `json:"__typename"`
+	// The name of the integration.
+	Name string `json:"name"`
+}
+
+// GetTypename returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration.Typename, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsIDPortenAuthIntegration) GetName() string {
+	return v.Name
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration includes the requested fields of the GraphQL type MaskinportenAuthIntegration.
+// The GraphQL type's documentation follows.
+//
+// Maskinporten authentication.
+//
+// Read more: https://docs.nais.io/auth/maskinporten/
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration struct {
+	Typename string `json:"__typename"`
+	// The name of the integration.
+	Name string `json:"name"`
+}
+
+// GetTypename returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration.Typename, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsMaskinportenAuthIntegration) GetName() string {
+	return v.Name
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration includes the requested fields of the GraphQL type TokenXAuthIntegration.
+// The GraphQL type's documentation follows.
+//
+// TokenX authentication.
+//
+// Read more: https://docs.nais.io/auth/tokenx/
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration struct {
+	Typename string `json:"__typename"`
+	// The name of the integration.
+	Name string `json:"name"`
+}
+
+// GetTypename returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration.Typename, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationAuthIntegrationsTokenXAuthIntegration) GetName() string {
+	return v.Name
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress includes the requested fields of the GraphQL type Ingress.
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress struct {
+	// URL for the ingress.
+	Url string `json:"url"`
+	// Type of ingress.
+	Type IngressType `json:"type"`
+}
+
+// GetUrl returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress.Url, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress) GetUrl() string {
+	return v.Url
+}
+
+// GetType returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress.Type, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationIngressesIngress) GetType() IngressType {
+	return v.Type
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment includes the requested fields of the GraphQL type TeamEnvironment.
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment struct {
+	// Get the environment.
+	Environment GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment `json:"environment"`
+}
+
+// GetEnvironment returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment.Environment, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironment) GetEnvironment() GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment {
+	return v.Environment
+}
+
+// GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment includes the requested fields of the GraphQL type Environment.
+// The GraphQL type's documentation follows.
+//
+// An environment represents a runtime environment for workloads.
+//
+// Learn more in the [official Nais documentation](https://docs.nais.io/workloads/explanations/environment/).
+type GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment struct {
+	// Unique name of the environment.
+	Name string `json:"name"`
+}
+
+// GetName returns GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment.Name, and is useful for accessing the field via an interface.
+func (v *GetApplicationEndpointsTeamApplicationsApplicationConnectionNodesApplicationTeamEnvironmentEnvironment) GetName() string {
+	return v.Name
+}
+
 // GetApplicationEnvVarsResponse is returned by GetApplicationEnvVars on success.
 type GetApplicationEnvVarsResponse struct {
 	// Get a team by its slug.
@@ -18866,14 +19265,92 @@ func (v *GetJobRunsTeamJobsJobConnectionNodesJobRunsJobRunConnectionNodesJobRunT
 	return v.Actor
 }
 
-// GetLatestJobRunStateResponse is returned by GetLatestJobRunState on success.
-type GetLatestJobRunStateResponse struct {
+// GetKafkaTopicAclResponse is returned by GetKafkaTopicAcl on success.
+type GetKafkaTopicAclResponse struct {
 	// Get a team by its slug.
-	Team GetLatestJobRunStateTeam `json:"team"`
+	Team GetKafkaTopicAclTeam `json:"team"`
 }
 
-// GetTeam returns GetLatestJobRunStateResponse.Team, and is useful for accessing the field via an interface.
-func (v *GetLatestJobRunStateResponse) GetTeam() GetLatestJobRunStateTeam { return v.Team }
+// GetTeam returns GetKafkaTopicAclResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclResponse) GetTeam() GetKafkaTopicAclTeam { return v.Team }
+
+// GetKafkaTopicAclTeam includes the requested fields of the GraphQL type Team.
+// The GraphQL type's documentation follows.
+//
+// The team type represents a team on the [Nais platform](https://nais.io/).
+//
+// Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
+//
+// External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
+type GetKafkaTopicAclTeam struct {
+	// Get a specific environment for the team.
+	Environment GetKafkaTopicAclTeamEnvironment `json:"environment"`
+}
+
+// GetEnvironment returns GetKafkaTopicAclTeam.Environment, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeam) GetEnvironment() GetKafkaTopicAclTeamEnvironment { return v.Environment }
+
+// GetKafkaTopicAclTeamEnvironment includes the requested fields of the GraphQL type TeamEnvironment.
+type GetKafkaTopicAclTeamEnvironment struct {
+	// Kafka topic in the team environment.
+	KafkaTopic GetKafkaTopicAclTeamEnvironmentKafkaTopic `json:"kafkaTopic"`
+}
+
+// GetKafkaTopic returns GetKafkaTopicAclTeamEnvironment.KafkaTopic, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironment) GetKafkaTopic() GetKafkaTopicAclTeamEnvironmentKafkaTopic {
+	return v.KafkaTopic
+}
+
+// GetKafkaTopicAclTeamEnvironmentKafkaTopic includes the requested fields of the GraphQL type KafkaTopic.
+type GetKafkaTopicAclTeamEnvironmentKafkaTopic struct {
+	Acl GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection `json:"acl"`
+}
+
+// GetAcl returns GetKafkaTopicAclTeamEnvironmentKafkaTopic.Acl, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironmentKafkaTopic) GetAcl() GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection {
+	return v.Acl
+}
+
+// GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection includes the requested fields of the GraphQL type KafkaTopicAclConnection.
+type GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection struct {
+	Nodes []GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl `json:"nodes"`
+}
+
+// GetNodes returns GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnection) GetNodes() []GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl {
+	return v.Nodes
+}
+
+// GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl includes the requested fields of the GraphQL type KafkaTopicAcl.
+type GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl struct {
+	TeamName     string `json:"teamName"`
+	WorkloadName string `json:"workloadName"`
+	Access       string `json:"access"`
+}
+
+// GetTeamName returns GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl.TeamName, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl) GetTeamName() string {
+	return v.TeamName
+}
+
+// GetWorkloadName returns GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl.WorkloadName, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl) GetWorkloadName() string {
+	return v.WorkloadName
+}
+
+// GetAccess returns GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl.Access, and is useful for accessing the field via an interface.
+func (v *GetKafkaTopicAclTeamEnvironmentKafkaTopicAclKafkaTopicAclConnectionNodesKafkaTopicAcl) GetAccess() string {
+	return v.Access
+}
+
+// GetLatestJobRunStateResponse is returned by GetLatestJobRunState on success.
+type GetLatestJobRunStateResponse struct {
+	// Get a team by its slug.
+	Team GetLatestJobRunStateTeam `json:"team"`
+}
+
+// GetTeam returns GetLatestJobRunStateResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetLatestJobRunStateResponse) GetTeam() GetLatestJobRunStateTeam { return v.Team }
 
 // GetLatestJobRunStateTeam includes the requested fields of the GraphQL type Team.
 // The GraphQL type's documentation follows.
@@ -18946,6 +19423,117 @@ func (v *GetLatestJobRunStateTeamJobsJobConnectionNodesJobRunsJobRunConnectionNo
 	return v.State
 }
 
+// GetOpenSearchMaintenanceResponse is returned by GetOpenSearchMaintenance on success.
+type GetOpenSearchMaintenanceResponse struct {
+	// Get a team by its slug.
+	Team GetOpenSearchMaintenanceTeam `json:"team"`
+}
+
+// GetTeam returns GetOpenSearchMaintenanceResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceResponse) GetTeam() GetOpenSearchMaintenanceTeam { return v.Team }
+
+// GetOpenSearchMaintenanceTeam includes the requested fields of the GraphQL type Team.
+// The GraphQL type's documentation follows.
+//
+// The team type represents a team on the [Nais platform](https://nais.io/).
+//
+// Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
+//
+// External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
+type GetOpenSearchMaintenanceTeam struct {
+	// Get a specific environment for the team.
+	Environment GetOpenSearchMaintenanceTeamEnvironment `json:"environment"`
+}
+
+// GetEnvironment returns GetOpenSearchMaintenanceTeam.Environment, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceTeam) GetEnvironment() GetOpenSearchMaintenanceTeamEnvironment {
+	return v.Environment
+}
+
+// GetOpenSearchMaintenanceTeamEnvironment includes the requested fields of the GraphQL type TeamEnvironment.
+type GetOpenSearchMaintenanceTeamEnvironment struct {
+	// OpenSearch instance in the team environment.
+	OpenSearch GetOpenSearchMaintenanceTeamEnvironmentOpenSearch `json:"openSearch"`
+}
+
+// GetOpenSearch returns GetOpenSearchMaintenanceTeamEnvironment.OpenSearch, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceTeamEnvironment) GetOpenSearch() GetOpenSearchMaintenanceTeamEnvironmentOpenSearch {
+	return v.OpenSearch
+}
+
+// GetOpenSearchMaintenanceTeamEnvironmentOpenSearch includes the requested fields of the GraphQL type OpenSearch.
+type GetOpenSearchMaintenanceTeamEnvironmentOpenSearch struct {
+	// Fetch maintenances updates for the OpenSearch instance.
+	Maintenance GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance `json:"maintenance"`
+}
+
+// GetMaintenance returns GetOpenSearchMaintenanceTeamEnvironmentOpenSearch.Maintenance, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearch) GetMaintenance() GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance {
+	return v.Maintenance
+}
+
+// GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance includes the requested fields of the GraphQL type OpenSearchMaintenance.
+type GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance struct {
+	OpenSearchMaintenanceFields `json:"-"`
+}
+
+// GetWindow returns GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance.Window, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance) GetWindow() OpenSearchMaintenanceFieldsWindowMaintenanceWindow {
+	return v.OpenSearchMaintenanceFields.Window
+}
+
+// GetUpdates returns GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance.Updates, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance) GetUpdates() OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection {
+	return v.OpenSearchMaintenanceFields.Updates
+}
+
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.OpenSearchMaintenanceFields)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance struct {
+	Window OpenSearchMaintenanceFieldsWindowMaintenanceWindow `json:"window"`
+
+	Updates OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection `json:"updates"`
+}
+
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance) __premarshalJSON() (*__premarshalGetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance, error) {
+	var retval __premarshalGetOpenSearchMaintenanceTeamEnvironmentOpenSearchMaintenance
+
+	retval.Window = v.OpenSearchMaintenanceFields.Window
+	retval.Updates = v.OpenSearchMaintenanceFields.Updates
+	return &retval, nil
+}
+
 // GetOpenSearchResponse is returned by GetOpenSearch on success.
 type GetOpenSearchResponse struct {
 	// Get a team by its slug.
@@ -18992,9 +19580,11 @@ type GetOpenSearchTeamEnvironmentOpenSearch struct {
 	// Available storage in GB.
 	StorageGB int `json:"storageGB"`
 	// Fetch version for the OpenSearch instance.
-	Version GetOpenSearchTeamEnvironmentOpenSearchVersion                          `json:"version"`
-	State   OpenSearchState                                                        `json:"state"`
-	Access  GetOpenSearchTeamEnvironmentOpenSearchAccessOpenSearchAccessConnection `json:"access"`
+	Version GetOpenSearchTeamEnvironmentOpenSearchVersion `json:"version"`
+	State   OpenSearchState                               `json:"state"`
+	// Fetch maintenances updates for the OpenSearch instance.
+	Maintenance GetOpenSearchTeamEnvironmentOpenSearchMaintenance                      `json:"maintenance"`
+	Access      GetOpenSearchTeamEnvironmentOpenSearchAccessOpenSearchAccessConnection `json:"access"`
 }
 
 // GetName returns GetOpenSearchTeamEnvironmentOpenSearch.Name, and is useful for accessing the field via an interface.
@@ -19017,6 +19607,11 @@ func (v *GetOpenSearchTeamEnvironmentOpenSearch) GetVersion() GetOpenSearchTeamE
 // GetState returns GetOpenSearchTeamEnvironmentOpenSearch.State, and is useful for accessing the field via an interface.
 func (v *GetOpenSearchTeamEnvironmentOpenSearch) GetState() OpenSearchState { return v.State }
 
+// GetMaintenance returns GetOpenSearchTeamEnvironmentOpenSearch.Maintenance, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchTeamEnvironmentOpenSearch) GetMaintenance() GetOpenSearchTeamEnvironmentOpenSearchMaintenance {
+	return v.Maintenance
+}
+
 // GetAccess returns GetOpenSearchTeamEnvironmentOpenSearch.Access, and is useful for accessing the field via an interface.
 func (v *GetOpenSearchTeamEnvironmentOpenSearch) GetAccess() GetOpenSearchTeamEnvironmentOpenSearchAccessOpenSearchAccessConnection {
 	return v.Access
@@ -19300,6 +19895,68 @@ func (v *GetOpenSearchTeamEnvironmentOpenSearchAccessOpenSearchAccessConnectionE
 	return v.Slug
 }
 
+// GetOpenSearchTeamEnvironmentOpenSearchMaintenance includes the requested fields of the GraphQL type OpenSearchMaintenance.
+type GetOpenSearchTeamEnvironmentOpenSearchMaintenance struct {
+	OpenSearchMaintenanceFields `json:"-"`
+}
+
+// GetWindow returns GetOpenSearchTeamEnvironmentOpenSearchMaintenance.Window, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchTeamEnvironmentOpenSearchMaintenance) GetWindow() OpenSearchMaintenanceFieldsWindowMaintenanceWindow {
+	return v.OpenSearchMaintenanceFields.Window
+}
+
+// GetUpdates returns GetOpenSearchTeamEnvironmentOpenSearchMaintenance.Updates, and is useful for accessing the field via an interface.
+func (v *GetOpenSearchTeamEnvironmentOpenSearchMaintenance) GetUpdates() OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection {
+	return v.OpenSearchMaintenanceFields.Updates
+}
+
+func (v *GetOpenSearchTeamEnvironmentOpenSearchMaintenance) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetOpenSearchTeamEnvironmentOpenSearchMaintenance
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetOpenSearchTeamEnvironmentOpenSearchMaintenance = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.OpenSearchMaintenanceFields)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetOpenSearchTeamEnvironmentOpenSearchMaintenance struct {
+	Window OpenSearchMaintenanceFieldsWindowMaintenanceWindow `json:"window"`
+
+	Updates OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection `json:"updates"`
+}
+
+func (v *GetOpenSearchTeamEnvironmentOpenSearchMaintenance) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetOpenSearchTeamEnvironmentOpenSearchMaintenance) __premarshalJSON() (*__premarshalGetOpenSearchTeamEnvironmentOpenSearchMaintenance, error) {
+	var retval __premarshalGetOpenSearchTeamEnvironmentOpenSearchMaintenance
+
+	retval.Window = v.OpenSearchMaintenanceFields.Window
+	retval.Updates = v.OpenSearchMaintenanceFields.Updates
+	return &retval, nil
+}
+
 // GetOpenSearchTeamEnvironmentOpenSearchVersion includes the requested fields of the GraphQL type OpenSearchVersion.
 type GetOpenSearchTeamEnvironmentOpenSearchVersion struct {
 	// The full version string of the OpenSearch instance. This will be available after the instance is created.
@@ -28597,16 +29254,16 @@ func (v *GetTeamKafkaTopicsTeamKafkaTopicsKafkaTopicConnectionNodesKafkaTopicTea
 	return v.Name
 }
 
-// GetTeamPostgresInstancesResponse is returned by GetTeamPostgresInstances on success.
-type GetTeamPostgresInstancesResponse struct {
+// GetTeamNetworkPoliciesResponse is returned by GetTeamNetworkPolicies on success.
+type GetTeamNetworkPoliciesResponse struct {
 	// Get a team by its slug.
-	Team GetTeamPostgresInstancesTeam `json:"team"`
+	Team GetTeamNetworkPoliciesTeam `json:"team"`
 }
 
-// GetTeam returns GetTeamPostgresInstancesResponse.Team, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesResponse) GetTeam() GetTeamPostgresInstancesTeam { return v.Team }
+// GetTeam returns GetTeamNetworkPoliciesResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesResponse) GetTeam() GetTeamNetworkPoliciesTeam { return v.Team }
 
-// GetTeamPostgresInstancesTeam includes the requested fields of the GraphQL type Team.
+// GetTeamNetworkPoliciesTeam includes the requested fields of the GraphQL type Team.
 // The GraphQL type's documentation follows.
 //
 // The team type represents a team on the [Nais platform](https://nais.io/).
@@ -28614,86 +29271,626 @@ func (v *GetTeamPostgresInstancesResponse) GetTeam() GetTeamPostgresInstancesTea
 // Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
 //
 // External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
-type GetTeamPostgresInstancesTeam struct {
-	// Postgres instances owned by the team.
-	PostgresInstances GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection `json:"postgresInstances"`
-	// SQL instances owned by the team.
-	SqlInstances GetTeamPostgresInstancesTeamSqlInstancesSqlInstanceConnection `json:"sqlInstances"`
-}
-
-// GetPostgresInstances returns GetTeamPostgresInstancesTeam.PostgresInstances, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeam) GetPostgresInstances() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection {
-	return v.PostgresInstances
+type GetTeamNetworkPoliciesTeam struct {
+	// Nais workloads owned by the team.
+	Workloads GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection `json:"workloads"`
 }
 
-// GetSqlInstances returns GetTeamPostgresInstancesTeam.SqlInstances, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeam) GetSqlInstances() GetTeamPostgresInstancesTeamSqlInstancesSqlInstanceConnection {
-	return v.SqlInstances
+// GetWorkloads returns GetTeamNetworkPoliciesTeam.Workloads, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeam) GetWorkloads() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection {
+	return v.Workloads
 }
 
-// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection includes the requested fields of the GraphQL type PostgresInstanceConnection.
-type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection struct {
-	Nodes []GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance `json:"nodes"`
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection includes the requested fields of the GraphQL type WorkloadConnection.
+// The GraphQL type's documentation follows.
+//
+// Workload connection.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection struct {
+	// List of nodes.
+	Nodes []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload `json:"-"`
 }
 
-// GetNodes returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection.Nodes, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection) GetNodes() []GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance {
+// GetNodes returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection) GetNodes() []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload {
 	return v.Nodes
 }
 
-// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance includes the requested fields of the GraphQL type PostgresInstance.
-type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance struct {
-	Name            string                                                                                                      `json:"name"`
-	TeamEnvironment GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceTeamEnvironment `json:"teamEnvironment"`
-	// Major version of PostgreSQL.
-	MajorVersion string `json:"majorVersion"`
-	// Indicates whether the Postgres cluster is configured for high availability.
-	HighAvailability bool `json:"highAvailability"`
-	// Audit logging configuration for the Postgres cluster.
-	Audit GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit `json:"audit"`
-	// Current state of the Postgres cluster.
-	State PostgresInstanceState `json:"state"`
-}
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection) UnmarshalJSON(b []byte) error {
 
-// GetName returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.Name, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetName() string {
-	return v.Name
-}
+	if string(b) == "null" {
+		return nil
+	}
 
-// GetTeamEnvironment returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.TeamEnvironment, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetTeamEnvironment() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceTeamEnvironment {
-	return v.TeamEnvironment
-}
+	var firstPass struct {
+		*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection
+		Nodes []json.RawMessage `json:"nodes"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection = v
 
-// GetMajorVersion returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.MajorVersion, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetMajorVersion() string {
-	return v.MajorVersion
-}
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
 
-// GetHighAvailability returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.HighAvailability, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetHighAvailability() bool {
-	return v.HighAvailability
+	{
+		dst := &v.Nodes
+		src := firstPass.Nodes
+		*dst = make(
+			[]GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			if len(src) != 0 && string(src) != "null" {
+				err = __unmarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload(
+					src, dst)
+				if err != nil {
+					return fmt.Errorf(
+						"unable to unmarshal GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection.Nodes: %w", err)
+				}
+			}
+		}
+	}
+	return nil
 }
 
-// GetAudit returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.Audit, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetAudit() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit {
-	return v.Audit
+type __premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection struct {
+	Nodes []json.RawMessage `json:"nodes"`
 }
 
-// GetState returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.State, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetState() PostgresInstanceState {
-	return v.State
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit includes the requested fields of the GraphQL type PostgresInstanceAudit.
-type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit struct {
-	// Indicates whether audit logging is enabled for the Postgres cluster.
-	Enabled bool `json:"enabled"`
-}
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection) __premarshalJSON() (*__premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection, error) {
+	var retval __premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection
 
-// GetEnabled returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit.Enabled, and is useful for accessing the field via an interface.
-func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit) GetEnabled() bool {
-	return v.Enabled
+	{
+
+		dst := &retval.Nodes
+		src := v.Nodes
+		*dst = make(
+			[]json.RawMessage,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			var err error
+			*dst, err = __marshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload(
+				&src)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to marshal GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnection.Nodes: %w", err)
+			}
+		}
+	}
+	return &retval, nil
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication includes the requested fields of the GraphQL type Application.
+// The GraphQL type's documentation follows.
+//
+// An application lets you run one or more instances of a container image on the [Nais platform](https://nais.io/).
+//
+// Learn more about how to create and configure your applications in the [Nais documentation](https://docs.nais.io/workloads/application/).
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication struct {
+	Typename string `json:"__typename"`
+	// Interface for workloads.
+	Name string `json:"name"`
+	// Interface for workloads.
+	NetworkPolicy GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy `json:"networkPolicy"`
+}
+
+// GetTypename returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication.Typename, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication.Name, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication) GetName() string {
+	return v.Name
+}
+
+// GetNetworkPolicy returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication.NetworkPolicy, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication) GetNetworkPolicy() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy {
+	return v.NetworkPolicy
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob includes the requested fields of the GraphQL type Job.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob struct {
+	Typename string `json:"__typename"`
+	// Interface for workloads.
+	Name string `json:"name"`
+	// Interface for workloads.
+	NetworkPolicy GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy `json:"networkPolicy"`
+}
+
+// GetTypename returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob.Typename, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob) GetTypename() string {
+	return v.Typename
+}
+
+// GetName returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob.Name, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob) GetName() string {
+	return v.Name
+}
+
+// GetNetworkPolicy returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob.NetworkPolicy, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob) GetNetworkPolicy() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy {
+	return v.NetworkPolicy
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload includes the requested fields of the GraphQL interface Workload.
+//
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload is implemented by the following types:
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob
+// The GraphQL type's documentation follows.
+//
+// Interface for workloads.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload interface {
+	implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
+	// GetName returns the interface-field "name" from its implementation.
+	// The GraphQL interface field's documentation follows.
+	//
+	// Interface for workloads.
+	GetName() string
+	// GetNetworkPolicy returns the interface-field "networkPolicy" from its implementation.
+	// The GraphQL interface field's documentation follows.
+	//
+	// Interface for workloads.
+	GetNetworkPolicy() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy
+}
+
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication) implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload() {
+}
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob) implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload() {
+}
+
+func __unmarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload(b []byte, v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "Application":
+		*v = new(GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication)
+		return json.Unmarshal(b, *v)
+	case "Job":
+		*v = new(GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing Workload.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload(v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication:
+		typename = "Application"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesApplication
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob:
+		typename = "Job"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesJob
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkload: "%T"`, v)
+	}
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy includes the requested fields of the GraphQL type NetworkPolicy.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy struct {
+	Inbound  GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy   `json:"inbound"`
+	Outbound GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy `json:"outbound"`
+}
+
+// GetInbound returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy.Inbound, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy) GetInbound() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy {
+	return v.Inbound
+}
+
+// GetOutbound returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy.Outbound, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicy) GetOutbound() GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy {
+	return v.Outbound
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy includes the requested fields of the GraphQL type InboundNetworkPolicy.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy struct {
+	Rules []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule `json:"rules"`
+}
+
+// GetRules returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy.Rules, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicy) GetRules() []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule {
+	return v.Rules
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule includes the requested fields of the GraphQL type NetworkPolicyRule.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule struct {
+	TargetWorkloadName string `json:"targetWorkloadName"`
+	TargetTeamSlug     string `json:"targetTeamSlug"`
+	Mutual             bool   `json:"mutual"`
+}
+
+// GetTargetWorkloadName returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule.TargetWorkloadName, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule) GetTargetWorkloadName() string {
+	return v.TargetWorkloadName
+}
+
+// GetTargetTeamSlug returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule.TargetTeamSlug, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule) GetTargetTeamSlug() string {
+	return v.TargetTeamSlug
+}
+
+// GetMutual returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule.Mutual, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyInboundInboundNetworkPolicyRulesNetworkPolicyRule) GetMutual() bool {
+	return v.Mutual
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy includes the requested fields of the GraphQL type OutboundNetworkPolicy.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy struct {
+	Rules    []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule              `json:"rules"`
+	External []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget `json:"-"`
+}
+
+// GetRules returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy.Rules, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy) GetRules() []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule {
+	return v.Rules
+}
+
+// GetExternal returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy.External, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy) GetExternal() []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget {
+	return v.External
+}
+
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy
+		External []json.RawMessage `json:"external"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.External
+		src := firstPass.External
+		*dst = make(
+			[]GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			if len(src) != 0 && string(src) != "null" {
+				err = __unmarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget(
+					src, dst)
+				if err != nil {
+					return fmt.Errorf(
+						"unable to unmarshal GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy.External: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy struct {
+	Rules []GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule `json:"rules"`
+
+	External []json.RawMessage `json:"external"`
+}
+
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy) __premarshalJSON() (*__premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy, error) {
+	var retval __premarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy
+
+	retval.Rules = v.Rules
+	{
+
+		dst := &retval.External
+		src := v.External
+		*dst = make(
+			[]json.RawMessage,
+			len(src))
+		for i, src := range src {
+			dst := &(*dst)[i]
+			var err error
+			*dst, err = __marshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget(
+				&src)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to marshal GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicy.External: %w", err)
+			}
+		}
+	}
+	return &retval, nil
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost includes the requested fields of the GraphQL type ExternalNetworkPolicyHost.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost struct {
+	Typename string `json:"__typename"`
+	Target   string `json:"target"`
+	Ports    []int  `json:"ports"`
+}
+
+// GetTypename returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost.Typename, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost) GetTypename() string {
+	return v.Typename
+}
+
+// GetTarget returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost.Target, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost) GetTarget() string {
+	return v.Target
+}
+
+// GetPorts returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost.Ports, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost) GetPorts() []int {
+	return v.Ports
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4 includes the requested fields of the GraphQL type ExternalNetworkPolicyIpv4.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4 struct {
+	Typename string `json:"__typename"`
+	Target   string `json:"target"`
+	Ports    []int  `json:"ports"`
+}
+
+// GetTypename returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4.Typename, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4) GetTypename() string {
+	return v.Typename
+}
+
+// GetTarget returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4.Target, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4) GetTarget() string {
+	return v.Target
+}
+
+// GetPorts returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4.Ports, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4) GetPorts() []int {
+	return v.Ports
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget includes the requested fields of the GraphQL interface ExternalNetworkPolicyTarget.
+//
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget is implemented by the following types:
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget interface {
+	implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
+	// GetTarget returns the interface-field "target" from its implementation.
+	GetTarget() string
+	// GetPorts returns the interface-field "ports" from its implementation.
+	GetPorts() []int
+}
+
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost) implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget() {
+}
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4) implementsGraphQLInterfaceGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget() {
+}
+
+func __unmarshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget(b []byte, v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "ExternalNetworkPolicyHost":
+		*v = new(GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost)
+		return json.Unmarshal(b, *v)
+	case "ExternalNetworkPolicyIpv4":
+		*v = new(GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing ExternalNetworkPolicyTarget.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalGetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget(v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost:
+		typename = "ExternalNetworkPolicyHost"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyHost
+		}{typename, v}
+		return json.Marshal(result)
+	case *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4:
+		typename = "ExternalNetworkPolicyIpv4"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyIpv4
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyExternalExternalNetworkPolicyTarget: "%T"`, v)
+	}
+}
+
+// GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule includes the requested fields of the GraphQL type NetworkPolicyRule.
+type GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule struct {
+	TargetWorkloadName string `json:"targetWorkloadName"`
+	TargetTeamSlug     string `json:"targetTeamSlug"`
+	Mutual             bool   `json:"mutual"`
+}
+
+// GetTargetWorkloadName returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule.TargetWorkloadName, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule) GetTargetWorkloadName() string {
+	return v.TargetWorkloadName
+}
+
+// GetTargetTeamSlug returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule.TargetTeamSlug, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule) GetTargetTeamSlug() string {
+	return v.TargetTeamSlug
+}
+
+// GetMutual returns GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule.Mutual, and is useful for accessing the field via an interface.
+func (v *GetTeamNetworkPoliciesTeamWorkloadsWorkloadConnectionNodesWorkloadNetworkPolicyOutboundOutboundNetworkPolicyRulesNetworkPolicyRule) GetMutual() bool {
+	return v.Mutual
+}
+
+// GetTeamPostgresInstancesResponse is returned by GetTeamPostgresInstances on success.
+type GetTeamPostgresInstancesResponse struct {
+	// Get a team by its slug.
+	Team GetTeamPostgresInstancesTeam `json:"team"`
+}
+
+// GetTeam returns GetTeamPostgresInstancesResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesResponse) GetTeam() GetTeamPostgresInstancesTeam { return v.Team }
+
+// GetTeamPostgresInstancesTeam includes the requested fields of the GraphQL type Team.
+// The GraphQL type's documentation follows.
+//
+// The team type represents a team on the [Nais platform](https://nais.io/).
+//
+// Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
+//
+// External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
+type GetTeamPostgresInstancesTeam struct {
+	// Postgres instances owned by the team.
+	PostgresInstances GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection `json:"postgresInstances"`
+	// SQL instances owned by the team.
+	SqlInstances GetTeamPostgresInstancesTeamSqlInstancesSqlInstanceConnection `json:"sqlInstances"`
+}
+
+// GetPostgresInstances returns GetTeamPostgresInstancesTeam.PostgresInstances, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeam) GetPostgresInstances() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection {
+	return v.PostgresInstances
+}
+
+// GetSqlInstances returns GetTeamPostgresInstancesTeam.SqlInstances, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeam) GetSqlInstances() GetTeamPostgresInstancesTeamSqlInstancesSqlInstanceConnection {
+	return v.SqlInstances
+}
+
+// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection includes the requested fields of the GraphQL type PostgresInstanceConnection.
+type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection struct {
+	Nodes []GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance `json:"nodes"`
+}
+
+// GetNodes returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnection) GetNodes() []GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance {
+	return v.Nodes
+}
+
+// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance includes the requested fields of the GraphQL type PostgresInstance.
+type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance struct {
+	Name            string                                                                                                      `json:"name"`
+	TeamEnvironment GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceTeamEnvironment `json:"teamEnvironment"`
+	// Major version of PostgreSQL.
+	MajorVersion string `json:"majorVersion"`
+	// Indicates whether the Postgres cluster is configured for high availability.
+	HighAvailability bool `json:"highAvailability"`
+	// Audit logging configuration for the Postgres cluster.
+	Audit GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit `json:"audit"`
+	// Current state of the Postgres cluster.
+	State PostgresInstanceState `json:"state"`
+}
+
+// GetName returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.Name, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetName() string {
+	return v.Name
+}
+
+// GetTeamEnvironment returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.TeamEnvironment, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetTeamEnvironment() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceTeamEnvironment {
+	return v.TeamEnvironment
+}
+
+// GetMajorVersion returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.MajorVersion, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetMajorVersion() string {
+	return v.MajorVersion
+}
+
+// GetHighAvailability returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.HighAvailability, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetHighAvailability() bool {
+	return v.HighAvailability
+}
+
+// GetAudit returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.Audit, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetAudit() GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit {
+	return v.Audit
+}
+
+// GetState returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance.State, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstance) GetState() PostgresInstanceState {
+	return v.State
+}
+
+// GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit includes the requested fields of the GraphQL type PostgresInstanceAudit.
+type GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit struct {
+	// Indicates whether audit logging is enabled for the Postgres cluster.
+	Enabled bool `json:"enabled"`
+}
+
+// GetEnabled returns GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit.Enabled, and is useful for accessing the field via an interface.
+func (v *GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceAudit) GetEnabled() bool {
+	return v.Enabled
 }
 
 // GetTeamPostgresInstancesTeamPostgresInstancesPostgresInstanceConnectionNodesPostgresInstanceTeamEnvironment includes the requested fields of the GraphQL type TeamEnvironment.
@@ -28884,22 +30081,133 @@ func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetUnassigned() in
 	return v.Unassigned
 }
 
-// GetSbomCount returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.SbomCount, and is useful for accessing the field via an interface.
-func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetSbomCount() int { return v.SbomCount }
+// GetSbomCount returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.SbomCount, and is useful for accessing the field via an interface.
+func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetSbomCount() int { return v.SbomCount }
+
+// GetCoverage returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.Coverage, and is useful for accessing the field via an interface.
+func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetCoverage() float64 {
+	return v.Coverage
+}
+
+// GetRiskScoreTrend returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.RiskScoreTrend, and is useful for accessing the field via an interface.
+func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetRiskScoreTrend() TeamVulnerabilityRiskScoreTrend {
+	return v.RiskScoreTrend
+}
+
+// GetLastUpdated returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.LastUpdated, and is useful for accessing the field via an interface.
+func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetLastUpdated() time.Time {
+	return v.LastUpdated
+}
+
+// GetValkeyMaintenanceResponse is returned by GetValkeyMaintenance on success.
+type GetValkeyMaintenanceResponse struct {
+	// Get a team by its slug.
+	Team GetValkeyMaintenanceTeam `json:"team"`
+}
+
+// GetTeam returns GetValkeyMaintenanceResponse.Team, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceResponse) GetTeam() GetValkeyMaintenanceTeam { return v.Team }
+
+// GetValkeyMaintenanceTeam includes the requested fields of the GraphQL type Team.
+// The GraphQL type's documentation follows.
+//
+// The team type represents a team on the [Nais platform](https://nais.io/).
+//
+// Learn more about what Nais teams are and what they can be used for in the [official Nais documentation](https://docs.nais.io/explanations/team/).
+//
+// External resources (e.g. entraIDGroupID, gitHubTeamSlug) are managed by [Nais API reconcilers](https://github.com/nais/api-reconcilers).
+type GetValkeyMaintenanceTeam struct {
+	// Get a specific environment for the team.
+	Environment GetValkeyMaintenanceTeamEnvironment `json:"environment"`
+}
+
+// GetEnvironment returns GetValkeyMaintenanceTeam.Environment, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceTeam) GetEnvironment() GetValkeyMaintenanceTeamEnvironment {
+	return v.Environment
+}
+
+// GetValkeyMaintenanceTeamEnvironment includes the requested fields of the GraphQL type TeamEnvironment.
+type GetValkeyMaintenanceTeamEnvironment struct {
+	// Valkey instance in the team environment.
+	Valkey GetValkeyMaintenanceTeamEnvironmentValkey `json:"valkey"`
+}
+
+// GetValkey returns GetValkeyMaintenanceTeamEnvironment.Valkey, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceTeamEnvironment) GetValkey() GetValkeyMaintenanceTeamEnvironmentValkey {
+	return v.Valkey
+}
+
+// GetValkeyMaintenanceTeamEnvironmentValkey includes the requested fields of the GraphQL type Valkey.
+type GetValkeyMaintenanceTeamEnvironmentValkey struct {
+	// Fetch maintenances updates for the Valkey instance.
+	Maintenance GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance `json:"maintenance"`
+}
+
+// GetMaintenance returns GetValkeyMaintenanceTeamEnvironmentValkey.Maintenance, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceTeamEnvironmentValkey) GetMaintenance() GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance {
+	return v.Maintenance
+}
+
+// GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance includes the requested fields of the GraphQL type ValkeyMaintenance.
+type GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance struct {
+	ValkeyMaintenanceFields `json:"-"`
+}
+
+// GetWindow returns GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance.Window, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance) GetWindow() ValkeyMaintenanceFieldsWindowMaintenanceWindow {
+	return v.ValkeyMaintenanceFields.Window
+}
+
+// GetUpdates returns GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance.Updates, and is useful for accessing the field via an interface.
+func (v *GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance) GetUpdates() ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection {
+	return v.ValkeyMaintenanceFields.Updates
+}
+
+func (v *GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.ValkeyMaintenanceFields)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetValkeyMaintenanceTeamEnvironmentValkeyMaintenance struct {
+	Window ValkeyMaintenanceFieldsWindowMaintenanceWindow `json:"window"`
 
-// GetCoverage returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.Coverage, and is useful for accessing the field via an interface.
-func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetCoverage() float64 {
-	return v.Coverage
+	Updates ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection `json:"updates"`
 }
 
-// GetRiskScoreTrend returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.RiskScoreTrend, and is useful for accessing the field via an interface.
-func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetRiskScoreTrend() TeamVulnerabilityRiskScoreTrend {
-	return v.RiskScoreTrend
+func (v *GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
 }
 
-// GetLastUpdated returns GetTeamVulnerabilitySummaryTeamVulnerabilitySummary.LastUpdated, and is useful for accessing the field via an interface.
-func (v *GetTeamVulnerabilitySummaryTeamVulnerabilitySummary) GetLastUpdated() time.Time {
-	return v.LastUpdated
+func (v *GetValkeyMaintenanceTeamEnvironmentValkeyMaintenance) __premarshalJSON() (*__premarshalGetValkeyMaintenanceTeamEnvironmentValkeyMaintenance, error) {
+	var retval __premarshalGetValkeyMaintenanceTeamEnvironmentValkeyMaintenance
+
+	retval.Window = v.ValkeyMaintenanceFields.Window
+	retval.Updates = v.ValkeyMaintenanceFields.Updates
+	return &retval, nil
 }
 
 // GetValkeyResponse is returned by GetValkey on success.
@@ -28944,9 +30252,11 @@ type GetValkeyTeamEnvironmentValkey struct {
 	// Availability tier for the Valkey instance.
 	Tier ValkeyTier `json:"tier"`
 	// Maximum memory policy for the Valkey instance.
-	MaxMemoryPolicy ValkeyMaxMemoryPolicy                                      `json:"maxMemoryPolicy"`
-	State           ValkeyState                                                `json:"state"`
-	Access          GetValkeyTeamEnvironmentValkeyAccessValkeyAccessConnection `json:"access"`
+	MaxMemoryPolicy ValkeyMaxMemoryPolicy `json:"maxMemoryPolicy"`
+	State           ValkeyState           `json:"state"`
+	// Fetch maintenances updates for the Valkey instance.
+	Maintenance GetValkeyTeamEnvironmentValkeyMaintenance                  `json:"maintenance"`
+	Access      GetValkeyTeamEnvironmentValkeyAccessValkeyAccessConnection `json:"access"`
 }
 
 // GetName returns GetValkeyTeamEnvironmentValkey.Name, and is useful for accessing the field via an interface.
@@ -28966,6 +30276,11 @@ func (v *GetValkeyTeamEnvironmentValkey) GetMaxMemoryPolicy() ValkeyMaxMemoryPol
 // GetState returns GetValkeyTeamEnvironmentValkey.State, and is useful for accessing the field via an interface.
 func (v *GetValkeyTeamEnvironmentValkey) GetState() ValkeyState { return v.State }
 
+// GetMaintenance returns GetValkeyTeamEnvironmentValkey.Maintenance, and is useful for accessing the field via an interface.
+func (v *GetValkeyTeamEnvironmentValkey) GetMaintenance() GetValkeyTeamEnvironmentValkeyMaintenance {
+	return v.Maintenance
+}
+
 // GetAccess returns GetValkeyTeamEnvironmentValkey.Access, and is useful for accessing the field via an interface.
 func (v *GetValkeyTeamEnvironmentValkey) GetAccess() GetValkeyTeamEnvironmentValkeyAccessValkeyAccessConnection {
 	return v.Access
@@ -29249,6 +30564,68 @@ func (v *GetValkeyTeamEnvironmentValkeyAccessValkeyAccessConnectionEdgesValkeyAc
 	return v.Slug
 }
 
+// GetValkeyTeamEnvironmentValkeyMaintenance includes the requested fields of the GraphQL type ValkeyMaintenance.
+type GetValkeyTeamEnvironmentValkeyMaintenance struct {
+	ValkeyMaintenanceFields `json:"-"`
+}
+
+// GetWindow returns GetValkeyTeamEnvironmentValkeyMaintenance.Window, and is useful for accessing the field via an interface.
+func (v *GetValkeyTeamEnvironmentValkeyMaintenance) GetWindow() ValkeyMaintenanceFieldsWindowMaintenanceWindow {
+	return v.ValkeyMaintenanceFields.Window
+}
+
+// GetUpdates returns GetValkeyTeamEnvironmentValkeyMaintenance.Updates, and is useful for accessing the field via an interface.
+func (v *GetValkeyTeamEnvironmentValkeyMaintenance) GetUpdates() ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection {
+	return v.ValkeyMaintenanceFields.Updates
+}
+
+func (v *GetValkeyTeamEnvironmentValkeyMaintenance) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetValkeyTeamEnvironmentValkeyMaintenance
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetValkeyTeamEnvironmentValkeyMaintenance = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.ValkeyMaintenanceFields)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetValkeyTeamEnvironmentValkeyMaintenance struct {
+	Window ValkeyMaintenanceFieldsWindowMaintenanceWindow `json:"window"`
+
+	Updates ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection `json:"updates"`
+}
+
+func (v *GetValkeyTeamEnvironmentValkeyMaintenance) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetValkeyTeamEnvironmentValkeyMaintenance) __premarshalJSON() (*__premarshalGetValkeyTeamEnvironmentValkeyMaintenance, error) {
+	var retval __premarshalGetValkeyTeamEnvironmentValkeyMaintenance
+
+	retval.Window = v.ValkeyMaintenanceFields.Window
+	retval.Updates = v.ValkeyMaintenanceFields.Updates
+	return &retval, nil
+}
+
 // GrantPostgresAccessGrantPostgresAccessGrantPostgresAccessPayload includes the requested fields of the GraphQL type GrantPostgresAccessPayload.
 type GrantPostgresAccessGrantPostgresAccessGrantPostgresAccessPayload struct {
 	Error string `json:"error"`
@@ -29331,6 +30708,22 @@ var AllImageVulnerabilitySuppressionState = []ImageVulnerabilitySuppressionState
 	ImageVulnerabilitySuppressionStateNotAffected,
 }
 
+type IngressType string
+
+const (
+	IngressTypeUnknown       IngressType = "UNKNOWN"
+	IngressTypeExternal      IngressType = "EXTERNAL"
+	IngressTypeInternal      IngressType = "INTERNAL"
+	IngressTypeAuthenticated IngressType = "AUTHENTICATED"
+)
+
+var AllIngressType = []IngressType{
+	IngressTypeUnknown,
+	IngressTypeExternal,
+	IngressTypeInternal,
+	IngressTypeAuthenticated,
+}
+
 // The kind of source for an environment variable or mounted file.
 type InstanceGroupValueSourceKind string
 
@@ -30478,6 +31871,84 @@ func (v *OpenSearchFilter) GetTiers() []OpenSearchTier { return v.Tiers }
 // GetLabels returns OpenSearchFilter.Labels, and is useful for accessing the field via an interface.
 func (v *OpenSearchFilter) GetLabels() []LabelFilter { return v.Labels }
 
+// OpenSearchMaintenanceFields includes the GraphQL fields of OpenSearchMaintenance requested by the fragment OpenSearchMaintenanceFields.
+type OpenSearchMaintenanceFields struct {
+	// The day and time of the week when the maintenance will be scheduled.
+	Window  OpenSearchMaintenanceFieldsWindowMaintenanceWindow                      `json:"window"`
+	Updates OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection `json:"updates"`
+}
+
+// GetWindow returns OpenSearchMaintenanceFields.Window, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFields) GetWindow() OpenSearchMaintenanceFieldsWindowMaintenanceWindow {
+	return v.Window
+}
+
+// GetUpdates returns OpenSearchMaintenanceFields.Updates, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFields) GetUpdates() OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection {
+	return v.Updates
+}
+
+// OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection includes the requested fields of the GraphQL type OpenSearchMaintenanceUpdateConnection.
+type OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection struct {
+	// List of nodes.
+	Nodes []OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate `json:"nodes"`
+}
+
+// GetNodes returns OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnection) GetNodes() []OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate {
+	return v.Nodes
+}
+
+// OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate includes the requested fields of the GraphQL type OpenSearchMaintenanceUpdate.
+type OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate struct {
+	// Title of the maintenance.
+	Title string `json:"title"`
+	// Description of the maintenance.
+	Description string `json:"description"`
+	// Deadline for installing the maintenance. If set, maintenance is mandatory and will be forcibly applied.
+	Deadline time.Time `json:"deadline"`
+	// The time when the update will be automatically applied. If set, maintenance is mandatory and will be forcibly applied.
+	StartAt time.Time `json:"startAt"`
+}
+
+// GetTitle returns OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate.Title, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate) GetTitle() string {
+	return v.Title
+}
+
+// GetDescription returns OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate.Description, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate) GetDescription() string {
+	return v.Description
+}
+
+// GetDeadline returns OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate.Deadline, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate) GetDeadline() time.Time {
+	return v.Deadline
+}
+
+// GetStartAt returns OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate.StartAt, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsUpdatesOpenSearchMaintenanceUpdateConnectionNodesOpenSearchMaintenanceUpdate) GetStartAt() time.Time {
+	return v.StartAt
+}
+
+// OpenSearchMaintenanceFieldsWindowMaintenanceWindow includes the requested fields of the GraphQL type MaintenanceWindow.
+type OpenSearchMaintenanceFieldsWindowMaintenanceWindow struct {
+	// Day of the week when the maintenance is scheduled.
+	DayOfWeek Weekday `json:"dayOfWeek"`
+	// Time of day when the maintenance is scheduled.
+	TimeOfDay string `json:"timeOfDay"`
+}
+
+// GetDayOfWeek returns OpenSearchMaintenanceFieldsWindowMaintenanceWindow.DayOfWeek, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsWindowMaintenanceWindow) GetDayOfWeek() Weekday {
+	return v.DayOfWeek
+}
+
+// GetTimeOfDay returns OpenSearchMaintenanceFieldsWindowMaintenanceWindow.TimeOfDay, and is useful for accessing the field via an interface.
+func (v *OpenSearchMaintenanceFieldsWindowMaintenanceWindow) GetTimeOfDay() string {
+	return v.TimeOfDay
+}
+
 type OpenSearchMajorVersion string
 
 const (
@@ -31108,6 +32579,48 @@ var AllSqlInstanceState = []SqlInstanceState{
 	SqlInstanceStateFailed,
 }
 
+// StartOpenSearchMaintenanceResponse is returned by StartOpenSearchMaintenance on success.
+type StartOpenSearchMaintenanceResponse struct {
+	// Start maintenance updates for an OpenSearch instance.
+	StartOpenSearchMaintenance StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload `json:"startOpenSearchMaintenance"`
+}
+
+// GetStartOpenSearchMaintenance returns StartOpenSearchMaintenanceResponse.StartOpenSearchMaintenance, and is useful for accessing the field via an interface.
+func (v *StartOpenSearchMaintenanceResponse) GetStartOpenSearchMaintenance() StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload {
+	return v.StartOpenSearchMaintenance
+}
+
+// StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload includes the requested fields of the GraphQL type StartOpenSearchMaintenancePayload.
+type StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload struct {
+	Error string `json:"error"`
+}
+
+// GetError returns StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload.Error, and is useful for accessing the field via an interface.
+func (v *StartOpenSearchMaintenanceStartOpenSearchMaintenanceStartOpenSearchMaintenancePayload) GetError() string {
+	return v.Error
+}
+
+// StartValkeyMaintenanceResponse is returned by StartValkeyMaintenance on success.
+type StartValkeyMaintenanceResponse struct {
+	// Start maintenance updates for a Valkey instance.
+	StartValkeyMaintenance StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload `json:"startValkeyMaintenance"`
+}
+
+// GetStartValkeyMaintenance returns StartValkeyMaintenanceResponse.StartValkeyMaintenance, and is useful for accessing the field via an interface.
+func (v *StartValkeyMaintenanceResponse) GetStartValkeyMaintenance() StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload {
+	return v.StartValkeyMaintenance
+}
+
+// StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload includes the requested fields of the GraphQL type StartValkeyMaintenancePayload.
+type StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload struct {
+	Error string `json:"error"`
+}
+
+// GetError returns StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload.Error, and is useful for accessing the field via an interface.
+func (v *StartValkeyMaintenanceStartValkeyMaintenanceStartValkeyMaintenancePayload) GetError() string {
+	return v.Error
+}
+
 // TailLogLogLogLine includes the requested fields of the GraphQL type LogLine.
 type TailLogLogLogLine struct {
 	// Timestamp of the log line.
@@ -32206,6 +33719,80 @@ func (v *ValkeyFilter) GetTiers() []ValkeyTier { return v.Tiers }
 // GetLabels returns ValkeyFilter.Labels, and is useful for accessing the field via an interface.
 func (v *ValkeyFilter) GetLabels() []LabelFilter { return v.Labels }
 
+// ValkeyMaintenanceFields includes the GraphQL fields of ValkeyMaintenance requested by the fragment ValkeyMaintenanceFields.
+type ValkeyMaintenanceFields struct {
+	// The day and time of the week when the maintenance will be scheduled.
+	Window  ValkeyMaintenanceFieldsWindowMaintenanceWindow                  `json:"window"`
+	Updates ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection `json:"updates"`
+}
+
+// GetWindow returns ValkeyMaintenanceFields.Window, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFields) GetWindow() ValkeyMaintenanceFieldsWindowMaintenanceWindow {
+	return v.Window
+}
+
+// GetUpdates returns ValkeyMaintenanceFields.Updates, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFields) GetUpdates() ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection {
+	return v.Updates
+}
+
+// ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection includes the requested fields of the GraphQL type ValkeyMaintenanceUpdateConnection.
+type ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection struct {
+	// List of nodes.
+	Nodes []ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate `json:"nodes"`
+}
+
+// GetNodes returns ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnection) GetNodes() []ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate {
+	return v.Nodes
+}
+
+// ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate includes the requested fields of the GraphQL type ValkeyMaintenanceUpdate.
+type ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate struct {
+	// Title of the maintenance.
+	Title string `json:"title"`
+	// Description of the maintenance.
+	Description string `json:"description"`
+	// Deadline for installing the maintenance. If set, maintenance is mandatory and will be forcibly applied.
+	Deadline time.Time `json:"deadline"`
+	// The time when the update will be automatically applied. If set, maintenance is mandatory and will be forcibly applied.
+	StartAt time.Time `json:"startAt"`
+}
+
+// GetTitle returns ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate.Title, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate) GetTitle() string {
+	return v.Title
+}
+
+// GetDescription returns ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate.Description, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate) GetDescription() string {
+	return v.Description
+}
+
+// GetDeadline returns ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate.Deadline, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate) GetDeadline() time.Time {
+	return v.Deadline
+}
+
+// GetStartAt returns ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate.StartAt, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate) GetStartAt() time.Time {
+	return v.StartAt
+}
+
+// ValkeyMaintenanceFieldsWindowMaintenanceWindow includes the requested fields of the GraphQL type MaintenanceWindow.
+type ValkeyMaintenanceFieldsWindowMaintenanceWindow struct {
+	// Day of the week when the maintenance is scheduled.
+	DayOfWeek Weekday `json:"dayOfWeek"`
+	// Time of day when the maintenance is scheduled.
+	TimeOfDay string `json:"timeOfDay"`
+}
+
+// GetDayOfWeek returns ValkeyMaintenanceFieldsWindowMaintenanceWindow.DayOfWeek, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsWindowMaintenanceWindow) GetDayOfWeek() Weekday { return v.DayOfWeek }
+
+// GetTimeOfDay returns ValkeyMaintenanceFieldsWindowMaintenanceWindow.TimeOfDay, and is useful for accessing the field via an interface.
+func (v *ValkeyMaintenanceFieldsWindowMaintenanceWindow) GetTimeOfDay() string { return v.TimeOfDay }
+
 type ValkeyMaxMemoryPolicy string
 
 const (
@@ -32382,6 +33969,36 @@ func (v *ViewSecretValuesViewSecretValuesViewSecretValuesPayloadValuesSecretValu
 	return v.Encoding
 }
 
+// The days of the week.
+type Weekday string
+
+const (
+	// Monday
+	WeekdayMonday Weekday = "MONDAY"
+	// Tuesday
+	WeekdayTuesday Weekday = "TUESDAY"
+	// Wednesday
+	WeekdayWednesday Weekday = "WEDNESDAY"
+	// Thursday
+	WeekdayThursday Weekday = "THURSDAY"
+	// Friday
+	WeekdayFriday Weekday = "FRIDAY"
+	// Saturday
+	WeekdaySaturday Weekday = "SATURDAY"
+	// Sunday
+	WeekdaySunday Weekday = "SUNDAY"
+)
+
+var AllWeekday = []Weekday{
+	WeekdayMonday,
+	WeekdayTuesday,
+	WeekdayWednesday,
+	WeekdayThursday,
+	WeekdayFriday,
+	WeekdaySaturday,
+	WeekdaySunday,
+}
+
 // __AddConfigValueInput is used internally by genqlient
 type __AddConfigValueInput struct {
 	Name            string           `json:"name"`
@@ -32826,6 +34443,22 @@ func (v *__GetApplicationActivityInput) GetActivityTypes() []ActivityLogActivity
 // GetFirst returns __GetApplicationActivityInput.First, and is useful for accessing the field via an interface.
 func (v *__GetApplicationActivityInput) GetFirst() int { return v.First }
 
+// __GetApplicationEndpointsInput is used internally by genqlient
+type __GetApplicationEndpointsInput struct {
+	Team string   `json:"team"`
+	Name string   `json:"name"`
+	Env  []string `json:"env"`
+}
+
+// GetTeam returns __GetApplicationEndpointsInput.Team, and is useful for accessing the field via an interface.
+func (v *__GetApplicationEndpointsInput) GetTeam() string { return v.Team }
+
+// GetName returns __GetApplicationEndpointsInput.Name, and is useful for accessing the field via an interface.
+func (v *__GetApplicationEndpointsInput) GetName() string { return v.Name }
+
+// GetEnv returns __GetApplicationEndpointsInput.Env, and is useful for accessing the field via an interface.
+func (v *__GetApplicationEndpointsInput) GetEnv() []string { return v.Env }
+
 // __GetApplicationEnvVarsInput is used internally by genqlient
 type __GetApplicationEnvVarsInput struct {
 	Slug string   `json:"slug"`
@@ -33068,6 +34701,22 @@ func (v *__GetJobRunsInput) GetName() string { return v.Name }
 // GetEnv returns __GetJobRunsInput.Env, and is useful for accessing the field via an interface.
 func (v *__GetJobRunsInput) GetEnv() []string { return v.Env }
 
+// __GetKafkaTopicAclInput is used internally by genqlient
+type __GetKafkaTopicAclInput struct {
+	Team        string `json:"team"`
+	Environment string `json:"environment"`
+	Topic       string `json:"topic"`
+}
+
+// GetTeam returns __GetKafkaTopicAclInput.Team, and is useful for accessing the field via an interface.
+func (v *__GetKafkaTopicAclInput) GetTeam() string { return v.Team }
+
+// GetEnvironment returns __GetKafkaTopicAclInput.Environment, and is useful for accessing the field via an interface.
+func (v *__GetKafkaTopicAclInput) GetEnvironment() string { return v.Environment }
+
+// GetTopic returns __GetKafkaTopicAclInput.Topic, and is useful for accessing the field via an interface.
+func (v *__GetKafkaTopicAclInput) GetTopic() string { return v.Topic }
+
 // __GetLatestJobRunStateInput is used internally by genqlient
 type __GetLatestJobRunStateInput struct {
 	Team string   `json:"team"`
@@ -33100,6 +34749,22 @@ func (v *__GetOpenSearchInput) GetEnvironmentName() string { return v.Environmen
 // GetTeamSlug returns __GetOpenSearchInput.TeamSlug, and is useful for accessing the field via an interface.
 func (v *__GetOpenSearchInput) GetTeamSlug() string { return v.TeamSlug }
 
+// __GetOpenSearchMaintenanceInput is used internally by genqlient
+type __GetOpenSearchMaintenanceInput struct {
+	Name            string `json:"name"`
+	EnvironmentName string `json:"environmentName"`
+	TeamSlug        string `json:"teamSlug"`
+}
+
+// GetName returns __GetOpenSearchMaintenanceInput.Name, and is useful for accessing the field via an interface.
+func (v *__GetOpenSearchMaintenanceInput) GetName() string { return v.Name }
+
+// GetEnvironmentName returns __GetOpenSearchMaintenanceInput.EnvironmentName, and is useful for accessing the field via an interface.
+func (v *__GetOpenSearchMaintenanceInput) GetEnvironmentName() string { return v.EnvironmentName }
+
+// GetTeamSlug returns __GetOpenSearchMaintenanceInput.TeamSlug, and is useful for accessing the field via an interface.
+func (v *__GetOpenSearchMaintenanceInput) GetTeamSlug() string { return v.TeamSlug }
+
 // __GetSecretActivityInput is used internally by genqlient
 type __GetSecretActivityInput struct {
 	Team          string                    `json:"team"`
@@ -33198,6 +34863,18 @@ func (v *__GetTeamKafkaTopicsInput) GetTeam() string { return v.Team }
 // GetFilter returns __GetTeamKafkaTopicsInput.Filter, and is useful for accessing the field via an interface.
 func (v *__GetTeamKafkaTopicsInput) GetFilter() KafkaTopicFilter { return v.Filter }
 
+// __GetTeamNetworkPoliciesInput is used internally by genqlient
+type __GetTeamNetworkPoliciesInput struct {
+	Team   string              `json:"team"`
+	Filter TeamWorkloadsFilter `json:"filter"`
+}
+
+// GetTeam returns __GetTeamNetworkPoliciesInput.Team, and is useful for accessing the field via an interface.
+func (v *__GetTeamNetworkPoliciesInput) GetTeam() string { return v.Team }
+
+// GetFilter returns __GetTeamNetworkPoliciesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__GetTeamNetworkPoliciesInput) GetFilter() TeamWorkloadsFilter { return v.Filter }
+
 // __GetTeamPostgresInstancesInput is used internally by genqlient
 type __GetTeamPostgresInstancesInput struct {
 	Team           string                 `json:"team"`
@@ -33246,6 +34923,22 @@ func (v *__GetValkeyInput) GetEnvironmentName() string { return v.EnvironmentNam
 // GetTeamSlug returns __GetValkeyInput.TeamSlug, and is useful for accessing the field via an interface.
 func (v *__GetValkeyInput) GetTeamSlug() string { return v.TeamSlug }
 
+// __GetValkeyMaintenanceInput is used internally by genqlient
+type __GetValkeyMaintenanceInput struct {
+	Name            string `json:"name"`
+	EnvironmentName string `json:"environmentName"`
+	TeamSlug        string `json:"teamSlug"`
+}
+
+// GetName returns __GetValkeyMaintenanceInput.Name, and is useful for accessing the field via an interface.
+func (v *__GetValkeyMaintenanceInput) GetName() string { return v.Name }
+
+// GetEnvironmentName returns __GetValkeyMaintenanceInput.EnvironmentName, and is useful for accessing the field via an interface.
+func (v *__GetValkeyMaintenanceInput) GetEnvironmentName() string { return v.EnvironmentName }
+
+// GetTeamSlug returns __GetValkeyMaintenanceInput.TeamSlug, and is useful for accessing the field via an interface.
+func (v *__GetValkeyMaintenanceInput) GetTeamSlug() string { return v.TeamSlug }
+
 // __GrantPostgresAccessInput is used internally by genqlient
 type __GrantPostgresAccessInput struct {
 	Input GrantPostgresAccessInput `json:"input"`
@@ -33452,6 +35145,38 @@ func (v *__SetRoleInput) GetEmail() string { return v.Email }
 // GetRole returns __SetRoleInput.Role, and is useful for accessing the field via an interface.
 func (v *__SetRoleInput) GetRole() TeamMemberRole { return v.Role }
 
+// __StartOpenSearchMaintenanceInput is used internally by genqlient
+type __StartOpenSearchMaintenanceInput struct {
+	ServiceName     string `json:"serviceName"`
+	EnvironmentName string `json:"environmentName"`
+	TeamSlug        string `json:"teamSlug"`
+}
+
+// GetServiceName returns __StartOpenSearchMaintenanceInput.ServiceName, and is useful for accessing the field via an interface.
+func (v *__StartOpenSearchMaintenanceInput) GetServiceName() string { return v.ServiceName }
+
+// GetEnvironmentName returns __StartOpenSearchMaintenanceInput.EnvironmentName, and is useful for accessing the field via an interface.
+func (v *__StartOpenSearchMaintenanceInput) GetEnvironmentName() string { return v.EnvironmentName }
+
+// GetTeamSlug returns __StartOpenSearchMaintenanceInput.TeamSlug, and is useful for accessing the field via an interface.
+func (v *__StartOpenSearchMaintenanceInput) GetTeamSlug() string { return v.TeamSlug }
+
+// __StartValkeyMaintenanceInput is used internally by genqlient
+type __StartValkeyMaintenanceInput struct {
+	ServiceName     string `json:"serviceName"`
+	EnvironmentName string `json:"environmentName"`
+	TeamSlug        string `json:"teamSlug"`
+}
+
+// GetServiceName returns __StartValkeyMaintenanceInput.ServiceName, and is useful for accessing the field via an interface.
+func (v *__StartValkeyMaintenanceInput) GetServiceName() string { return v.ServiceName }
+
+// GetEnvironmentName returns __StartValkeyMaintenanceInput.EnvironmentName, and is useful for accessing the field via an interface.
+func (v *__StartValkeyMaintenanceInput) GetEnvironmentName() string { return v.EnvironmentName }
+
+// GetTeamSlug returns __StartValkeyMaintenanceInput.TeamSlug, and is useful for accessing the field via an interface.
+func (v *__StartValkeyMaintenanceInput) GetTeamSlug() string { return v.TeamSlug }
+
 // __TailLogInput is used internally by genqlient
 type __TailLogInput struct {
 	Environment string    `json:"environment"`
@@ -34894,7 +36619,67 @@ func GetAllValkeys(
 		},
 	}
 
-	data_ = &GetAllValkeysResponse{}
+	data_ = &GetAllValkeysResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by GetApplicationActivity.
+const GetApplicationActivity_Operation = `
+query GetApplicationActivity ($team: Slug!, $name: String!, $environments: [String!], $activityTypes: [ActivityLogActivityType!], $first: Int) {
+	team(slug: $team) {
+		applications(filter: {name:$name,environments:$environments}) {
+			nodes {
+				name
+				teamEnvironment {
+					environment {
+						name
+					}
+				}
+				activityLog(first: $first, filter: {activityTypes:$activityTypes}) {
+					nodes {
+						__typename
+						actor
+						createdAt
+						message
+						environmentName
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func GetApplicationActivity(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	team string,
+	name string,
+	environments []string,
+	activityTypes []ActivityLogActivityType,
+	first int,
+) (data_ *GetApplicationActivityResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetApplicationActivity",
+		Query:  GetApplicationActivity_Operation,
+		Variables: &__GetApplicationActivityInput{
+			Team:          team,
+			Name:          name,
+			Environments:  environments,
+			ActivityTypes: activityTypes,
+			First:         first,
+		},
+	}
+
+	data_ = &GetApplicationActivityResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -34906,11 +36691,11 @@ func GetAllValkeys(
 	return data_, err_
 }
 
-// The query executed by GetApplicationActivity.
-const GetApplicationActivity_Operation = `
-query GetApplicationActivity ($team: Slug!, $name: String!, $environments: [String!], $activityTypes: [ActivityLogActivityType!], $first: Int) {
+// The query executed by GetApplicationEndpoints.
+const GetApplicationEndpoints_Operation = `
+query GetApplicationEndpoints ($team: Slug!, $name: String!, $env: [String!]) {
 	team(slug: $team) {
-		applications(filter: {name:$name,environments:$environments}) {
+		applications(filter: {name:$name,environments:$env}) {
 			nodes {
 				name
 				teamEnvironment {
@@ -34918,13 +36703,23 @@ query GetApplicationActivity ($team: Slug!, $name: String!, $environments: [Stri
 						name
 					}
 				}
-				activityLog(first: $first, filter: {activityTypes:$activityTypes}) {
-					nodes {
-						__typename
-						actor
-						createdAt
-						message
-						environmentName
+				ingresses {
+					url
+					type
+				}
+				authIntegrations {
+					__typename
+					... on EntraIDAuthIntegration {
+						name
+					}
+					... on IDPortenAuthIntegration {
+						name
+					}
+					... on MaskinportenAuthIntegration {
+						name
+					}
+					... on TokenXAuthIntegration {
+						name
 					}
 				}
 			}
@@ -34933,28 +36728,24 @@ query GetApplicationActivity ($team: Slug!, $name: String!, $environments: [Stri
 }
 `
 
-func GetApplicationActivity(
+func GetApplicationEndpoints(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	team string,
 	name string,
-	environments []string,
-	activityTypes []ActivityLogActivityType,
-	first int,
-) (data_ *GetApplicationActivityResponse, err_ error) {
+	env []string,
+) (data_ *GetApplicationEndpointsResponse, err_ error) {
 	req_ := &graphql.Request{
-		OpName: "GetApplicationActivity",
-		Query:  GetApplicationActivity_Operation,
-		Variables: &__GetApplicationActivityInput{
-			Team:          team,
-			Name:          name,
-			Environments:  environments,
-			ActivityTypes: activityTypes,
-			First:         first,
+		OpName: "GetApplicationEndpoints",
+		Query:  GetApplicationEndpoints_Operation,
+		Variables: &__GetApplicationEndpointsInput{
+			Team: team,
+			Name: name,
+			Env:  env,
 		},
 	}
 
-	data_ = &GetApplicationActivityResponse{}
+	data_ = &GetApplicationEndpointsResponse{}
 	resp_ := &graphql.Response{Data: data_}
 
 	err_ = client_.MakeRequest(
@@ -35796,6 +37587,54 @@ func GetJobRuns(
 	return data_, err_
 }
 
+// The query executed by GetKafkaTopicAcl.
+const GetKafkaTopicAcl_Operation = `
+query GetKafkaTopicAcl ($team: Slug!, $environment: String!, $topic: String!) {
+	team(slug: $team) {
+		environment(name: $environment) {
+			kafkaTopic(name: $topic) {
+				acl(first: 1000) {
+					nodes {
+						teamName
+						workloadName
+						access
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func GetKafkaTopicAcl(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	team string,
+	environment string,
+	topic string,
+) (data_ *GetKafkaTopicAclResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetKafkaTopicAcl",
+		Query:  GetKafkaTopicAcl_Operation,
+		Variables: &__GetKafkaTopicAclInput{
+			Team:        team,
+			Environment: environment,
+			Topic:       topic,
+		},
+	}
+
+	data_ = &GetKafkaTopicAclResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetLatestJobRunState.
 const GetLatestJobRunState_Operation = `
 query GetLatestJobRunState ($team: Slug!, $name: String!, $env: [String!]) {
@@ -35859,6 +37698,9 @@ query GetOpenSearch ($name: String!, $environmentName: String!, $teamSlug: Slug!
 					desiredMajor
 				}
 				state
+				maintenance {
+					... OpenSearchMaintenanceFields
+				}
 				access(first: 1000, orderBy: {direction:ASC,field:ACCESS}) {
 					edges {
 						node {
@@ -35878,6 +37720,20 @@ query GetOpenSearch ($name: String!, $environmentName: String!, $teamSlug: Slug!
 		}
 	}
 }
+fragment OpenSearchMaintenanceFields on OpenSearchMaintenance {
+	window {
+		dayOfWeek
+		timeOfDay
+	}
+	updates(first: 100) {
+		nodes {
+			title
+			description
+			deadline
+			startAt
+		}
+	}
+}
 `
 
 func GetOpenSearch(
@@ -35909,6 +37765,64 @@ func GetOpenSearch(
 	return data_, err_
 }
 
+// The query executed by GetOpenSearchMaintenance.
+const GetOpenSearchMaintenance_Operation = `
+query GetOpenSearchMaintenance ($name: String!, $environmentName: String!, $teamSlug: Slug!) {
+	team(slug: $teamSlug) {
+		environment(name: $environmentName) {
+			openSearch(name: $name) {
+				maintenance {
+					... OpenSearchMaintenanceFields
+				}
+			}
+		}
+	}
+}
+fragment OpenSearchMaintenanceFields on OpenSearchMaintenance {
+	window {
+		dayOfWeek
+		timeOfDay
+	}
+	updates(first: 100) {
+		nodes {
+			title
+			description
+			deadline
+			startAt
+		}
+	}
+}
+`
+
+func GetOpenSearchMaintenance(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	name string,
+	environmentName string,
+	teamSlug string,
+) (data_ *GetOpenSearchMaintenanceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetOpenSearchMaintenance",
+		Query:  GetOpenSearchMaintenance_Operation,
+		Variables: &__GetOpenSearchMaintenanceInput{
+			Name:            name,
+			EnvironmentName: environmentName,
+			TeamSlug:        teamSlug,
+		},
+	}
+
+	data_ = &GetOpenSearchMaintenanceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetSecret.
 const GetSecret_Operation = `
 query GetSecret ($name: String!, $environmentName: String!, $teamSlug: Slug!) {
@@ -36247,6 +38161,68 @@ func GetTeamKafkaTopics(
 	return data_, err_
 }
 
+// The query executed by GetTeamNetworkPolicies.
+const GetTeamNetworkPolicies_Operation = `
+query GetTeamNetworkPolicies ($team: Slug!, $filter: TeamWorkloadsFilter) {
+	team(slug: $team) {
+		workloads(first: 1000, filter: $filter) {
+			nodes {
+				__typename
+				name
+				networkPolicy {
+					inbound {
+						rules {
+							targetWorkloadName
+							targetTeamSlug
+							mutual
+						}
+					}
+					outbound {
+						rules {
+							targetWorkloadName
+							targetTeamSlug
+							mutual
+						}
+						external {
+							__typename
+							target
+							ports
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func GetTeamNetworkPolicies(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	team string,
+	filter TeamWorkloadsFilter,
+) (data_ *GetTeamNetworkPoliciesResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetTeamNetworkPolicies",
+		Query:  GetTeamNetworkPolicies_Operation,
+		Variables: &__GetTeamNetworkPoliciesInput{
+			Team:   team,
+			Filter: filter,
+		},
+	}
+
+	data_ = &GetTeamNetworkPoliciesResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by GetTeamPostgresInstances.
 const GetTeamPostgresInstances_Operation = `
 query GetTeamPostgresInstances ($team: Slug!, $postgresFilter: PostgresInstanceFilter, $sqlFilter: SqlInstanceFilter) {
@@ -36374,6 +38350,9 @@ query GetValkey ($name: String!, $environmentName: String!, $teamSlug: Slug!) {
 				tier
 				maxMemoryPolicy
 				state
+				maintenance {
+					... ValkeyMaintenanceFields
+				}
 				access(first: 1000, orderBy: {direction:ASC,field:ACCESS}) {
 					edges {
 						node {
@@ -36393,6 +38372,20 @@ query GetValkey ($name: String!, $environmentName: String!, $teamSlug: Slug!) {
 		}
 	}
 }
+fragment ValkeyMaintenanceFields on ValkeyMaintenance {
+	window {
+		dayOfWeek
+		timeOfDay
+	}
+	updates(first: 100) {
+		nodes {
+			title
+			description
+			deadline
+			startAt
+		}
+	}
+}
 `
 
 func GetValkey(
@@ -36424,6 +38417,64 @@ func GetValkey(
 	return data_, err_
 }
 
+// The query executed by GetValkeyMaintenance.
+const GetValkeyMaintenance_Operation = `
+query GetValkeyMaintenance ($name: String!, $environmentName: String!, $teamSlug: Slug!) {
+	team(slug: $teamSlug) {
+		environment(name: $environmentName) {
+			valkey(name: $name) {
+				maintenance {
+					... ValkeyMaintenanceFields
+				}
+			}
+		}
+	}
+}
+fragment ValkeyMaintenanceFields on ValkeyMaintenance {
+	window {
+		dayOfWeek
+		timeOfDay
+	}
+	updates(first: 100) {
+		nodes {
+			title
+			description
+			deadline
+			startAt
+		}
+	}
+}
+`
+
+func GetValkeyMaintenance(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	name string,
+	environmentName string,
+	teamSlug string,
+) (data_ *GetValkeyMaintenanceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "GetValkeyMaintenance",
+		Query:  GetValkeyMaintenance_Operation,
+		Variables: &__GetValkeyMaintenanceInput{
+			Name:            name,
+			EnvironmentName: environmentName,
+			TeamSlug:        teamSlug,
+		},
+	}
+
+	data_ = &GetValkeyMaintenanceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The mutation executed by GrantPostgresAccess.
 const GrantPostgresAccess_Operation = `
 mutation GrantPostgresAccess ($input: GrantPostgresAccessInput!) {
@@ -36987,6 +39038,82 @@ func SetRole(
 	return data_, err_
 }
 
+// The mutation executed by StartOpenSearchMaintenance.
+const StartOpenSearchMaintenance_Operation = `
+mutation StartOpenSearchMaintenance ($serviceName: String!, $environmentName: String!, $teamSlug: Slug!) {
+	startOpenSearchMaintenance(input: {serviceName:$serviceName,environmentName:$environmentName,teamSlug:$teamSlug}) {
+		error
+	}
+}
+`
+
+func StartOpenSearchMaintenance(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	serviceName string,
+	environmentName string,
+	teamSlug string,
+) (data_ *StartOpenSearchMaintenanceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "StartOpenSearchMaintenance",
+		Query:  StartOpenSearchMaintenance_Operation,
+		Variables: &__StartOpenSearchMaintenanceInput{
+			ServiceName:     serviceName,
+			EnvironmentName: environmentName,
+			TeamSlug:        teamSlug,
+		},
+	}
+
+	data_ = &StartOpenSearchMaintenanceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The mutation executed by StartValkeyMaintenance.
+const StartValkeyMaintenance_Operation = `
+mutation StartValkeyMaintenance ($serviceName: String!, $environmentName: String!, $teamSlug: Slug!) {
+	startValkeyMaintenance(input: {serviceName:$serviceName,environmentName:$environmentName,teamSlug:$teamSlug}) {
+		error
+	}
+}
+`
+
+func StartValkeyMaintenance(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	serviceName string,
+	environmentName string,
+	teamSlug string,
+) (data_ *StartValkeyMaintenanceResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "StartValkeyMaintenance",
+		Query:  StartValkeyMaintenance_Operation,
+		Variables: &__StartValkeyMaintenanceInput{
+			ServiceName:     serviceName,
+			EnvironmentName: environmentName,
+			TeamSlug:        teamSlug,
+		},
+	}
+
+	data_ = &StartValkeyMaintenanceResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The subscription executed by TailLog.
 const TailLog_Operation = `
 subscription TailLog ($environment: String!, $query: String!, $limit: Int, $start: Time) {
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/cli/internal/netpol/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func Netpol(parentFlags *flags.GlobalFlags) *naistrix.Command {
+	f := &flag.Netpol{GlobalFlags: parentFlags}
+	return &naistrix.Command{
+		Name:         "netpol",
+		Title:        "Inspect the network policies of a team.",
+		Description:  "Commands for inspecting which workloads are allowed to talk to each other, as given by the access policies of the workloads.",
+		StickyFlags:  f,
+		ValidateFunc: validation.RequireTeam(f),
+		SubCommands: []*naistrix.Command{
+			graph(f),
+		},
+	}
+}
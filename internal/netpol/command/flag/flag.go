@@ -0,0 +1,25 @@
+package flag
+
+import (
+	"context"
+
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
+)
+
+type Netpol struct {
+	*flags.GlobalFlags
+}
+
+type Format string
+
+func (f *Format) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"mermaid", "dot"}, "Available graph formats."
+}
+
+type Graph struct {
+	*Netpol
+	Format Format `name:"format" usage:"Format of the graph (mermaid or dot)."`
+	App    string `name:"app" usage:"Only show the rules to and from the application or job with this |NAME|."`
+	Check  bool   `name:"check" usage:"Fail if any rule has no matching rule on the other side."`
+}
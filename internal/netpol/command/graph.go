@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/netpol"
+	"github.com/nais/cli/internal/netpol/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func graph(parentFlags *flag.Netpol) *naistrix.Command {
+	flags := &flag.Graph{
+		Netpol: parentFlags,
+		Format: "mermaid",
+	}
+	return &naistrix.Command{
+		Name:  "graph",
+		Title: "Show a graph of the access policies of the workloads of a team.",
+		Description: heredoc.Doc(`
+			Shows which workloads of the team may talk to each other, and to workloads of other teams and external hosts, as a Mermaid or DOT graph.
+
+			Traffic between two workloads is only allowed when the source has an outbound rule to the target, and the target has an inbound rule from the source. Rules without a matching rule on the other side are drawn as dashed edges, and listed on stderr so that stdout only holds the graph. Rules with wildcards are not checked.
+		`),
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.Format != "mermaid" && flags.Format != "dot" {
+					return fmt.Errorf("invalid format %q, must be one of: mermaid, dot", flags.Format)
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Show the graph of the team in environment dev as a Mermaid flowchart.",
+				Command:     "--environment dev",
+			},
+			{
+				Description: "Render the rules to and from the application my-app with Graphviz.",
+				Command:     "--environment dev --app my-app --format dot | dot -Tsvg > my-app.svg",
+			},
+			{
+				Description: "Fail if any rule lacks a matching rule on the other side, e.g. in a pipeline.",
+				Command:     "--environment dev --check",
+			},
+		},
+		RunFunc: func(ctx context.Context, _ *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			workloads, err := netpol.Workloads(ctx, flags.Team, string(flags.Environment))
+			if err != nil {
+				return err
+			}
+
+			g := netpol.NewGraph(flags.Team, workloads)
+			if flags.App != "" {
+				if !slices.ContainsFunc(workloads, func(w netpol.Workload) bool { return w.Name == flags.App }) {
+					return fmt.Errorf("no workload named %q in %q", flags.App, flags.Environment)
+				}
+				g = g.Filter(flags.App)
+			}
+			mismatches := g.Mismatches()
+
+			if flags.Format == "dot" {
+				out.Printf("%s", g.DOT())
+			} else {
+				out.Printf("%s", g.Mermaid())
+			}
+
+			// The mismatches are written to stderr, so that the graph on stdout can be piped to a renderer.
+			errOut := naistrix.NewOutputWriter(os.Stderr, &flags.VerboseLevel)
+			if len(mismatches) == 0 {
+				if flags.Check {
+					errOut.Successf("All rules have a matching rule on the other side.\n")
+				}
+				return nil
+			}
+
+			errOut.Println()
+			errOut.Warnf("%d rules have no matching rule on the other side, so the traffic is not allowed:\n", len(mismatches))
+			if err := errOut.Table().Render(mismatches); err != nil {
+				return err
+			}
+			if flags.Check {
+				return fmt.Errorf("found %d rules without a matching rule on the other side", len(mismatches))
+			}
+			return nil
+		},
+	}
+}
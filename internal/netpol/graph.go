@@ -0,0 +1,210 @@
+package netpol
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Node is a workload in the graph, or an external host when Team is empty.
+type Node struct {
+	Team     string
+	Workload string
+}
+
+func (n Node) String() string {
+	if n.Team == "" {
+		return n.Workload
+	}
+	return n.Team + "/" + n.Workload
+}
+
+func (n Node) external() bool {
+	return n.Team == ""
+}
+
+// wildcard returns true if the node matches all workloads or all teams.
+func (n Node) wildcard() bool {
+	return n.Workload == "*" || n.Team == "*"
+}
+
+// Edge is allowed traffic from one workload to another. Outbound is true if the source has an outbound rule to the
+// target, and Inbound is true if the target has an inbound rule from the source. Traffic is only allowed when both are
+// true.
+type Edge struct {
+	From     Node
+	To       Node
+	Outbound bool
+	Inbound  bool
+}
+
+// Matched returns true if both sides of the edge have a rule for the other.
+func (e Edge) Matched() bool {
+	return e.Outbound && e.Inbound
+}
+
+// Graph is the edges between the workloads of a team and the workloads and hosts they talk to.
+type Graph struct {
+	Team  string
+	Edges []Edge
+}
+
+// NewGraph returns the graph of the access policies of the workloads of a team.
+func NewGraph(team string, workloads []Workload) *Graph {
+	edges := map[[2]Node]*Edge{}
+	edge := func(from, to Node) *Edge {
+		key := [2]Node{from, to}
+		if e, ok := edges[key]; ok {
+			return e
+		}
+		e := &Edge{From: from, To: to}
+		edges[key] = e
+		return e
+	}
+
+	for _, w := range workloads {
+		self := Node{Team: team, Workload: w.Name}
+		for _, r := range w.Outbound {
+			e := edge(self, Node{Team: cmp.Or(r.Team, team), Workload: r.Workload})
+			e.Outbound = true
+			e.Inbound = e.Inbound || r.Mutual
+		}
+		for _, r := range w.Inbound {
+			e := edge(Node{Team: cmp.Or(r.Team, team), Workload: r.Workload}, self)
+			e.Inbound = true
+			e.Outbound = e.Outbound || r.Mutual
+		}
+		// Traffic to external hosts only needs an outbound rule.
+		for _, host := range w.External {
+			e := edge(self, Node{Workload: host})
+			e.Outbound, e.Inbound = true, true
+		}
+	}
+
+	g := &Graph{Team: team}
+	for _, e := range edges {
+		g.Edges = append(g.Edges, *e)
+	}
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		return cmp.Or(strings.Compare(a.From.String(), b.From.String()), strings.Compare(a.To.String(), b.To.String()))
+	})
+	return g
+}
+
+// Filter returns the graph with only the edges to and from a workload of the team.
+func (g *Graph) Filter(workload string) *Graph {
+	node := Node{Team: g.Team, Workload: workload}
+	ret := &Graph{Team: g.Team}
+	for _, e := range g.Edges {
+		if e.From == node || e.To == node {
+			ret.Edges = append(ret.Edges, e)
+		}
+	}
+	return ret
+}
+
+// Mismatch is a rule without a matching rule on the other side, which means that the traffic is not allowed.
+type Mismatch struct {
+	From    string `heading:"From" json:"from"`
+	To      string `heading:"To" json:"to"`
+	Missing string `heading:"Missing" json:"missing"`
+}
+
+// Mismatches returns the rules of the graph without a matching rule on the other side. Rules with wildcards are not
+// checked, as they match workloads that are not known.
+func (g *Graph) Mismatches() []Mismatch {
+	var ret []Mismatch
+	for _, e := range g.Edges {
+		if e.Matched() || e.From.wildcard() || e.To.wildcard() {
+			continue
+		}
+		m := Mismatch{From: g.label(e.From), To: g.label(e.To)}
+		if e.Outbound {
+			m.Missing = fmt.Sprintf("inbound rule in %s", m.To)
+		} else {
+			m.Missing = fmt.Sprintf("outbound rule in %s", m.From)
+		}
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+// label returns the name of a node, without the team for workloads of the team of the graph.
+func (g *Graph) label(n Node) string {
+	if n.Team == g.Team {
+		return n.Workload
+	}
+	return n.String()
+}
+
+func (g *Graph) nodes() []Node {
+	var ret []Node
+	for _, e := range g.Edges {
+		for _, n := range []Node{e.From, e.To} {
+			if !slices.Contains(ret, n) {
+				ret = append(ret, n)
+			}
+		}
+	}
+	slices.SortFunc(ret, func(a, b Node) int { return strings.Compare(a.String(), b.String()) })
+	return ret
+}
+
+// missingSide returns a label for an edge without a matching rule on both sides.
+func missingSide(e Edge) string {
+	if e.Outbound {
+		return "no inbound rule"
+	}
+	return "no outbound rule"
+}
+
+// DOT formats the graph in the Graphviz DOT language. Edges without a matching rule on both sides are dashed and red.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.Team)
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range g.nodes() {
+		shape := "box"
+		if n.external() {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, shape=%s];\n", n.String(), g.label(n), shape)
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		if !e.Matched() {
+			attrs = fmt.Sprintf(" [style=dashed, color=red, label=%q]", missingSide(e))
+		}
+		fmt.Fprintf(&b, "  %q -> %q%s;\n", e.From.String(), e.To.String(), attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid formats the graph as a Mermaid flowchart. Edges without a matching rule on both sides are dotted and labelled.
+func (g *Graph) Mermaid() string {
+	nodes := g.nodes()
+	id := func(n Node) string {
+		return fmt.Sprintf("n%d", slices.Index(nodes, n))
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range nodes {
+		label := strings.ReplaceAll(g.label(n), `"`, "#quot;")
+		if n.external() {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id(n), label)
+		} else {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id(n), label)
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Matched() {
+			fmt.Fprintf(&b, "  %s --> %s\n", id(e.From), id(e.To))
+		} else {
+			fmt.Fprintf(&b, "  %s -. %s .-> %s\n", id(e.From), missingSide(e), id(e.To))
+		}
+	}
+	return b.String()
+}
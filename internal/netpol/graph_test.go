@@ -0,0 +1,86 @@
+package netpol
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var workloads = []Workload{
+	{
+		Name:     "frontend",
+		Outbound: []Rule{{Team: "my-team", Workload: "backend", Mutual: true}, {Team: "other-team", Workload: "api"}},
+		External: []string{"example.com"},
+	},
+	{
+		Name:     "backend",
+		Inbound:  []Rule{{Team: "my-team", Workload: "frontend", Mutual: true}, {Team: "my-team", Workload: "worker"}},
+		Outbound: []Rule{{Team: "*", Workload: "*"}},
+	},
+	{
+		Name: "worker",
+	},
+}
+
+func TestNewGraph(t *testing.T) {
+	g := NewGraph("my-team", workloads)
+
+	want := []Edge{
+		{From: Node{"my-team", "backend"}, To: Node{"*", "*"}, Outbound: true},
+		{From: Node{"my-team", "frontend"}, To: Node{"", "example.com"}, Outbound: true, Inbound: true},
+		{From: Node{"my-team", "frontend"}, To: Node{"my-team", "backend"}, Outbound: true, Inbound: true},
+		{From: Node{"my-team", "frontend"}, To: Node{"other-team", "api"}, Outbound: true},
+		{From: Node{"my-team", "worker"}, To: Node{"my-team", "backend"}, Inbound: true},
+	}
+	if !reflect.DeepEqual(g.Edges, want) {
+		t.Errorf("Edges = %+v, want %+v", g.Edges, want)
+	}
+}
+
+func TestGraph_Mismatches(t *testing.T) {
+	got := NewGraph("my-team", workloads).Mismatches()
+
+	want := []Mismatch{
+		{From: "frontend", To: "other-team/api", Missing: "inbound rule in other-team/api"},
+		{From: "worker", To: "backend", Missing: "outbound rule in worker"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Mismatches() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGraph_Filter(t *testing.T) {
+	g := NewGraph("my-team", workloads).Filter("worker")
+
+	if len(g.Edges) != 1 || g.Edges[0].From.Workload != "worker" {
+		t.Errorf("Filter() = %+v, want only the edge from worker", g.Edges)
+	}
+}
+
+func TestGraph_formats(t *testing.T) {
+	g := NewGraph("my-team", workloads).Filter("frontend")
+
+	dot := g.DOT()
+	for _, s := range []string{
+		`"my-team/frontend" [label="frontend", shape=box];`,
+		`"example.com" [label="example.com", shape=ellipse];`,
+		`"my-team/frontend" -> "my-team/backend";`,
+		`"my-team/frontend" -> "other-team/api" [style=dashed, color=red, label="no inbound rule"];`,
+	} {
+		if !strings.Contains(dot, s) {
+			t.Errorf("DOT() does not contain %q:\n%s", s, dot)
+		}
+	}
+
+	mermaid := g.Mermaid()
+	for _, s := range []string{
+		"flowchart LR\n",
+		`n0(["example.com"])`,
+		"n2 --> n1",
+		"n2 -. no inbound rule .-> n3",
+	} {
+		if !strings.Contains(mermaid, s) {
+			t.Errorf("Mermaid() does not contain %q:\n%s", s, mermaid)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package netpol
+
+import (
+	"context"
+
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+// Workload is a workload of the team and the rules of its access policy.
+type Workload struct {
+	Name     string
+	Inbound  []Rule
+	Outbound []Rule
+	External []string
+}
+
+// Rule is an inbound or outbound rule of an access policy. Mutual is true if the workload on the other side has a
+// matching rule.
+type Rule struct {
+	Team     string
+	Workload string
+	Mutual   bool
+}
+
+// Workloads returns the workloads of a team in an environment with the rules of their access policies.
+func Workloads(ctx context.Context, team, environment string) ([]Workload, error) {
+	_ = `# @genqlient
+		query GetTeamNetworkPolicies($team: Slug!, $filter: TeamWorkloadsFilter) {
+			team(slug: $team) {
+				workloads(first: 1000, filter: $filter) {
+					nodes {
+						__typename
+						name
+						networkPolicy {
+							inbound {
+								rules {
+									targetWorkloadName
+									targetTeamSlug
+									mutual
+								}
+							}
+							outbound {
+								rules {
+									targetWorkloadName
+									targetTeamSlug
+									mutual
+								}
+								external {
+									target
+									ports
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	client, err := naisapi.GraphqlClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gql.GetTeamNetworkPolicies(ctx, client, team, gql.TeamWorkloadsFilter{Environments: []string{environment}})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]Workload, 0, len(resp.Team.Workloads.Nodes))
+	for _, n := range resp.Team.Workloads.Nodes {
+		policy := n.GetNetworkPolicy()
+		w := Workload{Name: n.GetName()}
+		for _, r := range policy.Inbound.Rules {
+			w.Inbound = append(w.Inbound, Rule{Team: r.TargetTeamSlug, Workload: r.TargetWorkloadName, Mutual: r.Mutual})
+		}
+		for _, r := range policy.Outbound.Rules {
+			w.Outbound = append(w.Outbound, Rule{Team: r.TargetTeamSlug, Workload: r.TargetWorkloadName, Mutual: r.Mutual})
+		}
+		for _, e := range policy.Outbound.External {
+			w.External = append(w.External, e.GetTarget())
+		}
+		ret = append(ret, w)
+	}
+	return ret, nil
+}
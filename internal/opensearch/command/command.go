@@ -28,6 +28,8 @@ func OpenSearch(parentFlags *flags.GlobalFlags) *naistrix.Command {
 			delete(f),
 			get(f),
 			list(f),
+			maintenance(f),
+			query(f),
 			update(f),
 		},
 	}
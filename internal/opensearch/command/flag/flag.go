@@ -158,3 +158,19 @@ func (p *Permission) AutoComplete(context.Context, *naistrix.Arguments, string,
 	}
 	return perms, "Available permission levels."
 }
+
+type Query struct {
+	*OpenSearch
+	Permission Permission `name:"permission" short:"p" usage:"Permission level for the credentials (READ, WRITE, READWRITE, ADMIN)."`
+	TTL        string     `name:"ttl" usage:"Time-to-live for the temporary credentials used to connect (e.g. '1h', '1d'). Maximum 30 days."`
+	Method     string     `name:"method" short:"X" usage:"HTTP |METHOD|. Defaults to GET without a body and POST with a body."`
+	Path       string     `name:"path" usage:"|PATH| of the API to call, e.g. _search, _cat/indices or my-index/_doc/1."`
+	Index      string     `name:"index" short:"i" usage:"|INDEX| to prefix the path with."`
+	Force      bool       `name:"force" usage:"Allow DELETE requests."`
+}
+
+type Maintenance struct {
+	*OpenSearch
+	Start bool `name:"start" usage:"Start the pending maintenance updates now."`
+	Yes   bool `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
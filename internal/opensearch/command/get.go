@@ -16,7 +16,7 @@ func get(parentFlags *flag.OpenSearch) *naistrix.Command {
 	return &naistrix.Command{
 		Name:        "get",
 		Title:       "Get an OpenSearch instance.",
-		Description: "This command describes an OpenSearch instance, listing its current configuration and access list.",
+		Description: "This command describes an OpenSearch instance, listing its current configuration, access list and pending maintenance.",
 		Flags:       flags,
 		Args: []naistrix.Argument{
 			{Name: "name"},
@@ -51,7 +51,12 @@ func get(parentFlags *flag.OpenSearch) *naistrix.Command {
 			}
 
 			out.Println("OpenSearch access list")
-			return out.Table(output.TableWithTopMargin()).Render(opensearch.FormatAccessList(metadata, existing))
+			if err := out.Table(output.TableWithTopMargin()).Render(opensearch.FormatAccessList(metadata, existing)); err != nil {
+				return fmt.Errorf("rendering table: %w", err)
+			}
+
+			out.Println()
+			return renderMaintenance(out, &existing.Maintenance.OpenSearchMaintenanceFields)
 		},
 	}
 }
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/opensearch"
+	"github.com/nais/cli/internal/opensearch/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+	"github.com/nais/naistrix/output"
+)
+
+func maintenance(parentFlags *flag.OpenSearch) *naistrix.Command {
+	flags := &flag.Maintenance{OpenSearch: parentFlags}
+	return &naistrix.Command{
+		Name:  "maintenance",
+		Title: "Show and start pending maintenance for an OpenSearch instance.",
+		Description: heredoc.Doc(`
+			Lists the pending maintenance updates for an OpenSearch instance, with their deadlines and the weekly maintenance window.
+
+			Updates are applied in the maintenance window, or at their deadline if they are mandatory. Maintenance may restart the instance, so use --start to apply the updates at a time that suits you instead.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "name"},
+		},
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			validateArgs,
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() == 0 {
+				return autoCompleteOpenSearchNames(ctx, flags.Team, string(flags.Environment), true)
+			}
+			return nil, ""
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "List pending maintenance for an OpenSearch instance named some-opensearch in environment dev.",
+				Command:     "some-opensearch --environment dev",
+			},
+			{
+				Description: "Start the pending maintenance now.",
+				Command:     "some-opensearch --environment dev --start",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			metadata := metadataFromArgs(args, flags.Team, string(flags.Environment))
+
+			m, err := opensearch.GetMaintenance(ctx, metadata)
+			if err != nil {
+				return fmt.Errorf("fetching maintenance for OpenSearch instance: %w", err)
+			}
+
+			if err := renderMaintenance(out, m); err != nil {
+				return err
+			}
+			if len(m.Updates.Nodes) == 0 {
+				return nil
+			}
+
+			if !flags.Start {
+				out.Infof("Run with --start to apply the updates now.\n")
+				return nil
+			}
+
+			out.Warnln("Maintenance may restart the OpenSearch instance, and it may be unavailable for a short while.")
+			if !flags.Yes {
+				if result, err := input.Confirm("Are you sure you want to continue?"); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if err := opensearch.StartMaintenance(ctx, metadata); err != nil {
+				return fmt.Errorf("starting maintenance: %w", err)
+			}
+
+			out.Successf("Started maintenance of OpenSearch instance %q in %q\n", metadata.Name, metadata.EnvironmentName)
+			return nil
+		},
+	}
+}
+
+func renderMaintenance(out *naistrix.OutputWriter, m *gql.OpenSearchMaintenanceFields) error {
+	if window := opensearch.FormatMaintenanceWindow(m); window != "" {
+		out.Printf("Maintenance window: %s\n", window)
+	}
+
+	if len(m.Updates.Nodes) == 0 {
+		out.Println("No pending maintenance updates.")
+		return nil
+	}
+
+	out.Println("Pending maintenance updates")
+	return out.Table(output.TableWithTopMargin()).Render(opensearch.FormatMaintenanceUpdates(m))
+}
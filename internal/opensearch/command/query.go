@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/aiven"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/opensearch"
+	"github.com/nais/cli/internal/opensearch/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func query(parentFlags *flag.OpenSearch) *naistrix.Command {
+	flags := &flag.Query{
+		OpenSearch: parentFlags,
+		Permission: flag.Permission(gql.CredentialPermissionRead),
+		TTL:        "1h",
+		Path:       "_search",
+	}
+	return &naistrix.Command{
+		Name:  "query",
+		Title: "Send a request to an OpenSearch instance.",
+		Description: heredoc.Doc(`
+			Creates temporary credentials for an OpenSearch instance, sends an authenticated request to its API and pretty-prints the response.
+
+			The request body is given as an argument. Without a path, the request is sent to the _search API. The credentials have READ permission unless another permission is given, and DELETE requests are refused unless --force is given.
+		`),
+		Flags: flags,
+		Args: []naistrix.Argument{
+			{Name: "name"},
+			{Name: "body", Repeatable: true},
+		},
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(_ context.Context, args *naistrix.Arguments) error {
+				if args.Get("name") == "" {
+					return fmt.Errorf("name cannot be empty")
+				}
+				if !aiven.IsValidPermission(gql.CredentialPermission(flags.Permission)) {
+					return fmt.Errorf("invalid permission %q, must be one of: %v", flags.Permission, gql.AllCredentialPermission)
+				}
+				if strings.EqualFold(flags.Method, http.MethodDelete) && !flags.Force {
+					return fmt.Errorf("DELETE requests are refused unless --force is given")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() != 0 {
+				return nil, ""
+			}
+			return autoCompleteOpenSearchNames(ctx, flags.Team, string(flags.Environment), true)
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Search all indices of an OpenSearch instance named my-opensearch in environment dev.",
+				Command:     `my-opensearch --environment dev '{"query": {"match": {"message": "timeout"}}}'`,
+			},
+			{
+				Description: "Search a single index.",
+				Command:     `my-opensearch --environment dev --index logs '{"size": 5, "sort": [{"@timestamp": "desc"}]}'`,
+			},
+			{
+				Description: "List the indices.",
+				Command:     "my-opensearch --environment dev --path '_cat/indices?v&format=json'",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			path := flags.Path
+			if flags.Index != "" {
+				path = strings.TrimSuffix(flags.Index, "/") + "/" + strings.TrimPrefix(path, "/")
+			}
+
+			return opensearch.Query(
+				ctx,
+				metadataFromArgs(args, flags.Team, string(flags.Environment)),
+				gql.CredentialPermission(flags.Permission),
+				flags.TTL,
+				opensearch.Request{
+					Method: flags.Method,
+					Path:   path,
+					Body:   strings.Join(args.GetRepeatable("body"), " "),
+				},
+				out,
+			)
+		},
+	}
+}
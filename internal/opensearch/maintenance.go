@@ -0,0 +1,110 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func GetMaintenance(ctx context.Context, metadata Metadata) (*gql.OpenSearchMaintenanceFields, error) {
+	_ = `# @genqlient
+		query GetOpenSearchMaintenance($name: String!, $environmentName: String!, $teamSlug: Slug!) {
+		  team(slug: $teamSlug) {
+			environment(name: $environmentName) {
+			  openSearch(name: $name) {
+				maintenance {
+				  ...OpenSearchMaintenanceFields
+				}
+			  }
+			}
+		  }
+		}
+
+		fragment OpenSearchMaintenanceFields on OpenSearchMaintenance {
+		  window {
+			dayOfWeek
+			timeOfDay
+		  }
+		  updates(first: 100) {
+			nodes {
+			  title
+			  description
+			  deadline
+			  startAt
+			}
+		  }
+		}
+	`
+
+	client, err := naisapi.GraphqlClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gql.GetOpenSearchMaintenance(ctx, client, metadata.Name, metadata.EnvironmentName, metadata.TeamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Team.Environment.OpenSearch.Maintenance.OpenSearchMaintenanceFields, nil
+}
+
+// StartMaintenance starts the pending maintenance updates of an OpenSearch instance now, instead of in the next maintenance
+// window or at their deadline.
+func StartMaintenance(ctx context.Context, metadata Metadata) error {
+	_ = `# @genqlient
+		mutation StartOpenSearchMaintenance($serviceName: String!, $environmentName: String!, $teamSlug: Slug!) {
+		  startOpenSearchMaintenance(input: { serviceName: $serviceName, environmentName: $environmentName, teamSlug: $teamSlug }) {
+		    error
+		  }
+		}
+	`
+
+	client, err := naisapi.GraphqlClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := gql.StartOpenSearchMaintenance(ctx, client, metadata.Name, metadata.EnvironmentName, metadata.TeamSlug)
+	if err != nil {
+		return err
+	}
+
+	if resp.StartOpenSearchMaintenance.Error != "" {
+		return fmt.Errorf("%s", resp.StartOpenSearchMaintenance.Error)
+	}
+	return nil
+}
+
+// FormatMaintenanceWindow returns the weekly maintenance window, or an empty string if none is configured.
+func FormatMaintenanceWindow(maintenance *gql.OpenSearchMaintenanceFields) string {
+	if maintenance.Window.DayOfWeek == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s at %s", maintenance.Window.DayOfWeek, maintenance.Window.TimeOfDay)
+}
+
+func FormatMaintenanceUpdates(maintenance *gql.OpenSearchMaintenanceFields) [][]string {
+	rows := [][]string{
+		{"Title", "Deadline", "Starts at", "Description"},
+	}
+	for _, update := range maintenance.Updates.Nodes {
+		rows = append(rows, []string{
+			update.Title,
+			formatMaintenanceTime(update.Deadline),
+			formatMaintenanceTime(update.StartAt),
+			update.Description,
+		})
+	}
+	return rows
+}
+
+func formatMaintenanceTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
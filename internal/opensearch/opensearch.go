@@ -100,6 +100,9 @@ func Get(ctx context.Context, metadata Metadata) (*gql.GetOpenSearchTeamEnvironm
 				  desiredMajor
 				}
 				state
+				maintenance {
+				  ...OpenSearchMaintenanceFields
+				}
 				access(first: 1000, orderBy: {direction: ASC, field: ACCESS}) {
 				  edges {
 					node {
@@ -0,0 +1,113 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/naistrix"
+)
+
+// Request is an HTTP request to the OpenSearch API.
+type Request struct {
+	Method string
+	// Path is relative to the root of the API, e.g. _search or my-index/_doc/1.
+	Path string
+	Body string
+}
+
+// Query creates temporary credentials for an OpenSearch instance, sends the request with them, and prints the response.
+// JSON responses are pretty-printed.
+func Query(ctx context.Context, metadata Metadata, permission gql.CredentialPermission, ttl string, req Request, out *naistrix.OutputWriter) error {
+	if req.Body != "" && !isNDJSONPath(req.Path) && !json.Valid([]byte(req.Body)) {
+		return fmt.Errorf("the request body is not valid JSON")
+	}
+
+	creds, err := CreateCredentials(ctx, metadata.TeamSlug, metadata.EnvironmentName, metadata.Name, permission, ttl)
+	if err != nil {
+		return fmt.Errorf("creating OpenSearch credentials: %w", err)
+	}
+
+	httpReq, err := newHTTPRequest(ctx, creds.Host, creds.Port, req)
+	if err != nil {
+		return err
+	}
+	httpReq.SetBasicAuth(creds.Username, creds.Password)
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+
+	out.Println(prettyJSON(body))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request failed: %s", resp.Status)
+	}
+	return nil
+}
+
+func newHTTPRequest(ctx context.Context, host string, port int, req Request) (*http.Request, error) {
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+		if req.Body != "" {
+			method = http.MethodPost
+		}
+	}
+
+	u := &url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+	}
+	path, query, _ := strings.Cut(req.Path, "?")
+	u.Path = "/" + strings.TrimPrefix(path, "/")
+	u.RawQuery = query
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != "" {
+		contentType := "application/json"
+		if isNDJSONPath(req.Path) {
+			contentType = "application/x-ndjson"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	return httpReq, nil
+}
+
+// isNDJSONPath reports whether the API at the path takes newline-delimited JSON.
+func isNDJSONPath(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	return strings.HasSuffix(path, "_bulk") || strings.HasSuffix(path, "_msearch")
+}
+
+func prettyJSON(b []byte) string {
+	buf := &bytes.Buffer{}
+	if err := json.Indent(buf, b, "", "  "); err != nil {
+		return strings.TrimRight(string(b), "\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,52 @@
+package opensearch
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewHTTPRequest(t *testing.T) {
+	tests := []struct {
+		req         Request
+		method      string
+		url         string
+		contentType string
+	}{
+		{
+			req:    Request{Path: "_cat/indices?v&format=json"},
+			method: "GET",
+			url:    "https://os.example.com:443/_cat/indices?v&format=json",
+		},
+		{
+			req:         Request{Path: "/logs/_search", Body: `{"size": 1}`},
+			method:      "POST",
+			url:         "https://os.example.com:443/logs/_search",
+			contentType: "application/json",
+		},
+		{
+			req:         Request{Method: "put", Path: "_bulk", Body: "{}\n{}\n"},
+			method:      "PUT",
+			url:         "https://os.example.com:443/_bulk",
+			contentType: "application/x-ndjson",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := newHTTPRequest(context.Background(), "os.example.com", 443, tt.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Method != tt.method || got.URL.String() != tt.url || got.Header.Get("Content-Type") != tt.contentType {
+			t.Errorf("newHTTPRequest(%+v) = %s %s (%q), want %s %s (%q)", tt.req, got.Method, got.URL, got.Header.Get("Content-Type"), tt.method, tt.url, tt.contentType)
+		}
+	}
+}
+
+func TestPrettyJSON(t *testing.T) {
+	if got, want := prettyJSON([]byte(`{"a":[1,2]}`)), "{\n  \"a\": [\n    1,\n    2\n  ]\n}"; got != want {
+		t.Errorf("prettyJSON() = %q, want %q", got, want)
+	}
+	if got, want := prettyJSON([]byte("green open logs\n")), "green open logs"; got != want {
+		t.Errorf("prettyJSON() = %q, want %q", got, want)
+	}
+}
@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
+	"github.com/nais/cli/internal/gcloud"
 	"github.com/nais/cli/internal/postgres/command/flag"
 	"github.com/nais/naistrix"
 )
@@ -48,11 +51,53 @@ func PrepareAccess(ctx context.Context, appName, team, environment string, fl *f
 		return statement
 	}
 
+	var grant *AccessGrant
+	if fl.For > 0 {
+		grantedBy, err := gcloud.GetActiveUserEmail(ctx)
+		if err != nil {
+			return err
+		}
+		// The access from before is kept when the grant expires, so that a time-boxed grant does not take away access
+		// that was granted without a time limit.
+		previous, err := schemaAccess(ctx, appName, team, environment, fl.Schema, sv)
+		if err != nil {
+			return fmt.Errorf("checking existing access to schema %q: %w", fl.Schema, err)
+		}
+		grant = &AccessGrant{
+			Schema:        fl.Schema,
+			AllPrivileges: fl.AllPrivileges,
+			Previous:      previous,
+			GrantedBy:     grantedBy,
+			Expires:       time.Now().Add(fl.For),
+		}
+	}
+
+	statement := grantSelectPrivs
 	if fl.AllPrivileges {
-		return sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, prependUsageIfNotPublic(grantAllPrivs), sv)
-	} else {
-		return sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, prependUsageIfNotPublic(grantSelectPrivs), sv)
+		statement = grantAllPrivs
+	}
+	if err := sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, prependUsageIfNotPublic(statement), sv); err != nil {
+		return err
+	}
+
+	if err := recordAccessGrant(ctx, appName, team, environment, fl.Schema, grant); err != nil {
+		if grant == nil {
+			// The access has been granted, but an earlier time-boxed grant for the schema may still be recorded and
+			// revoke it when it expires.
+			out.Warnf("Unable to check for time-boxed access to schema %q, see 'nais postgres access list': %v\n", fl.Schema, err)
+			return nil
+		}
+		// Without a record the access would never expire, so take it back.
+		if rerr := sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, expireStatement(*grant), sv); rerr != nil {
+			return fmt.Errorf("%w; revoking the access also failed, run 'nais postgres revoke': %v", err, rerr)
+		}
+		return fmt.Errorf("access was revoked again, as the expiry could not be recorded: %w", err)
+	}
+
+	if grant != nil {
+		out.Printf("Access to schema %q expires at %s. Expired access is revoked by the next postgres command, see 'nais postgres access list'.\n", fl.Schema, grant.Expires.Local().Format(time.DateTime))
 	}
+	return nil
 }
 
 func RevokeAccess(ctx context.Context, appName, team, environment string, fl *flag.Revoke, out *naistrix.OutputWriter) error {
@@ -62,34 +107,43 @@ func RevokeAccess(ctx context.Context, appName, team, environment string, fl *fl
 		return err
 	}
 
-	q := revokeAllPrivs
-	if fl.Schema != "public" {
-		q += "\n" + revokeUsage
+	if err := sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, revokeStatement(fl.Schema), sv); err != nil {
+		return err
 	}
-	return sqlExecAsAppUser(ctx, appName, team, environment, fl.Schema, q, sv)
+	return recordAccessGrant(ctx, appName, team, environment, fl.Schema, nil)
 }
 
-func sqlExecAsAppUser(ctx context.Context, appName, team, environment string, schema, statement string, sv *SecretValues) error {
-	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
-	if err != nil {
-		return err
+// expireStatement returns the statement that takes back the access added by a time-boxed grant, leaving the access the
+// schema had before it. It is empty when the grant did not add anything.
+func expireStatement(g AccessGrant) string {
+	switch g.Previous {
+	case accessAll:
+		return ""
+	case accessRead:
+		if !g.AllPrivileges {
+			return ""
+		}
+		return revokeAllPrivs + "\n" + grantSelectPrivs
+	default:
+		return revokeStatement(g.Schema)
 	}
+}
 
-	dbInfo.SetSecretValues(sv)
-
-	connectionInfo, err := dbInfo.DBConnection(ctx)
-	if err != nil {
-		return err
+func revokeStatement(schema string) string {
+	if schema != "public" {
+		return revokeAllPrivs + "\n" + revokeUsage
 	}
+	return revokeAllPrivs
+}
 
-	schema = pq.QuoteIdentifier(schema)
-	statement = strings.ReplaceAll(statement, "$schema", schema)
-	db, err := sql.Open("cloudsqlpostgres", connectionInfo.ProxyConnectionString())
+func sqlExecAsAppUser(ctx context.Context, appName, team, environment string, schema, statement string, sv *SecretValues) error {
+	db, err := openAsAppUser(ctx, appName, team, environment, sv)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = db.Close() }()
 
+	statement = strings.ReplaceAll(statement, "$schema", pq.QuoteIdentifier(schema))
 	_, err = db.ExecContext(ctx, statement)
 	if err != nil {
 		return formatInvalidGrantError(err)
@@ -97,3 +151,51 @@ func sqlExecAsAppUser(ctx context.Context, appName, team, environment string, sc
 
 	return nil
 }
+
+// schemaAccess returns the access cloudsqliamuser has to new tables in a schema, as granted by prepare: accessAll,
+// accessRead or accessNone.
+func schemaAccess(ctx context.Context, appName, team, environment, schema string, sv *SecretValues) (string, error) {
+	db, err := openAsAppUser(ctx, appName, team, environment, sv)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = db.Close() }()
+
+	var read, write bool
+	err = db.QueryRowContext(ctx, `SELECT
+			coalesce(bool_or(a.privilege_type = 'SELECT'), false),
+			coalesce(bool_or(a.privilege_type = 'INSERT'), false)
+		FROM pg_default_acl d
+		JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+		JOIN pg_roles r ON r.oid = a.grantee
+		WHERE n.nspname = $1 AND d.defaclobjtype = 'r' AND r.rolname = 'cloudsqliamuser'`, schema).Scan(&read, &write)
+	if err != nil {
+		return "", formatInvalidGrantError(err)
+	}
+
+	switch {
+	case write:
+		return accessAll, nil
+	case read:
+		return accessRead, nil
+	default:
+		return accessNone, nil
+	}
+}
+
+func openAsAppUser(ctx context.Context, appName, team, environment string, sv *SecretValues) (*sql.DB, error) {
+	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInfo.SetSecretValues(sv)
+
+	connectionInfo, err := dbInfo.DBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.Open("cloudsqlpostgres", connectionInfo.ProxyConnectionString())
+}
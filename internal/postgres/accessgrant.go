@@ -0,0 +1,302 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// accessGrantsAnnotation is the annotation on the SQL instance that records time-boxed access grants, so that every
+// member of the team can see them and any postgres command can revoke them when they expire.
+const accessGrantsAnnotation = "postgres.nais.io/access-grants"
+
+// The access to a schema granted to cloudsqliamuser, as recorded in [AccessGrant.Previous].
+const (
+	accessNone = ""
+	accessRead = "read"
+	accessAll  = "all"
+)
+
+// AccessGrant is access to a schema granted to cloudsqliamuser by `nais postgres prepare --for`. Previous is the access
+// the schema had before the grant, which is kept when it expires.
+type AccessGrant struct {
+	Schema        string    `json:"schema"`
+	AllPrivileges bool      `json:"allPrivileges"`
+	Previous      string    `json:"previous,omitempty"`
+	GrantedBy     string    `json:"grantedBy"`
+	Expires       time.Time `json:"expires"`
+}
+
+// AccessGrantSummary is a time-boxed access grant on the SQL instance of an application.
+type AccessGrantSummary struct {
+	AppName    string    `heading:"App" json:"app"`
+	Instance   string    `heading:"Instance" json:"instance"`
+	Schema     string    `heading:"Schema" json:"schema"`
+	Privileges string    `heading:"Privileges" json:"privileges"`
+	GrantedBy  string    `heading:"Granted By" json:"granted_by"`
+	Expires    time.Time `heading:"Expires" json:"expires"`
+	Remaining  string    `heading:"Remaining" json:"-"`
+}
+
+var sqlInstanceResource = schema.GroupVersionResource{
+	Group:    "sql.cnrm.cloud.google.com",
+	Version:  "v1beta1",
+	Resource: "sqlinstances",
+}
+
+func sqlInstanceClient(team, environment string) (dynamic.ResourceInterface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	configOverrides := &clientcmd.ConfigOverrides{
+		CurrentContext: environment,
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get kubeconfig: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("load kubeclient configuration: %w", err)
+	}
+
+	return dynamicClient.Resource(sqlInstanceResource).Namespace(team), nil
+}
+
+// appSQLInstance returns the SQL instance of an application, or nil if the application does not use Cloud SQL.
+func appSQLInstance(ctx context.Context, client dynamic.ResourceInterface, appName string) (*unstructured.Unstructured, error) {
+	sqlInstances, err := client.List(ctx, meta_v1.ListOptions{LabelSelector: "app=" + appName})
+	if err != nil {
+		return nil, fmt.Errorf("looking for sqlinstance for app %q: %w", appName, err)
+	}
+	switch len(sqlInstances.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &sqlInstances.Items[0], nil
+	default:
+		return nil, fmt.Errorf("multiple sqlinstances found for app %q", appName)
+	}
+}
+
+func parseAccessGrants(instance *unstructured.Unstructured) ([]AccessGrant, error) {
+	value, ok := instance.GetAnnotations()[accessGrantsAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	var grants []AccessGrant
+	if err := json.Unmarshal([]byte(value), &grants); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on sqlinstance %q: %w", accessGrantsAnnotation, instance.GetName(), err)
+	}
+	return grants, nil
+}
+
+func updateAccessGrants(ctx context.Context, client dynamic.ResourceInterface, instance *unstructured.Unstructured, grants []AccessGrant) error {
+	// A null value removes the annotation in a merge patch.
+	var value any
+	if len(grants) > 0 {
+		b, err := json.Marshal(grants)
+		if err != nil {
+			return err
+		}
+		value = string(b)
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{accessGrantsAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Patch(ctx, instance.GetName(), types.MergePatchType, patch, meta_v1.PatchOptions{}); err != nil {
+		return fmt.Errorf("updating access grants on sqlinstance %q: %w", instance.GetName(), err)
+	}
+	return nil
+}
+
+// setAccessGrant replaces the grant for the same schema, if any, with the new grant. The access from before the replaced
+// grant is kept, as the access that is there now includes what the replaced grant added.
+func setAccessGrant(grants []AccessGrant, grant AccessGrant) []AccessGrant {
+	if i := slices.IndexFunc(grants, func(g AccessGrant) bool { return g.Schema == grant.Schema }); i >= 0 {
+		grant.Previous = grants[i].Previous
+	}
+	return append(removeAccessGrant(grants, grant.Schema), grant)
+}
+
+func removeAccessGrant(grants []AccessGrant, schema string) []AccessGrant {
+	return slices.DeleteFunc(slices.Clone(grants), func(g AccessGrant) bool { return g.Schema == schema })
+}
+
+// partitionAccessGrants splits grants into those that have expired and those that are still active.
+func partitionAccessGrants(grants []AccessGrant, now time.Time) (expired, active []AccessGrant) {
+	for _, g := range grants {
+		if now.Before(g.Expires) {
+			active = append(active, g)
+		} else {
+			expired = append(expired, g)
+		}
+	}
+	return expired, active
+}
+
+// recordAccessGrant records a time-boxed grant on the SQL instance of the application. A nil grant removes the record
+// for the schema, as access granted without a time limit does not expire.
+func recordAccessGrant(ctx context.Context, appName, team, environment, schema string, grant *AccessGrant) error {
+	client, err := sqlInstanceClient(team, environment)
+	if err != nil {
+		return err
+	}
+
+	instance, err := appSQLInstance(ctx, client, appName)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		if grant != nil {
+			return fmt.Errorf("time-boxed access is only supported for Cloud SQL databases")
+		}
+		return nil
+	}
+
+	grants, err := parseAccessGrants(instance)
+	if err != nil {
+		return err
+	}
+
+	updated := removeAccessGrant(grants, schema)
+	if grant != nil {
+		updated = setAccessGrant(grants, *grant)
+	} else if len(updated) == len(grants) {
+		return nil
+	}
+
+	return updateAccessGrants(ctx, client, instance, updated)
+}
+
+// ListAccessGrants lists the time-boxed access grants on the SQL instances of the team.
+func ListAccessGrants(ctx context.Context, team, environment string, fl *flag.AccessList, out *naistrix.OutputWriter) error {
+	client, err := sqlInstanceClient(team, environment)
+	if err != nil {
+		return err
+	}
+
+	sqlInstances, err := client.List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing sqlinstances in %q: %w", team, err)
+	}
+
+	now := time.Now()
+	ret := []AccessGrantSummary{}
+	for i := range sqlInstances.Items {
+		instance := &sqlInstances.Items[i]
+		grants, err := parseAccessGrants(instance)
+		if err != nil {
+			out.Warnf("%v\n", err)
+			continue
+		}
+
+		for _, g := range grants {
+			ret = append(ret, AccessGrantSummary{
+				AppName:    instance.GetLabels()["app"],
+				Instance:   instance.GetName(),
+				Schema:     g.Schema,
+				Privileges: grantPrivileges(g),
+				GrantedBy:  g.GrantedBy,
+				Expires:    g.Expires,
+				Remaining:  remaining(g.Expires, now),
+			})
+		}
+	}
+
+	if fl.Output == "json" {
+		return out.JSON(output.JSONWithPrettyOutput()).Render(ret)
+	}
+
+	if len(ret) == 0 {
+		out.Println("No time-boxed access grants found.")
+		return nil
+	}
+	return out.Table().Render(ret)
+}
+
+func grantPrivileges(g AccessGrant) string {
+	if g.AllPrivileges {
+		return accessAll
+	}
+	return accessRead
+}
+
+func remaining(expires, now time.Time) string {
+	if !now.Before(expires) {
+		return "expired"
+	}
+	return expires.Sub(now).Round(time.Minute).String()
+}
+
+// SweepExpiredAccess revokes the time-boxed access grants on the SQL instances of the team that have expired, keeping
+// the access the schemas had before the grants. A grant that cannot be revoked is kept, and revoking it is attempted
+// again by the next command.
+func SweepExpiredAccess(ctx context.Context, team, environment string, fl *flag.Postgres, out *naistrix.OutputWriter) error {
+	client, err := sqlInstanceClient(team, environment)
+	if err != nil {
+		return err
+	}
+
+	sqlInstances, err := client.List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing sqlinstances in %q: %w", team, err)
+	}
+
+	for i := range sqlInstances.Items {
+		instance := &sqlInstances.Items[i]
+		grants, err := parseAccessGrants(instance)
+		if err != nil {
+			out.Warnf("%v\n", err)
+			continue
+		}
+
+		expired, _ := partitionAccessGrants(grants, time.Now())
+		if len(expired) == 0 {
+			continue
+		}
+
+		appName := instance.GetLabels()["app"]
+		sv, err := GetSecretValues(ctx, appName, team, environment, fl, ReasonRevokeExpiredAccess, out)
+		if err != nil {
+			out.Warnf("Unable to revoke expired access on %s: %v\n", instance.GetName(), err)
+			continue
+		}
+
+		for _, g := range expired {
+			if statement := expireStatement(g); statement != "" {
+				if err := sqlExecAsAppUser(ctx, appName, team, environment, g.Schema, statement, sv); err != nil {
+					out.Warnf("Unable to revoke expired access to schema %q on %s: %v\n", g.Schema, instance.GetName(), err)
+					continue
+				}
+			}
+			grants = removeAccessGrant(grants, g.Schema)
+			out.Infof("Revoked expired access to schema %q on %s, granted by %s until %s\n", g.Schema, instance.GetName(), g.GrantedBy, g.Expires.Local().Format(time.DateTime))
+		}
+
+		if err := updateAccessGrants(ctx, client, instance, grants); err != nil {
+			out.Warnf("%v\n", err)
+		}
+	}
+
+	return nil
+}
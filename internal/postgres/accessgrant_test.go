@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAccessGrants(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	grants := []AccessGrant{
+		{Schema: "public", Expires: now.Add(-time.Minute)},
+		{Schema: "audit", AllPrivileges: true, Expires: now.Add(time.Hour)},
+	}
+
+	expired, active := partitionAccessGrants(grants, now)
+	if len(expired) != 1 || expired[0].Schema != "public" {
+		t.Errorf("unexpected expired grants: %+v", expired)
+	}
+	if len(active) != 1 || active[0].Schema != "audit" {
+		t.Errorf("unexpected active grants: %+v", active)
+	}
+
+	updated := setAccessGrant(grants, AccessGrant{Schema: "public", Expires: now.Add(2 * time.Hour)})
+	if len(updated) != 2 || updated[1].Schema != "public" || !updated[1].Expires.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("unexpected grants after set: %+v", updated)
+	}
+	if !grants[0].Expires.Equal(now.Add(-time.Minute)) {
+		t.Errorf("setAccessGrant modified its input: %+v", grants)
+	}
+
+	replaced := setAccessGrant([]AccessGrant{{Schema: "public", Previous: accessRead}}, AccessGrant{Schema: "public", AllPrivileges: true, Previous: accessAll})
+	if len(replaced) != 1 || replaced[0].Previous != accessRead {
+		t.Errorf("expected the access from before the replaced grant to be kept, got %+v", replaced)
+	}
+
+	if updated := removeAccessGrant(grants, "audit"); len(updated) != 1 || updated[0].Schema != "public" {
+		t.Errorf("unexpected grants after remove: %+v", updated)
+	}
+
+	if got := remaining(now.Add(90*time.Minute+10*time.Second), now); got != "1h30m0s" {
+		t.Errorf("remaining() = %q, want %q", got, "1h30m0s")
+	}
+	if got := remaining(now, now); got != "expired" {
+		t.Errorf("remaining() = %q, want %q", got, "expired")
+	}
+}
+
+func TestParseAccessGrants(t *testing.T) {
+	instance := &unstructured.Unstructured{}
+	if grants, err := parseAccessGrants(instance); err != nil || grants != nil {
+		t.Errorf("parseAccessGrants() = %v, %v, want no grants", grants, err)
+	}
+
+	instance.SetAnnotations(map[string]string{
+		accessGrantsAnnotation: `[{"schema":"public","allPrivileges":true,"grantedBy":"user@example.com","expires":"2025-01-01T14:00:00Z"}]`,
+	})
+	grants, err := parseAccessGrants(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 1 || grants[0].GrantedBy != "user@example.com" || !grants[0].AllPrivileges {
+		t.Errorf("unexpected grants: %+v", grants)
+	}
+
+	instance.SetAnnotations(map[string]string{accessGrantsAnnotation: "not json"})
+	if _, err := parseAccessGrants(instance); err == nil {
+		t.Error("expected error for invalid annotation")
+	}
+}
+
+func TestExpireStatement(t *testing.T) {
+	tests := map[string]struct {
+		grant AccessGrant
+		want  string
+	}{
+		"no previous access":        {AccessGrant{Schema: "audit", AllPrivileges: true}, revokeAllPrivs + "\n" + revokeUsage},
+		"no previous access public": {AccessGrant{Schema: "public"}, revokeAllPrivs},
+		"write on top of read":      {AccessGrant{Schema: "public", AllPrivileges: true, Previous: accessRead}, revokeAllPrivs + "\n" + grantSelectPrivs},
+		"read on top of read":       {AccessGrant{Schema: "public", Previous: accessRead}, ""},
+		"anything on top of all":    {AccessGrant{Schema: "public", AllPrivileges: true, Previous: accessAll}, ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := expireStatement(tt.grant); got != tt.want {
+				t.Errorf("expireStatement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
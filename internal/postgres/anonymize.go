@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnonymizeStrategy decides what a column value is replaced with in a dump.
+type AnonymizeStrategy string
+
+const (
+	// AnonymizeNull replaces the value with NULL.
+	AnonymizeNull AnonymizeStrategy = "null"
+	// AnonymizeHash replaces the value with a keyed hash of it. Equal values get equal hashes within a dump, so joins on
+	// the column still work.
+	AnonymizeHash AnonymizeStrategy = "hash"
+	// AnonymizeEmail replaces the value with an email address built from a keyed hash of it.
+	AnonymizeEmail AnonymizeStrategy = "email"
+	// AnonymizeRedact replaces the value with a fixed placeholder.
+	AnonymizeRedact AnonymizeStrategy = "redact"
+	// AnonymizeValue replaces the value with the value given in the rule.
+	AnonymizeValue AnonymizeStrategy = "value"
+)
+
+var allAnonymizeStrategies = []AnonymizeStrategy{AnonymizeNull, AnonymizeHash, AnonymizeEmail, AnonymizeRedact, AnonymizeValue}
+
+// AnonymizeRule replaces the values of a column in a dump.
+type AnonymizeRule struct {
+	// Table is the table name, optionally qualified with the schema. Unqualified names are in the public schema.
+	Table    string            `yaml:"table"`
+	Column   string            `yaml:"column"`
+	Strategy AnonymizeStrategy `yaml:"strategy"`
+	Value    string            `yaml:"value"`
+}
+
+func (r AnonymizeRule) String() string {
+	return qualifiedTable(r.Table) + "." + r.Column
+}
+
+// AnonymizeRules is the format of the file given to `nais postgres dump --anonymize`.
+type AnonymizeRules struct {
+	// Key is the key used to hash values. When it is empty, a random key is used, so hashes only match within a dump.
+	// Setting it makes hashes match between dumps, and must be kept secret, as values can be recovered by anyone who
+	// knows it by hashing candidate values.
+	Key   string          `yaml:"key"`
+	Rules []AnonymizeRule `yaml:"rules"`
+}
+
+// LoadAnonymizeRules reads and validates anonymization rules from a YAML file.
+func LoadAnonymizeRules(path string) (*AnonymizeRules, error) {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("reading anonymization rules: %w", err)
+	}
+
+	rules := &AnonymizeRules{}
+	if err := yaml.Unmarshal(b, rules); err != nil {
+		return nil, fmt.Errorf("parsing anonymization rules in %s: %w", path, err)
+	}
+
+	for i, r := range rules.Rules {
+		if r.Table == "" || r.Column == "" {
+			return nil, fmt.Errorf("rule %d in %s: table and column are required", i+1, path)
+		}
+		if !slices.Contains(allAnonymizeStrategies, r.Strategy) {
+			return nil, fmt.Errorf("rule %d in %s: invalid strategy %q, must be one of: %v", i+1, path, r.Strategy, allAnonymizeStrategies)
+		}
+	}
+
+	return rules, nil
+}
+
+// anonymizer rewrites the COPY data of a plain-text dump, as written by pg_dump, according to a set of rules. The dump
+// is processed line by line, so dumps of any size can be streamed through it.
+type anonymizer struct {
+	rules   []AnonymizeRule
+	matched map[int]bool
+	key     []byte
+}
+
+func newAnonymizer(rules *AnonymizeRules) *anonymizer {
+	key := []byte(rules.Key)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &anonymizer{rules: rules.Rules, matched: map[int]bool{}, key: key}
+}
+
+// copyTo copies the dump from r to w, replacing the values of anonymized columns.
+func (a *anonymizer) copyTo(w io.Writer, r io.Reader) error {
+	br := bufio.NewReaderSize(r, 1<<20)
+	bw := bufio.NewWriterSize(w, 1<<20)
+
+	// columns holds the rule for each column of the COPY block being read, or is nil outside of anonymized blocks.
+	var columns []*AnonymizeRule
+	for {
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		switch {
+		case columns != nil && line == "\\.\n":
+			columns = nil
+		case columns != nil:
+			line = a.anonymizeRow(line, columns)
+		case strings.HasPrefix(line, "COPY ") && strings.HasSuffix(line, " FROM stdin;\n"):
+			columns = a.copyColumns(line)
+		}
+
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// copyColumns returns the rule for each column of a COPY statement, or nil if no rule applies to the table.
+func (a *anonymizer) copyColumns(line string) []*AnonymizeRule {
+	table, names, err := parseCopyStatement(line)
+	if err != nil {
+		return nil
+	}
+
+	var columns []*AnonymizeRule
+	for i, rule := range a.rules {
+		if qualifiedTable(rule.Table) != table {
+			continue
+		}
+		idx := slices.Index(names, rule.Column)
+		if idx < 0 {
+			continue
+		}
+		if columns == nil {
+			columns = make([]*AnonymizeRule, len(names))
+		}
+		columns[idx] = &a.rules[i]
+		a.matched[i] = true
+	}
+	return columns
+}
+
+// unmatched returns the rules that did not match any column in the dump, which usually means they have a typo.
+func (a *anonymizer) unmatched() []AnonymizeRule {
+	var ret []AnonymizeRule
+	for i, r := range a.rules {
+		if !a.matched[i] {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// parseCopyStatement parses a statement such as `COPY public.users (id, email) FROM stdin;` into the qualified table
+// name and the column names, with identifier quotes removed.
+func parseCopyStatement(line string) (table string, columns []string, err error) {
+	stmt := strings.TrimSuffix(strings.TrimPrefix(line, "COPY "), " FROM stdin;\n")
+	name, cols, found := strings.Cut(stmt, " (")
+	if !found || !strings.HasSuffix(cols, ")") {
+		return "", nil, fmt.Errorf("unexpected COPY statement: %q", line)
+	}
+
+	for col := range strings.SplitSeq(strings.TrimSuffix(cols, ")"), ", ") {
+		columns = append(columns, unquoteIdentifier(col))
+	}
+
+	return qualifiedTable(name), columns, nil
+}
+
+// qualifiedTable returns the table name qualified with its schema and without identifier quotes.
+func qualifiedTable(name string) string {
+	schema, table, found := strings.Cut(name, ".")
+	if !found || (strings.HasPrefix(name, `"`) && strings.Count(schema, `"`) == 1) {
+		// Either an unqualified name, or a quoted name containing a dot.
+		schema, table = "public", name
+	}
+	return unquoteIdentifier(schema) + "." + unquoteIdentifier(table)
+}
+
+func unquoteIdentifier(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
+
+// anonymizeRow replaces the values of a row in COPY text format, where columns are separated by tabs and NULL is
+// written as \N.
+func (a *anonymizer) anonymizeRow(line string, columns []*AnonymizeRule) string {
+	fields := strings.Split(strings.TrimSuffix(line, "\n"), "\t")
+	for i, field := range fields {
+		if i >= len(columns) || columns[i] == nil {
+			continue
+		}
+		fields[i] = a.anonymizeValue(field, columns[i])
+	}
+	return strings.Join(fields, "\t") + "\n"
+}
+
+// anonymizeValue replaces a single value according to a rule. Hashes are HMACs with the key of the anonymizer, so that
+// low-entropy values such as phone numbers cannot be recovered by hashing every candidate.
+func (a *anonymizer) anonymizeValue(field string, rule *AnonymizeRule) string {
+	const null = `\N`
+
+	if rule.Strategy == AnonymizeNull {
+		return null
+	}
+	if rule.Strategy == AnonymizeValue {
+		return escapeCopyValue(rule.Value)
+	}
+	if field == null {
+		return null
+	}
+
+	mac := hmac.New(sha256.New, a.key)
+	_, _ = mac.Write([]byte(field))
+	hash := hex.EncodeToString(mac.Sum(nil)[:8])
+	switch rule.Strategy {
+	case AnonymizeHash:
+		return hash
+	case AnonymizeEmail:
+		return hash + "@example.invalid"
+	default:
+		return "REDACTED"
+	}
+}
+
+// escapeCopyValue escapes a value for COPY text format.
+func escapeCopyValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDump = `--
+-- PostgreSQL database dump
+--
+
+COPY public.users (id, email, "Name", phone) FROM stdin;
+1	alice@example.com	Alice	12345678
+2	bob@example.com	Bob	\N
+\.
+
+COPY public.orders (id, user_email, comment) FROM stdin;
+1	alice@example.com	fragile
+\.
+
+COPY public.audit (id, message) FROM stdin;
+1	user logged in
+\.
+`
+
+func TestAnonymizer(t *testing.T) {
+	rules := &AnonymizeRules{Rules: []AnonymizeRule{
+		{Table: "users", Column: "email", Strategy: AnonymizeHash},
+		{Table: "public.users", Column: "Name", Strategy: AnonymizeRedact},
+		{Table: "users", Column: "phone", Strategy: AnonymizeNull},
+		{Table: "orders", Column: "user_email", Strategy: AnonymizeHash},
+		{Table: "orders", Column: "comment", Strategy: AnonymizeValue, Value: "a\tb"},
+		{Table: "orders", Column: "missing", Strategy: AnonymizeNull},
+	}}
+
+	a := newAnonymizer(rules)
+	out := &strings.Builder{}
+	if err := a.copyTo(out, strings.NewReader(testDump)); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := a.anonymizeValue("alice@example.com", &AnonymizeRule{Strategy: AnonymizeHash})
+	for _, want := range []string{
+		"1\t" + hash + "\tREDACTED\t\\N\n",
+		"1\t" + hash + "\ta\\tb\n",
+		"1\tuser logged in\n",
+		"COPY public.users (id, email, \"Name\", phone) FROM stdin;\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected dump to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "alice@example.com") {
+		t.Errorf("expected email to be anonymized, got:\n%s", out.String())
+	}
+
+	unmatched := a.unmatched()
+	if len(unmatched) != 1 || unmatched[0].Column != "missing" {
+		t.Errorf("expected the rule for the missing column to be unmatched, got %v", unmatched)
+	}
+}
+
+func TestAnonymizerKey(t *testing.T) {
+	rule := &AnonymizeRule{Strategy: AnonymizeHash}
+	hash := func(a *anonymizer) string {
+		return a.anonymizeValue("12345678", rule)
+	}
+
+	random := newAnonymizer(&AnonymizeRules{})
+	if hash(random) != hash(random) {
+		t.Error("expected equal values to get equal hashes within a dump")
+	}
+	if hash(random) == hash(newAnonymizer(&AnonymizeRules{})) {
+		t.Error("expected dumps without a key to use different random keys")
+	}
+
+	keyed := &AnonymizeRules{Key: "secret"}
+	if hash(newAnonymizer(keyed)) != hash(newAnonymizer(keyed)) {
+		t.Error("expected dumps with the same key to get equal hashes")
+	}
+}
+
+func TestQualifiedTable(t *testing.T) {
+	tests := map[string]string{
+		"users":             "public.users",
+		"app.users":         "app.users",
+		`"App"."Users"`:     "App.Users",
+		`"my.table"`:        "public.my.table",
+		`public."my.table"`: "public.my.table",
+	}
+
+	for input, want := range tests {
+		if got := qualifiedTable(input); got != want {
+			t.Errorf("qualifiedTable(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestLoadAnonymizeRules(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("rules:\n  - table: users\n    column: email\n    strategy: email\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadAnonymizeRules(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Rules) != 1 || rules.Rules[0].Strategy != AnonymizeEmail {
+		t.Errorf("unexpected rules: %+v", rules)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("rules:\n  - table: users\n    column: email\n    strategy: scramble\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnonymizeRules(invalid); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	logs "github.com/nais/cli/internal/log/command"
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+)
+
+// auditLogMarker prefixes every statement logged by pgaudit.
+const auditLogMarker = "AUDIT: "
+
+// TailAuditLog streams the pgaudit entries of the Cloud SQL instance of an application through the log subscription of
+// the team. Audit logging must first be enabled with `nais postgres enable-audit`.
+func TailAuditLog(ctx context.Context, appName, team, environment string, fl *flag.AuditLog, out *naistrix.OutputWriter) error {
+	query := fl.RawQuery
+	if query == "" {
+		dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+		if err != nil {
+			return err
+		}
+
+		cloudSQL, err := dbInfo.ToCloudSQLDBInfo()
+		if err != nil {
+			return fmt.Errorf("audit logs are only available for Cloud SQL databases: %w", err)
+		}
+
+		connectionName, err := cloudSQL.ConnectionName(ctx)
+		if err != nil {
+			return err
+		}
+
+		databaseID, err := databaseID(connectionName)
+		if err != nil {
+			return err
+		}
+
+		query = auditLogQuery(team, databaseID)
+	}
+
+	out.Verbosef("Using query: %s\n", query)
+	if err := naisapi.TailLog(ctx, out, environment, fl.Limit, fl.Since, fl.WithTimestamps, fl.WithLabels, query); err != nil {
+		return fmt.Errorf("unable to tail audit logs: %w", err)
+	}
+	return nil
+}
+
+// databaseID returns the ID Cloud Logging uses for an instance, PROJECT:INSTANCE, from its connection name,
+// PROJECT:REGION:INSTANCE.
+func databaseID(connectionName string) (string, error) {
+	parts := strings.Split(connectionName, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", fmt.Errorf("invalid connection name %q, expected PROJECT:REGION:INSTANCE", connectionName)
+	}
+	return parts[0] + ":" + parts[2], nil
+}
+
+// auditLogQuery returns a LogQL query for the pgaudit entries of a Cloud SQL instance in the logs of the team. The
+// entries carry the ID of the instance, which is matched with a line filter.
+func auditLogQuery(team, databaseID string) string {
+	selectors := logs.NewQueryBuilder().AddTeams(team).Build()
+	return fmt.Sprintf("%s |= %q |= %q", selectors, databaseID, auditLogMarker)
+}
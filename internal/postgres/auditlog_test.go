@@ -0,0 +1,26 @@
+package postgres
+
+import "testing"
+
+func TestDatabaseID(t *testing.T) {
+	got, err := databaseID("my-project:europe-north1:my-instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "my-project:my-instance" {
+		t.Errorf("databaseID() = %q, want %q", got, "my-project:my-instance")
+	}
+
+	for _, invalid := range []string{"", "my-instance", "my-project::", "a:b:c:d"} {
+		if _, err := databaseID(invalid); err == nil {
+			t.Errorf("databaseID(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestAuditLogQuery(t *testing.T) {
+	want := `{service_name!="",service_namespace=~"my-team"} |= "my-project:my-instance" |= "AUDIT: "`
+	if got := auditLogQuery("my-team", "my-project:my-instance"); got != want {
+		t.Errorf("auditLogQuery() = %q, want %q", got, want)
+	}
+}
@@ -215,7 +215,7 @@ func (d *CloudSQLDBInfo) RunProxy(ctx context.Context, host string, port *uint,
 		out.Println("If you get asked for a password, you can leave it blank. If that doesn't work, try running 'nais postgres grant", d.AppName()+"' again.")
 	}
 
-	err = runProxy(ctx, projectID, connectionName, address, portCh, out)
+	err = runProxy(ctx, projectID, connectionName, address, portCh, &proxyStats{}, newGrantKeeper(out), out)
 	if err != nil {
 		if errors.Is(err, context.Canceled) {
 			return nil
@@ -227,7 +227,7 @@ func (d *CloudSQLDBInfo) RunProxy(ctx context.Context, host string, port *uint,
 	return nil
 }
 
-func runProxy(ctx context.Context, projectID, connectionName, address string, port chan<- int, out *naistrix.OutputWriter) error {
+func runProxy(ctx context.Context, projectID, connectionName, address string, port chan<- int, stats *proxyStats, grants *grantKeeper, out *naistrix.OutputWriter) error {
 	err := checkPostgresqlPassword(out)
 	if err != nil {
 		return err
@@ -237,7 +237,8 @@ func runProxy(ctx context.Context, projectID, connectionName, address string, po
 	logging.Infof = func(format string, v ...any) { out.Infof(format, v...) }
 	logging.Errorf = func(format string, v ...any) { out.Errorf(format, v...) }
 
-	if err := grantUserAccess(ctx, projectID, "roles/cloudsql.instanceUser", 1*time.Hour, out); err != nil {
+	// The grant is renewed while the proxy is running, so that long-lived sessions keep working after the first hour.
+	if err := grants.keep(ctx, projectID); err != nil {
 		return err
 	}
 
@@ -285,7 +286,10 @@ func runProxy(ctx context.Context, projectID, connectionName, address string, po
 		}
 
 		out.Infof("New connection %s\n", conn.RemoteAddr())
+		stats.totalClients.Add(1)
+		stats.activeClients.Add(1)
 		wg.Go(func() {
+			defer stats.activeClients.Add(-1)
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
 
@@ -304,8 +308,8 @@ func runProxy(ctx context.Context, projectID, connectionName, address string, po
 			defer func() { _ = conn2.Close() }()
 
 			closer := make(chan struct{}, 2)
-			go copy(closer, conn2, conn)
-			go copy(closer, conn, conn2)
+			go copy(closer, conn2, &countingReader{r: conn, count: &stats.bytesIn})
+			go copy(closer, conn, &countingReader{r: conn2, count: &stats.bytesOut})
 			<-closer
 			out.Infof("Connection complete %s\n", conn.RemoteAddr())
 		})
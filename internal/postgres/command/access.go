@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func accessCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Access{
+		Postgres: parentFlags,
+	}
+	return &naistrix.Command{
+		Name:        "access",
+		Title:       "Manage time-boxed access to SQL instances.",
+		StickyFlags: flags,
+		SubCommands: []*naistrix.Command{
+			accessListCommand(flags),
+		},
+	}
+}
+
+func accessListCommand(parentFlags *flag.Access) *naistrix.Command {
+	flags := &flag.AccessList{
+		Access: parentFlags,
+		Output: "table",
+	}
+	return &naistrix.Command{
+		Name:  "list",
+		Title: "List time-boxed access grants.",
+		Description: heredoc.Doc(`
+			List the access granted with "nais postgres prepare --for" on the SQL instances of the team, with the time remaining until it expires.
+
+			Expired access is revoked by the next postgres command that runs for the team and environment.
+		`),
+		Flags:        flags,
+		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
+		RunFunc: func(ctx context.Context, _ *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.ListAccessGrants(ctx, flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func auditLogCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.AuditLog{
+		Postgres: parentFlags,
+		Since:    time.Hour,
+		Limit:    100,
+	}
+	return &naistrix.Command{
+		Name:  "audit-log",
+		Title: "Stream audit log entries from the database.",
+		Description: heredoc.Doc(`
+			Stream the pgaudit entries of the Cloud SQL instance of an application, through the log subscription of the team.
+
+			Audit logging must be enabled first, see "nais postgres enable-audit" and "nais postgres verify-audit".
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags:        flags,
+		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Stream audit log entries for my-app.",
+				Command:     "my-app",
+			},
+			{
+				Description: "Start with the entries from the last 24 hours, with timestamps.",
+				Command:     "my-app --since 24h --limit 1000 --with-timestamps",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.TailAuditLog(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func dumpCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Dump{Postgres: parentFlags}
+	return &naistrix.Command{
+		Name:  "dump",
+		Title: "Dump the database to a file.",
+		Description: heredoc.Doc(`
+			Take a logical backup of the database of an application, using pg_dump through a proxy on a random port. The dump is written in the plain SQL format of pg_dump, without owners and privileges, so that it can be restored in another environment with "nais postgres restore".
+
+			Columns can be anonymized while dumping with --anonymize, which takes a YAML file with rules such as:
+
+			  rules:
+			    - table: users
+			      column: email
+			      strategy: email
+			    - table: users
+			      column: phone
+			      strategy: "null"
+			    - table: public.orders
+			      column: comment
+			      strategy: value
+			      value: "anonymized"
+
+			Available strategies are "null", "hash" (equal values get equal hashes, so joins still work), "email", "redact" and "value". Tables without a schema are in the public schema.
+
+			Values are hashed with a random key for each dump, so hashes only match within a dump. Set "key" in the rules file to get the same hashes in several dumps, and keep the file secret, as the original values can be recovered by anyone with the key.
+
+			Requires pg_dump with a version equal to or newer than the database.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.Out == "" {
+					return fmt.Errorf("--out is required")
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Dump the database of my-app.",
+				Command:     "my-app --out my-app.sql --reason 'Reproducing bug 123'",
+			},
+			{
+				Description: "Dump a single table, with anonymized columns.",
+				Command:     "my-app --out users.sql --table users --anonymize anonymize.yaml --reason 'Reproducing bug 123'",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.RunDump(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
@@ -2,6 +2,9 @@ package flag
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/nais/cli/internal/flags"
 	"github.com/nais/cli/internal/labels"
@@ -40,6 +43,38 @@ type MigrateRollback struct {
 	*Migrate
 }
 
+type MigrateStatus struct {
+	*Migrate
+}
+
+type MigrateCheck struct {
+	*Migrate
+	TargetTier           string `name:"target-tier" usage:"The |TIER| of the new instance. Defaults to the tier of the current instance."`
+	TargetType           string `name:"target-type" usage:"The |TYPE| of the new instance, e.g. POSTGRES_17. Defaults to the type of the current instance."`
+	TargetDiskSize       int    `name:"target-disk-size" usage:"The |DISK_SIZE| of the new instance in GB."`
+	TargetDiskAutoResize bool   `name:"target-disk-auto-resize" usage:"Enable automatic disk resizing for the new instance."`
+}
+
+type Dump struct {
+	*Postgres
+	Out       string   `name:"out" short:"o" usage:"|FILE| to write the dump to."`
+	Schema    []string `name:"schema" usage:"Only dump the |SCHEMA|. Can be repeated."`
+	Table     []string `name:"table" usage:"Only dump the |TABLE|. Can be repeated."`
+	Anonymize string   `name:"anonymize" usage:"YAML |FILE| with rules for anonymizing columns in the dump."`
+}
+
+type Restore struct {
+	*Postgres
+	From string `name:"from" short:"f" usage:"|FILE| with a dump to restore."`
+	Yes  bool   `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type Inspect struct {
+	*Postgres
+	Output    Output        `name:"output" short:"o" usage:"Format output (table or json)."`
+	LongQuery time.Duration `name:"long-query" usage:"Report queries that have been running for longer than |DURATION|."`
+}
+
 type Password struct {
 	*Postgres
 }
@@ -79,20 +114,77 @@ type Grant struct {
 
 type Prepare struct {
 	*Postgres
-	AllPrivileges bool   `name:"all-privileges" usage:"Grant all privileges on the schema to the current user."`
-	Schema        string `name:"schema" usage:"Schema to grant access to."`
+	AllPrivileges bool          `name:"all-privileges" usage:"Grant all privileges on the schema to the current user."`
+	Schema        string        `name:"schema" usage:"Schema to grant access to."`
+	For           time.Duration `name:"for" usage:"Only grant access for |DURATION|, e.g. 2h. Expired access is revoked by the next postgres command."`
+}
+
+func (p *Prepare) Validate() error {
+	if p.For < 0 {
+		return fmt.Errorf("--for must be a positive duration")
+	}
+	return nil
+}
+
+type Access struct {
+	*Postgres
+}
+
+type AccessList struct {
+	*Access
+	Output Output `name:"output" short:"o" usage:"Format output (table or json)."`
 }
 
 type Proxy struct {
 	*Postgres
-	Port uint   `name:"port" short:"p" usage:"Port to use for the proxy. Defaults to 5432."`
-	Host string `name:"host" short:"H" usage:"Host to proxy to. Defaults to localhost."`
+	Port       uint   `name:"port" short:"p" usage:"Port to use for the proxy. Defaults to 5432."`
+	Host       string `name:"host" short:"H" usage:"Host to proxy to. Defaults to localhost."`
+	Multi      bool   `name:"multi" usage:"Proxy several databases, given as |[ENVIRONMENT/]APP_NAME|, on consecutive ports starting at --port."`
+	StatusPort uint   `name:"status-port" usage:"|PORT| for the local status endpoint when using --multi. Defaults to the port after the last proxy."`
 }
 
 type Psql struct {
 	*Postgres
 }
 
+type Query struct {
+	*Postgres
+	Command string        `name:"command" short:"c" usage:"SQL |STATEMENTS| to run."`
+	File    string        `name:"file" short:"f" usage:"|FILE| with SQL statements to run."`
+	Output  QueryOutput   `name:"output" short:"o" usage:"Format output (table, csv or json)."`
+	Write   bool          `name:"write" usage:"Allow statements that change data. Statements run in a read-only transaction by default."`
+	Timeout time.Duration `name:"timeout" usage:"Statement |TIMEOUT|. Examples: 30s, 5m. Use 0 to disable the timeout."`
+}
+
+func (q *Query) Validate() error {
+	if q.Command != "" && q.File != "" {
+		return fmt.Errorf("--command and --file cannot be used together")
+	}
+	if !slices.Contains(AllQueryOutputs, q.Output) {
+		return fmt.Errorf("invalid output %q, must be one of: %v", q.Output, AllQueryOutputs)
+	}
+	if q.Timeout < 0 {
+		return fmt.Errorf("--timeout must be a positive duration")
+	}
+	return nil
+}
+
+type QueryOutput string
+
+const (
+	QueryOutputTable QueryOutput = "table"
+	QueryOutputCSV   QueryOutput = "csv"
+	QueryOutputJSON  QueryOutput = "json"
+)
+
+var AllQueryOutputs = []QueryOutput{QueryOutputTable, QueryOutputCSV, QueryOutputJSON}
+
+var _ naistrix.FlagAutoCompleter = (*QueryOutput)(nil)
+
+func (o *QueryOutput) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"table", "csv", "json"}, "Available output formats."
+}
+
 type Revoke struct {
 	*Postgres
 	Schema string `name:"schema" usage:"The schema to revoke privileges from."`
@@ -113,3 +205,50 @@ var _ naistrix.FlagAutoCompleter = (*Output)(nil)
 func (o *Output) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
 	return []string{"table", "json"}, "Available output formats."
 }
+
+type AuditLog struct {
+	*Postgres
+	WithTimestamps bool          `name:"with-timestamps" usage:"Include timestamps in log output."`
+	WithLabels     bool          `name:"with-labels" usage:"Include labels in log output."`
+	RawQuery       string        `name:"raw-query" usage:"Provide a raw query to filter logs. See https://grafana.com/docs/loki/latest/logql/ for syntax."`
+	Since          time.Duration `name:"since" short:"s" usage:"How far back in time to start the initial batch. Examples: 300s, 1h, 2h45m. Defaults to 1h."`
+	Limit          int           `name:"limit" short:"l" usage:"Maximum number of initial log lines."`
+}
+
+type Top struct {
+	*Postgres
+	Output   Output        `name:"output" short:"o" usage:"Format output (table or json). JSON output is printed once."`
+	Sort     TopSort       `name:"sort" usage:"Sort statements by |COLUMN| (total-time, calls or rows)."`
+	Limit    int           `name:"limit" short:"n" usage:"Number of statements to show."`
+	Interval time.Duration `name:"interval" usage:"How often to refresh. Examples: 2s, 1m."`
+	Once     bool          `name:"once" usage:"Print the statements once and exit instead of refreshing."`
+}
+
+func (t *Top) Validate() error {
+	if !slices.Contains(AllTopSorts, t.Sort) {
+		return fmt.Errorf("invalid sort %q, must be one of: %v", t.Sort, AllTopSorts)
+	}
+	if t.Limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if t.Interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	return nil
+}
+
+type TopSort string
+
+const (
+	TopSortTotalTime TopSort = "total-time"
+	TopSortCalls     TopSort = "calls"
+	TopSortRows      TopSort = "rows"
+)
+
+var AllTopSorts = []TopSort{TopSortTotalTime, TopSortCalls, TopSortRows}
+
+var _ naistrix.FlagAutoCompleter = (*TopSort)(nil)
+
+func (s *TopSort) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"total-time", "calls", "rows"}, "Available sort columns."
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func inspectCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Inspect{
+		Postgres:  parentFlags,
+		Output:    "table",
+		LongQuery: time.Minute,
+	}
+	return &naistrix.Command{
+		Name:  "inspect",
+		Title: "Inspect schemas, privileges and activity in the database.",
+		Description: heredoc.Doc(`
+			Inspect the database of an application, connecting with the application credentials.
+
+			Reports schemas, the largest tables, estimated index bloat, roles and their effective privileges per schema, active connections, long-running queries and replication lag.
+
+			The report ends with the access that IAM users currently have through the cloudsqliamuser role, so that you can check what you already have before running "nais postgres prepare".
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags:        flags,
+		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Inspect the database of my-app.",
+				Command:     "my-app",
+			},
+			{
+				Description: "Report queries running for more than 10 seconds, as JSON.",
+				Command:     "my-app --long-query 10s -o json",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.Inspect(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
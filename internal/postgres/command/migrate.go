@@ -7,11 +7,14 @@ import (
 	"strconv"
 	"strings"
 
+	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
 	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/postgres/migrate/check"
 	"github.com/nais/cli/internal/postgres/migrate/finalize"
 	"github.com/nais/cli/internal/postgres/migrate/promote"
 	"github.com/nais/cli/internal/postgres/migrate/rollback"
 	"github.com/nais/cli/internal/postgres/migrate/setup"
+	"github.com/nais/cli/internal/postgres/migrate/status"
 	"github.com/nais/cli/internal/validation"
 	"github.com/nais/naistrix"
 )
@@ -21,14 +24,49 @@ func migrateCommand(parentFlags *flag.Postgres) *naistrix.Command {
 	return &naistrix.Command{
 		Name:         "migrate",
 		Title:        "Migrate to a new SQL instance.",
-		Description:  "Commands for migrating a Postgres database to a new Cloud SQL instance, including setup, promotion, finalization, and rollback.",
+		Description:  "Commands for migrating a Postgres database to a new Cloud SQL instance, including setup, promotion, finalization, rollback, and the status of a migration in progress.",
 		StickyFlags:  flags,
 		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
 		SubCommands: []*naistrix.Command{
+			migrateCheckCommand(flags),
 			migrateSetupCommand(flags),
 			migratePromoteCommand(flags),
 			migrateFinalizeCommand(flags),
 			migrateRollbackCommand(flags),
+			migrateStatusCommand(flags),
+		},
+	}
+}
+
+func migrateCheckCommand(parentFlags *flag.Migrate) *naistrix.Command {
+	flags := &flag.MigrateCheck{Migrate: parentFlags}
+	return &naistrix.Command{
+		Name:        "check",
+		Title:       "Check that a migration can be set up.",
+		Description: "Check connects to the database of the application and reports blockers and warnings for migrating to a new instance with the given configuration, such as tables without primary keys, insufficient disk, unavailable extensions and major version changes. Nothing is created.",
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		ValidateFunc: func(context.Context, *naistrix.Arguments) error {
+			if flags.TargetTier != "" && !strings.HasPrefix(flags.TargetTier, "db-") {
+				return fmt.Errorf("tier must start with `db-`")
+			}
+
+			if flags.TargetType != "" && !strings.HasPrefix(strings.ToUpper(flags.TargetType), "POSTGRES_") {
+				return fmt.Errorf("instance type must start with `POSTGRES_`")
+			}
+
+			return nil
+		},
+		Flags: flags,
+		Examples: []naistrix.Example{
+			{
+				Description: "Check migrating my-app to a new instance running Postgres 17 on a larger tier.",
+				Command:     "my-app --target-tier db-custom-2-7680 --target-type POSTGRES_17",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return check.Run(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
 		},
 	}
 }
@@ -50,7 +88,7 @@ func migrateSetupCommand(parentFlags *flag.Migrate) *naistrix.Command {
 	return &naistrix.Command{
 		Name:        "setup",
 		Title:       "Make necessary setup for a new SQL instance migration.",
-		Description: "Setup will create a new (target) instance with updated configuration, and enable continuous replication of data from the source instance.",
+		Description: "Setup will create a new (target) instance with updated configuration, and enable continuous replication of data from the source instance. Run check first to find problems before anything is created.",
 		Args: []naistrix.Argument{
 			{Name: "app_name"},
 			{Name: "target_sql_instance_name"},
@@ -123,3 +161,19 @@ func migrateRollbackCommand(parentFlags *flag.Migrate) *naistrix.Command {
 		},
 	}
 }
+
+func migrateStatusCommand(parentFlags *flag.Migrate) *naistrix.Command {
+	flags := &flag.MigrateStatus{Migrate: parentFlags}
+	return &naistrix.Command{
+		Name:        "status",
+		Title:       "Show the status of a migration.",
+		Description: "Status shows the phase of the migration in progress for an application, the progress of each step, and which steps can be run next. Steps run out of order are refused.",
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags: flags,
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return status.Run(ctx, args.Get("app_name"), flags.Team, string(flags.Environment))
+		},
+	}
+}
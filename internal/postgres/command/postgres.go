@@ -5,6 +5,7 @@ import (
 
 	"github.com/nais/cli/internal/flags"
 	"github.com/nais/cli/internal/gcloud"
+	"github.com/nais/cli/internal/postgres"
 	"github.com/nais/cli/internal/postgres/command/flag"
 	"github.com/nais/naistrix"
 )
@@ -20,22 +21,51 @@ func Postgres(parentFlags *flags.GlobalFlags) *naistrix.Command {
 		Description: "Commands for managing Google Cloud SQL Postgres instances, including listing, migration, user management, password rotation, and direct database access.",
 		Aliases:     []string{"pg"},
 		StickyFlags: flags,
-		SubCommands: []*naistrix.Command{
+		SubCommands: sweepExpiredAccess(flags, []*naistrix.Command{
+			accessCommand(flags),
 			listCommand(flags),
 			migrateCommand(flags),
 			passwordCommand(flags),
 			usersCommand(flags),
+			dumpCommand(flags),
+			restoreCommand(flags),
 			enableAuditCommand(flags),
+			auditLogCommand(flags),
 			verifyAuditCommand(flags),
 			grantCommand(flags),
+			inspectCommand(flags),
 			prepareCommand(flags),
 			proxyCommand(flags),
 			psqlCommand(flags),
+			queryCommand(flags),
 			revokeCommand(flags),
-		},
+			topCommand(flags),
+		}),
 		ValidateFunc: func(ctx context.Context, _ *naistrix.Arguments) error {
 			_, err := gcloud.ValidateAndGetUserLogin(ctx, false)
 			return err
 		},
 	}
 }
+
+// sweepExpiredAccess makes the commands revoke expired time-boxed access grants before they run. A failed sweep is
+// reported as a warning, and does not stop the command.
+func sweepExpiredAccess(fl *flag.Postgres, commands []*naistrix.Command) []*naistrix.Command {
+	for _, cmd := range commands {
+		cmd.SubCommands = sweepExpiredAccess(fl, cmd.SubCommands)
+		if cmd.RunFunc == nil {
+			continue
+		}
+
+		run := cmd.RunFunc
+		cmd.RunFunc = func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			if fl.Team != "" && fl.Environment != "" {
+				if err := postgres.SweepExpiredAccess(ctx, fl.Team, string(fl.Environment), fl, out); err != nil {
+					out.Warnf("Unable to revoke expired database access: %v\n", err)
+				}
+			}
+			return run(ctx, args, out)
+		}
+	}
+	return commands
+}
@@ -28,12 +28,29 @@ func prepareCommand(parentFlags *flag.Postgres) *naistrix.Command {
 			All IAM users in your GCP project will be able to connect to the instance.
 
 			This operation is only required to run once for each SQL instance.
+
+			Use --for to grant access for a limited time only. The expiry is recorded on the SQL instance, and expired access is revoked by the next postgres command that runs for the team and environment. See "nais postgres access list".
 		`),
 		Args: []naistrix.Argument{
 			{Name: "app_name"},
 		},
-		Flags:        flags,
-		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Give IAM users read access to the public schema.",
+				Command:     "my-app",
+			},
+			{
+				Description: "Give IAM users all privileges on the public schema for two hours.",
+				Command:     "my-app --all-privileges --for 2h",
+			},
+		},
 		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
 			if result, err := input.Confirm("Are you sure you want to continue?"); err != nil {
 				return err
@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/nais/cli/internal/postgres"
 	"github.com/nais/cli/internal/postgres/command/flag"
 	"github.com/nais/cli/internal/validation"
@@ -16,16 +18,66 @@ func proxyCommand(parentFlags *flag.Postgres) *naistrix.Command {
 		Host:     "localhost",
 	}
 	return &naistrix.Command{
-		Name:        "proxy",
-		Title:       "Create a proxy to a SQL instance.",
-		Description: "Allows your user to connect to databases and starts a proxy.",
+		Name:  "proxy",
+		Title: "Create a proxy to a SQL instance.",
+		Description: heredoc.Doc(`
+			Allows your user to connect to databases and starts a proxy.
+
+			With "--multi", several databases are proxied in one process on consecutive ports. Databases are given as [ENVIRONMENT/]APP_NAME, so that databases in different environments can be proxied together. A local HTTP endpoint reports connected clients, transferred bytes and when the database access grant expires.
+
+			Temporary database access is renewed before it expires for as long as the proxy is running.
+		`),
 		Args: []naistrix.Argument{
-			{Name: "app_name"},
+			{Name: "app_name", Repeatable: true},
+		},
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeam(flags),
+			func(ctx context.Context, args *naistrix.Arguments) error {
+				apps := args.GetRepeatable("app_name")
+				if !flags.Multi {
+					if len(apps) != 1 {
+						return fmt.Errorf("exactly one application must be specified, use --multi to proxy several databases")
+					}
+					return validation.RequireEnvironment(flags)(ctx, args)
+				}
+
+				if len(apps) == 0 {
+					return fmt.Errorf("at least one application must be specified")
+				}
+				for _, app := range apps {
+					if _, err := postgres.ParseProxyTarget(app, string(flags.Environment)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Proxy the database of my-app on localhost:5432.",
+				Command:     "my-app --environment dev --reason 'Investigating incident 123'",
+			},
+			{
+				Description: "Proxy the databases of my-app in dev and prod on ports 5432 and 5433, with status on port 5434.",
+				Command:     "--multi dev/my-app prod/my-app --reason 'Comparing data between environments'",
+			},
 		},
-		Flags:        flags,
-		ValidateFunc: validation.RequireTeamAndEnvironment(flags),
 		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
-			return postgres.RunProxy(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+			if !flags.Multi {
+				return postgres.RunProxy(ctx, args.GetRepeatable("app_name")[0], flags.Team, string(flags.Environment), flags, out)
+			}
+
+			var targets []postgres.ProxyTarget
+			for _, app := range args.GetRepeatable("app_name") {
+				target, err := postgres.ParseProxyTarget(app, string(flags.Environment))
+				if err != nil {
+					return err
+				}
+				targets = append(targets, target)
+			}
+
+			return postgres.RunMultiProxy(ctx, flags.Team, targets, flags, out)
 		},
 	}
 }
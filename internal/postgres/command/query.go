@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func queryCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Query{
+		Postgres: parentFlags,
+		Output:   flag.QueryOutputTable,
+		Timeout:  30 * time.Second,
+	}
+	return &naistrix.Command{
+		Name:  "query",
+		Title: "Run SQL statements against the database.",
+		Description: heredoc.Doc(`
+			Run SQL statements against the database of an application, without needing psql or a proxy.
+
+			Statements are given with --command, read from a file with --file, or read from stdin. If none of these are given and stdin is a terminal, an interactive prompt is started. History from the prompt is kept between sessions.
+
+			Statements run in a read-only transaction unless --write is given, in which case the transaction is committed when all statements succeed. In read-only mode, statements that end the transaction or change its read-only setting, such as COMMIT, are refused.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Count the rows in a table.",
+				Command:     "my-app -c 'select count(*) from users'",
+			},
+			{
+				Description: "Run statements from a file and output the result as CSV.",
+				Command:     "my-app -f report.sql -o csv",
+			},
+			{
+				Description: "Change data, with a longer statement timeout.",
+				Command:     "my-app --write --timeout 5m -c 'delete from events where created < now() - interval ''1 year'''",
+			},
+			{
+				Description: "Start an interactive prompt.",
+				Command:     "my-app",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.RunQuery(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
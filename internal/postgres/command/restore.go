@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func restoreCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Restore{Postgres: parentFlags}
+	return &naistrix.Command{
+		Name:  "restore",
+		Title: "Restore a dump into the database.",
+		Description: heredoc.Doc(`
+			Restore a dump made with "nais postgres dump" into the database of an application, using psql through a proxy on a random port.
+
+			The dump is restored in a single transaction. If any statement fails, nothing is changed. Objects in the dump that already exist in the database cause the restore to fail, so restore into an empty database or drop the objects first.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.From == "" {
+					return fmt.Errorf("--from is required")
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Restore a dump into the database of my-app in dev.",
+				Command:     "my-app --environment dev --from my-app.sql --reason 'Reproducing bug 123'",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			if !flags.Yes {
+				msg := fmt.Sprintf("Restore %s into the database of %s in %s?", flags.From, args.Get("app_name"), flags.Environment)
+				if result, err := input.Confirm(msg); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			return postgres.RunRestore(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func topCommand(parentFlags *flag.Postgres) *naistrix.Command {
+	flags := &flag.Top{
+		Postgres: parentFlags,
+		Output:   "table",
+		Sort:     flag.TopSortTotalTime,
+		Limit:    10,
+		Interval: 5 * time.Second,
+	}
+	return &naistrix.Command{
+		Name:  "top",
+		Title: "Show the most expensive statements in the database.",
+		Description: heredoc.Doc(`
+			Show the statements with the highest total execution time, number of calls or number of rows in the database of an application, from pg_stat_statements. The list is refreshed until interrupted.
+
+			The pg_stat_statements extension must be installed in the database.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "app_name"},
+		},
+		Flags: flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireTeamAndEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				return flags.Validate()
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Show the 10 statements with the highest total execution time, refreshed every 5 seconds.",
+				Command:     "my-app",
+			},
+			{
+				Description: "Show the 20 most called statements once.",
+				Command:     "my-app --sort calls --limit 20 --once",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			return postgres.Top(ctx, args.Get("app_name"), flags.Team, string(flags.Environment), flags, out)
+		},
+	}
+}
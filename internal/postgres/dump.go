@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+)
+
+// RunDump writes a logical backup of the database of an application to a file, in the plain SQL format of pg_dump.
+// Columns matching the anonymization rules, if any, are replaced while the dump is streamed to the file.
+func RunDump(ctx context.Context, appName, team, environment string, fl *flag.Dump, out *naistrix.OutputWriter) error {
+	var anon *anonymizer
+	if fl.Anonymize != "" {
+		rules, err := LoadAnonymizeRules(fl.Anonymize)
+		if err != nil {
+			return err
+		}
+		anon = newAnonymizer(rules)
+	}
+
+	pgDumpPath, err := exec.LookPath("pg_dump")
+	if err != nil {
+		return fmt.Errorf("pg_dump is required to dump the database: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	port, connectionInfo, err := StartDatabaseProxy(ctx, appName, team, environment, fl.Postgres, out)
+	if err != nil {
+		return err
+	}
+
+	// Owners and privileges are left out, as roles differ between environments and the dump is restored as the user
+	// running the restore.
+	arguments := []string{
+		"--host", "localhost",
+		"--port", strconv.Itoa(port),
+		"--username", connectionInfo.email,
+		"--dbname", connectionInfo.dbName,
+		"--format", "plain",
+		"--no-owner",
+		"--no-privileges",
+	}
+	for _, schema := range fl.Schema {
+		arguments = append(arguments, "--schema", schema)
+	}
+	for _, table := range fl.Table {
+		arguments = append(arguments, "--table", table)
+	}
+
+	// The dump holds the data of the database, so it is only readable by the current user.
+	f, err := os.OpenFile(fl.Out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, pgDumpPath, arguments...)
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+connectionInfo.password)
+
+	out.Printf("Dumping %s to %s\n", connectionInfo.dbName, fl.Out)
+	if anon == nil {
+		cmd.Stdout = f
+		err = cmd.Run()
+	} else {
+		err = runAnonymized(cmd, f, anon)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(fl.Out)
+		return fmt.Errorf("dumping database: %w", err)
+	}
+
+	if anon != nil {
+		for _, r := range anon.unmatched() {
+			out.Warnf("Anonymization rule for %s did not match any column in the dump.\n", r)
+		}
+	}
+
+	out.Successf("Database %s dumped to %s\n", connectionInfo.dbName, fl.Out)
+	return nil
+}
+
+func runAnonymized(cmd *exec.Cmd, w io.Writer, anon *anonymizer) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	copyErr := anon.copyTo(w, stdout)
+	if copyErr != nil {
+		// Drain the output, so that pg_dump is not blocked writing to a full pipe.
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+
+	return errors.Join(cmd.Wait(), copyErr)
+}
+
+// RunRestore restores a dump in the plain SQL format of pg_dump into the database of an application. The dump is
+// restored in a single transaction, which is rolled back on the first error.
+func RunRestore(ctx context.Context, appName, team, environment string, fl *flag.Restore, out *naistrix.OutputWriter) error {
+	if _, err := os.Stat(fl.From); err != nil {
+		return err
+	}
+
+	psqlPath, err := exec.LookPath("psql")
+	if err != nil {
+		return fmt.Errorf("psql is required to restore the database: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	port, connectionInfo, err := StartDatabaseProxy(ctx, appName, team, environment, fl.Postgres, out)
+	if err != nil {
+		return err
+	}
+
+	arguments := []string{
+		"--host", "localhost",
+		"--port", strconv.Itoa(port),
+		"--username", connectionInfo.email,
+		"--dbname", connectionInfo.dbName,
+		"--no-psqlrc",
+		"--quiet",
+		"--single-transaction",
+		"--set", "ON_ERROR_STOP=1",
+		"--file", fl.From,
+	}
+
+	cmd := exec.CommandContext(ctx, psqlPath, arguments...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+connectionInfo.password)
+
+	out.Printf("Restoring %s into %s\n", fl.From, connectionInfo.dbName)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("restoring database, no changes were made: %w", err)
+	}
+
+	out.Successf("Restored %s into %s\n", fl.From, connectionInfo.dbName)
+	return nil
+}
+
+// StartDatabaseProxy starts a proxy to the database of an application on a random local port, and returns the port
+// once the proxy accepts connections. The proxy runs until ctx is cancelled.
+func StartDatabaseProxy(ctx context.Context, appName, team, environment string, fl *flag.Postgres, out *naistrix.OutputWriter) (int, *ConnectionInfo, error) {
+	// Get secret values with user-provided reason (access is logged for audit purposes)
+	sv, err := GetSecretValuesWithUserReason(ctx, appName, team, environment, fl, fl.Reason, out)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dbInfo.SetSecretValues(sv)
+
+	connectionInfo, err := dbInfo.DBConnection(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	portCh := make(chan int, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- dbInfo.RunProxy(ctx, "localhost", nil, portCh, out, false)
+	}()
+
+	select {
+	case port := <-portCh:
+		out.Verbosef("Running proxy on localhost:%v\n", port)
+		return port, connectionInfo, nil
+	case err := <-errCh:
+		if err == nil {
+			err = fmt.Errorf("proxy stopped before accepting connections")
+		}
+		return 0, nil, err
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+}
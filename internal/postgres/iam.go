@@ -39,7 +39,7 @@ func GrantAndCreateSQLUser(ctx context.Context, appName, team, environment strin
 	}
 
 	out.Println("Grant user access")
-	err = grantUserAccess(ctx, projectID, "roles/cloudsql.admin", 5*time.Minute, out)
+	_, err = grantUserAccess(ctx, projectID, "roles/cloudsql.admin", 5*time.Minute, out)
 	if err != nil {
 		return err
 	}
@@ -89,20 +89,22 @@ func currentEmail(ctx context.Context) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-func grantUserAccess(ctx context.Context, projectID, role string, duration time.Duration, out *naistrix.OutputWriter) error {
+// grantUserAccess grants the current user the given role in the project for the given duration, and returns when the
+// grant expires. A zero expiry means that the user already has permanent access, or that the grant does not expire.
+func grantUserAccess(ctx context.Context, projectID, role string, duration time.Duration, out *naistrix.OutputWriter) (time.Time, error) {
 	email, err := currentEmail(ctx)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	exists, err := cleanupPermissions(ctx, projectID, email, role, "nais_cli_access")
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	if exists {
 		out.Println("User already has permanent access to database, will not grant temporary access")
-		return nil
+		return time.Time{}, nil
 	}
 
 	args := []string{
@@ -114,8 +116,10 @@ func grantUserAccess(ctx context.Context, projectID, role string, duration time.
 		"--billing-project", projectID,
 	}
 
+	var expires time.Time
 	if duration > 0 {
-		timestamp := time.Now().Add(duration).UTC().Format(time.RFC3339)
+		expires = time.Now().Add(duration).UTC()
+		timestamp := expires.Format(time.RFC3339)
 		args = append(
 			args,
 			"--condition",
@@ -129,9 +133,9 @@ func grantUserAccess(ctx context.Context, projectID, role string, duration time.
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
 		_, _ = io.Copy(os.Stdout, buf)
-		return fmt.Errorf("grantUserAccess: error running gcloud command: %w", err)
+		return time.Time{}, fmt.Errorf("grantUserAccess: error running gcloud command: %w", err)
 	}
-	return nil
+	return expires, nil
 }
 
 func cleanupPermissions(ctx context.Context, projectID, email, role, conditionName string) (exists bool, err error) {
@@ -0,0 +1,525 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+)
+
+// iamUserRole is the role that IAM users are members of, and that `nais postgres prepare` grants privileges to.
+const iamUserRole = "cloudsqliamuser"
+
+// ByteSize is a size in bytes that renders in human-readable units in table output.
+type ByteSize int64
+
+func (b ByteSize) String() string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := int64(b) / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// Duration is a duration that renders rounded to seconds in table output, and as seconds in JSON output.
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).Round(time.Second).String()
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return fmt.Appendf(nil, "%.3f", time.Duration(d).Seconds()), nil
+}
+
+type SchemaInfo struct {
+	Name   string   `heading:"Schema" json:"name"`
+	Owner  string   `heading:"Owner" json:"owner"`
+	Tables int      `heading:"Tables" json:"tables"`
+	Size   ByteSize `heading:"Size" json:"size_bytes"`
+}
+
+type TableInfo struct {
+	Schema    string   `heading:"Schema" json:"schema"`
+	Table     string   `heading:"Table" json:"table"`
+	Rows      int64    `heading:"Rows (est.)" json:"rows_estimate"`
+	TotalSize ByteSize `heading:"Total Size" json:"total_size_bytes"`
+	IndexSize ByteSize `heading:"Index Size" json:"index_size_bytes"`
+}
+
+type IndexBloat struct {
+	Schema string   `heading:"Schema" json:"schema"`
+	Table  string   `heading:"Table" json:"table"`
+	Index  string   `heading:"Index" json:"index"`
+	Size   ByteSize `heading:"Size" json:"size_bytes"`
+	Bloat  ByteSize `heading:"Est. Bloat" json:"bloat_bytes"`
+}
+
+type RoleInfo struct {
+	Name      string `heading:"Role" json:"name"`
+	Login     bool   `heading:"Login" json:"login"`
+	Superuser bool   `heading:"Superuser" json:"superuser"`
+	CreateDB  bool   `heading:"Create DB" json:"create_db"`
+	MemberOf  string `heading:"Member Of" json:"member_of"`
+}
+
+// SchemaPrivileges are the effective privileges of a role in a schema, including privileges inherited through role
+// membership.
+type SchemaPrivileges struct {
+	Role           string `heading:"Role" json:"role"`
+	Schema         string `heading:"Schema" json:"schema"`
+	Usage          bool   `heading:"Usage" json:"usage"`
+	Create         bool   `heading:"Create" json:"create"`
+	Tables         int    `heading:"Tables" json:"tables"`
+	ReadableTables int    `heading:"Readable" json:"readable_tables"`
+	WritableTables int    `heading:"Writable" json:"writable_tables"`
+	Access         string `heading:"Access" json:"access"`
+}
+
+type ConnectionInfoSummary struct {
+	User        string `heading:"User" json:"user"`
+	Application string `heading:"Application" json:"application"`
+	State       string `heading:"State" json:"state"`
+	Count       int    `heading:"Connections" json:"count"`
+}
+
+type LongQuery struct {
+	PID      int      `heading:"PID" json:"pid"`
+	User     string   `heading:"User" json:"user"`
+	State    string   `heading:"State" json:"state"`
+	Duration Duration `heading:"Duration" json:"duration_seconds"`
+	Query    string   `heading:"Query" json:"query"`
+}
+
+type ReplicationInfo struct {
+	Replica string   `heading:"Replica" json:"replica"`
+	State   string   `heading:"State" json:"state"`
+	Lag     Duration `heading:"Replay Lag" json:"replay_lag_seconds"`
+}
+
+// Inspection is the report made by `nais postgres inspect`.
+type Inspection struct {
+	Database    string                  `json:"database"`
+	Schemas     []SchemaInfo            `json:"schemas"`
+	Tables      []TableInfo             `json:"tables"`
+	IndexBloat  []IndexBloat            `json:"index_bloat"`
+	Roles       []RoleInfo              `json:"roles"`
+	Privileges  []SchemaPrivileges      `json:"privileges"`
+	Connections []ConnectionInfoSummary `json:"connections"`
+	LongQueries []LongQuery             `json:"long_queries"`
+	Replication []ReplicationInfo       `json:"replication"`
+}
+
+// IAMUserAccess returns the effective privileges of the role shared by all IAM users, per schema.
+func (i *Inspection) IAMUserAccess() []SchemaPrivileges {
+	var ret []SchemaPrivileges
+	for _, p := range i.Privileges {
+		if p.Role == iamUserRole {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// accessLevel summarizes the privileges of a role in a schema as none, usage, read or write, prefixed with "partial"
+// when the privilege only covers some of the tables.
+func accessLevel(usage bool, tables, readable, writable int) string {
+	switch {
+	case !usage:
+		return "none"
+	case tables > 0 && writable == tables:
+		return "write"
+	case writable > 0:
+		return "partial write"
+	case tables > 0 && readable == tables:
+		return "read"
+	case readable > 0:
+		return "partial read"
+	default:
+		return "usage"
+	}
+}
+
+// Inspect reports schemas, sizes, roles and privileges, connections and replication of the database of an application.
+func Inspect(ctx context.Context, appName, team, environment string, fl *flag.Inspect, out *naistrix.OutputWriter) error {
+	// Get secret values (access is logged for audit purposes)
+	sv, err := GetSecretValues(ctx, appName, team, environment, fl.Postgres, ReasonInspect, out)
+	if err != nil {
+		return err
+	}
+
+	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+	if err != nil {
+		return err
+	}
+
+	dbInfo.SetSecretValues(sv)
+
+	connectionInfo, err := dbInfo.DBConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("cloudsqlpostgres", connectionInfo.ProxyConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	inspection, err := inspectDatabase(ctx, db, fl.LongQuery)
+	if err != nil {
+		return formatInvalidGrantError(err)
+	}
+
+	if fl.Output == "json" {
+		return out.JSON(output.JSONWithPrettyOutput()).Render(inspection)
+	}
+
+	return renderInspection(inspection, out)
+}
+
+func renderInspection(i *Inspection, out *naistrix.OutputWriter) error {
+	sections := []struct {
+		title string
+		empty bool
+		rows  any
+	}{
+		{"Schemas", len(i.Schemas) == 0, i.Schemas},
+		{"Largest tables", len(i.Tables) == 0, i.Tables},
+		{"Index bloat", len(i.IndexBloat) == 0, i.IndexBloat},
+		{"Roles", len(i.Roles) == 0, i.Roles},
+		{"Privileges", len(i.Privileges) == 0, i.Privileges},
+		{"Connections", len(i.Connections) == 0, i.Connections},
+		{"Long-running queries", len(i.LongQueries) == 0, i.LongQueries},
+		{"Replication", len(i.Replication) == 0, i.Replication},
+	}
+
+	out.Printf("Database %q\n", i.Database)
+	for _, s := range sections {
+		out.Println()
+		out.Printf("%s:\n", s.title)
+		if s.empty {
+			out.Println("  None")
+			continue
+		}
+		if err := out.Table().Render(s.rows); err != nil {
+			return err
+		}
+	}
+
+	out.Println()
+	iam := i.IAMUserAccess()
+	if len(iam) == 0 {
+		out.Infof("Role %s does not exist, run 'nais postgres prepare' to give IAM users access.\n", iamUserRole)
+		return nil
+	}
+	for _, p := range iam {
+		out.Printf("%s has %s access to schema %q\n", iamUserRole, p.Access, p.Schema)
+	}
+	return nil
+}
+
+func inspectDatabase(ctx context.Context, db *sql.DB, longQuery time.Duration) (*Inspection, error) {
+	i := &Inspection{}
+	if err := db.QueryRowContext(ctx, "SELECT current_database()").Scan(&i.Database); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"schemas", func() (err error) { i.Schemas, err = querySchemas(ctx, db); return }},
+		{"tables", func() (err error) { i.Tables, err = queryTables(ctx, db); return }},
+		{"index bloat", func() (err error) { i.IndexBloat, err = queryIndexBloat(ctx, db); return }},
+		{"roles", func() (err error) { i.Roles, err = queryRoles(ctx, db); return }},
+		{"privileges", func() (err error) { i.Privileges, err = queryPrivileges(ctx, db); return }},
+		{"connections", func() (err error) { i.Connections, err = queryConnections(ctx, db); return }},
+		{"long-running queries", func() (err error) { i.LongQueries, err = queryLongQueries(ctx, db, longQuery); return }},
+		{"replication", func() (err error) { i.Replication, err = queryReplication(ctx, db); return }},
+	}
+	for _, step := range steps {
+		if err := step.fn(); err != nil {
+			return nil, fmt.Errorf("inspecting %s: %w", step.name, err)
+		}
+	}
+
+	return i, nil
+}
+
+// userSchemas excludes schemas that belong to the system.
+const userSchemas = `n.nspname NOT LIKE 'pg\_%' AND n.nspname <> 'information_schema'`
+
+func querySchemas(ctx context.Context, db *sql.DB) ([]SchemaInfo, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT n.nspname, pg_get_userbyid(n.nspowner),
+			count(c.oid) FILTER (WHERE c.relkind IN ('r', 'p')),
+			coalesce(sum(pg_total_relation_size(c.oid)) FILTER (WHERE c.relkind IN ('r', 'p', 'm')), 0)
+		FROM pg_namespace n
+		LEFT JOIN pg_class c ON c.relnamespace = n.oid
+		WHERE `+userSchemas+`
+		GROUP BY n.nspname, n.nspowner
+		ORDER BY n.nspname`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []SchemaInfo
+	for rows.Next() {
+		var s SchemaInfo
+		if err := rows.Scan(&s.Name, &s.Owner, &s.Tables, &s.Size); err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, rows.Err()
+}
+
+func queryTables(ctx context.Context, db *sql.DB) ([]TableInfo, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT n.nspname, c.relname, greatest(c.reltuples, 0)::bigint,
+			pg_total_relation_size(c.oid), pg_indexes_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p', 'm') AND `+userSchemas+`
+		ORDER BY pg_total_relation_size(c.oid) DESC
+		LIMIT 20`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []TableInfo
+	for rows.Next() {
+		var t TableInfo
+		if err := rows.Scan(&t.Schema, &t.Table, &t.Rows, &t.TotalSize, &t.IndexSize); err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, rows.Err()
+}
+
+// queryIndexBloat estimates the bloat of B-tree indexes, by comparing the number of pages with the number of pages the
+// index would need when packed at the default fill factor, based on the average width of the indexed columns. The
+// estimate is rough, but good enough to spot indexes that would benefit from a REINDEX.
+func queryIndexBloat(ctx context.Context, db *sql.DB) ([]IndexBloat, error) {
+	rows, err := db.QueryContext(ctx, `
+		WITH indexes AS (
+			SELECT n.nspname AS schema, t.relname AS tbl, c.relname AS idx, c.relpages, c.reltuples,
+				current_setting('block_size')::numeric AS bs,
+				coalesce((
+					SELECT sum(s.avg_width)
+					FROM pg_attribute a
+					JOIN pg_stats s ON s.schemaname = n.nspname AND s.tablename = t.relname AND s.attname = a.attname
+					WHERE a.attrelid = c.oid AND a.attnum > 0
+				), 0) AS width
+			FROM pg_index x
+			JOIN pg_class c ON c.oid = x.indexrelid
+			JOIN pg_class t ON t.oid = x.indrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			JOIN pg_am am ON am.oid = c.relam
+			WHERE am.amname = 'btree' AND c.relpages > 1 AND `+userSchemas+`
+		), estimates AS (
+			SELECT schema, tbl, idx, relpages * bs AS size,
+				greatest(relpages - 1 - ceil(reltuples * (width + 16) / ((bs - 40) * 0.9)), 0) * bs AS bloat
+			FROM indexes
+		)
+		SELECT schema, tbl, idx, size::bigint, bloat::bigint
+		FROM estimates
+		WHERE bloat > 0
+		ORDER BY bloat DESC
+		LIMIT 20`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []IndexBloat
+	for rows.Next() {
+		var b IndexBloat
+		if err := rows.Scan(&b.Schema, &b.Table, &b.Index, &b.Size, &b.Bloat); err != nil {
+			return nil, err
+		}
+		ret = append(ret, b)
+	}
+	return ret, rows.Err()
+}
+
+// internalRoles are roles used by Cloud SQL itself, which users cannot log in as.
+const internalRoles = `r.rolname NOT LIKE 'pg\_%' AND r.rolname NOT IN ('cloudsqladmin', 'cloudsqlagent', 'cloudsqlreplica', 'cloudsqlimportexport')`
+
+func queryRoles(ctx context.Context, db *sql.DB) ([]RoleInfo, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.rolname, r.rolcanlogin, r.rolsuper, r.rolcreatedb,
+			coalesce((
+				SELECT string_agg(g.rolname, ', ' ORDER BY g.rolname)
+				FROM pg_auth_members m
+				JOIN pg_roles g ON g.oid = m.roleid
+				WHERE m.member = r.oid
+			), '')
+		FROM pg_roles r
+		WHERE `+internalRoles+`
+		ORDER BY r.rolname`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []RoleInfo
+	for rows.Next() {
+		var r RoleInfo
+		if err := rows.Scan(&r.Name, &r.Login, &r.Superuser, &r.CreateDB, &r.MemberOf); err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, rows.Err()
+}
+
+func queryPrivileges(ctx context.Context, db *sql.DB) ([]SchemaPrivileges, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT r.rolname, n.nspname,
+			has_schema_privilege(r.oid, n.oid, 'USAGE'),
+			has_schema_privilege(r.oid, n.oid, 'CREATE'),
+			count(c.oid),
+			count(c.oid) FILTER (WHERE has_table_privilege(r.oid, c.oid, 'SELECT')),
+			count(c.oid) FILTER (WHERE has_table_privilege(r.oid, c.oid, 'INSERT, UPDATE, DELETE, TRUNCATE'))
+		FROM pg_roles r
+		CROSS JOIN pg_namespace n
+		LEFT JOIN pg_class c ON c.relnamespace = n.oid AND c.relkind IN ('r', 'p', 'v', 'm')
+		WHERE `+internalRoles+` AND `+userSchemas+`
+		GROUP BY r.rolname, r.oid, n.nspname, n.oid
+		ORDER BY r.rolname, n.nspname`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []SchemaPrivileges
+	for rows.Next() {
+		var p SchemaPrivileges
+		if err := rows.Scan(&p.Role, &p.Schema, &p.Usage, &p.Create, &p.Tables, &p.ReadableTables, &p.WritableTables); err != nil {
+			return nil, err
+		}
+		p.Access = accessLevel(p.Usage, p.Tables, p.ReadableTables, p.WritableTables)
+		ret = append(ret, p)
+	}
+	return ret, rows.Err()
+}
+
+func queryConnections(ctx context.Context, db *sql.DB) ([]ConnectionInfoSummary, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT coalesce(usename, ''), coalesce(application_name, ''), coalesce(state, ''), count(*)
+		FROM pg_stat_activity
+		WHERE datname = current_database() AND backend_type = 'client backend'
+		GROUP BY 1, 2, 3
+		ORDER BY 4 DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []ConnectionInfoSummary
+	for rows.Next() {
+		var c ConnectionInfoSummary
+		if err := rows.Scan(&c.User, &c.Application, &c.State, &c.Count); err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+	return ret, rows.Err()
+}
+
+func queryLongQueries(ctx context.Context, db *sql.DB, threshold time.Duration) ([]LongQuery, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT pid, coalesce(usename, ''), coalesce(state, ''),
+			extract(epoch FROM now() - query_start)::float8, query
+		FROM pg_stat_activity
+		WHERE datname = current_database()
+			AND state <> 'idle'
+			AND pid <> pg_backend_pid()
+			AND query_start < now() - make_interval(secs => $1)
+		ORDER BY query_start`, threshold.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []LongQuery
+	for rows.Next() {
+		var q LongQuery
+		var seconds float64
+		if err := rows.Scan(&q.PID, &q.User, &q.State, &seconds, &q.Query); err != nil {
+			return nil, err
+		}
+		q.Duration = Duration(time.Duration(seconds * float64(time.Second)))
+		q.Query = truncateQuery(q.Query, 80)
+		ret = append(ret, q)
+	}
+	return ret, rows.Err()
+}
+
+// queryReplication reports the replay lag of each replica when connected to a primary, or the lag of the instance
+// itself when connected to a replica.
+func queryReplication(ctx context.Context, db *sql.DB) ([]ReplicationInfo, error) {
+	var inRecovery bool
+	if err := db.QueryRowContext(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return nil, err
+	}
+
+	if inRecovery {
+		var seconds sql.NullFloat64
+		err := db.QueryRowContext(ctx, "SELECT extract(epoch FROM now() - pg_last_xact_replay_timestamp())::float8").Scan(&seconds)
+		if err != nil {
+			return nil, err
+		}
+		return []ReplicationInfo{{
+			Replica: "(this instance)",
+			State:   "replica",
+			Lag:     Duration(time.Duration(seconds.Float64 * float64(time.Second))),
+		}}, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT coalesce(nullif(application_name, ''), host(client_addr), ''), coalesce(state, ''),
+			coalesce(extract(epoch FROM replay_lag), 0)::float8
+		FROM pg_stat_replication
+		ORDER BY 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []ReplicationInfo
+	for rows.Next() {
+		var r ReplicationInfo
+		var seconds float64
+		if err := rows.Scan(&r.Replica, &r.State, &seconds); err != nil {
+			return nil, err
+		}
+		r.Lag = Duration(time.Duration(seconds * float64(time.Second)))
+		ret = append(ret, r)
+	}
+	return ret, rows.Err()
+}
+
+// truncateQuery shortens a query to a single line of at most n characters.
+func truncateQuery(query string, n int) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if r := []rune(query); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return query
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessLevel(t *testing.T) {
+	tests := []struct {
+		usage                      bool
+		tables, readable, writable int
+		want                       string
+	}{
+		{usage: false, tables: 3, readable: 3, writable: 3, want: "none"},
+		{usage: true, tables: 0, want: "usage"},
+		{usage: true, tables: 3, readable: 0, want: "usage"},
+		{usage: true, tables: 3, readable: 1, want: "partial read"},
+		{usage: true, tables: 3, readable: 3, want: "read"},
+		{usage: true, tables: 3, readable: 3, writable: 1, want: "partial write"},
+		{usage: true, tables: 3, readable: 3, writable: 3, want: "write"},
+	}
+
+	for _, tt := range tests {
+		if got := accessLevel(tt.usage, tt.tables, tt.readable, tt.writable); got != tt.want {
+			t.Errorf("accessLevel(%v, %d, %d, %d) = %q, want %q", tt.usage, tt.tables, tt.readable, tt.writable, got, tt.want)
+		}
+	}
+}
+
+func TestIAMUserAccess(t *testing.T) {
+	i := &Inspection{Privileges: []SchemaPrivileges{
+		{Role: "my-app", Schema: "public", Access: "write"},
+		{Role: iamUserRole, Schema: "public", Access: "read"},
+		{Role: iamUserRole, Schema: "audit", Access: "none"},
+	}}
+
+	got := i.IAMUserAccess()
+	if len(got) != 2 || got[0].Schema != "public" || got[1].Schema != "audit" {
+		t.Errorf("unexpected IAM user access: %+v", got)
+	}
+}
+
+func TestByteSizeAndDuration(t *testing.T) {
+	sizes := map[ByteSize]string{
+		512:                "512 B",
+		2048:               "2.0 KiB",
+		5 * 1024 * 1024:    "5.0 MiB",
+		1536 * 1024 * 1024: "1.5 GiB",
+	}
+	for size, want := range sizes {
+		if got := size.String(); got != want {
+			t.Errorf("ByteSize(%d) = %q, want %q", int64(size), got, want)
+		}
+	}
+
+	if got := Duration(90*time.Second + 400*time.Millisecond).String(); got != "1m30s" {
+		t.Errorf("Duration.String() = %q, want %q", got, "1m30s")
+	}
+	b, err := Duration(1500 * time.Millisecond).MarshalJSON()
+	if err != nil || string(b) != "1.500" {
+		t.Errorf("Duration.MarshalJSON() = %s, %v", b, err)
+	}
+}
+
+func TestTruncateQuery(t *testing.T) {
+	if got := truncateQuery("select *\n  from   users", 80); got != "select * from users" {
+		t.Errorf("unexpected query: %q", got)
+	}
+	if got := truncateQuery("select * from users", 10); got != "select * …" {
+		t.Errorf("unexpected query: %q", got)
+	}
+}
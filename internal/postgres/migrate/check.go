@@ -0,0 +1,344 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nais/cli/internal/option"
+	"github.com/nais/cli/internal/postgres/migrate/config"
+	"github.com/pterm/pterm"
+)
+
+type Severity string
+
+const (
+	SeverityBlocker Severity = "blocker"
+	SeverityWarning Severity = "warning"
+	SeverityOK      Severity = "ok"
+)
+
+// defaultDiskSize is the disk size, in GB, that nais gives instances that have neither a disk size nor autoresize set.
+const defaultDiskSize = 10
+
+// diskHeadroom is how much larger than the data the target disk should be, to leave room for replication and growth.
+const diskHeadroom = 1.2
+
+// removedExtensions are extensions that are not available from the given major version.
+var removedExtensions = map[string]int{
+	"tsearch2":  10,
+	"chkpass":   11,
+	"adminpack": 17,
+}
+
+// Finding is the result of a single pre-flight check.
+type Finding struct {
+	Severity Severity
+	Check    string
+	Message  string
+}
+
+// Check runs pre-flight checks for migrating the application to a target instance with the configured tier and type,
+// without creating anything. The database is inspected through db, which should be connected to the source instance.
+func (m *Migrator) Check(ctx context.Context, db *sql.DB) ([]Finding, error) {
+	var findings []Finding
+
+	status, err := GetStatus(ctx, m.client, m.cfg.AppName, m.cfg.Team)
+	if err != nil {
+		return nil, err
+	}
+	if status.Phase != PhaseNone {
+		findings = append(findings, Finding{SeverityBlocker, "Migration", fmt.Sprintf("a migration to %s is already in progress (%s)", status.TargetInstance, status.Phase)})
+	}
+
+	if err := m.cfg.Source.Resolve(ctx, m.client, m.cfg.AppName, m.cfg.Team); err != nil {
+		if errors.Is(err, config.ErrMissingSqlInstance) {
+			return append(findings, Finding{SeverityBlocker, "Source instance", "the application does not have any SQL instances in its spec"}), nil
+		}
+		return nil, err
+	}
+
+	target := checkTarget(m.cfg.Source, m.cfg.Target)
+
+	findings = append(findings,
+		checkVersion(m.cfg.Source.Type.String(), target.Type.String()),
+		checkTier(m.cfg.Source.Tier.String(), target.Tier.String()),
+	)
+
+	dbFindings, err := checkDatabase(ctx, db, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(findings, dbFindings...), nil
+}
+
+// checkTarget returns the target instance config with unset values the same as the source, like when answering the
+// prompts in setup. As in setup, the disk size is cleared when disk autoresize is enabled.
+func checkTarget(source, target config.InstanceConfig) config.InstanceConfig {
+	target.Tier = target.Tier.OrMaybe(func() option.Option[string] { return source.Tier })
+	target.Type = target.Type.OrMaybe(func() option.Option[string] { return source.Type })
+	target.DiskAutoresize = target.DiskAutoresize.OrMaybe(func() option.Option[bool] { return source.DiskAutoresize })
+	target.DiskSize = target.DiskSize.OrMaybe(func() option.Option[int] { return source.DiskSize })
+	target.DiskAutoresize.Do(func(v bool) {
+		if v {
+			target.DiskSize = option.None[int]()
+		}
+	})
+	return target
+}
+
+// majorVersion returns the major version of an instance type such as POSTGRES_16.
+func majorVersion(instanceType string) (int, error) {
+	v, ok := strings.CutPrefix(instanceType, "POSTGRES_")
+	if !ok {
+		return 0, fmt.Errorf("unknown instance type %q", instanceType)
+	}
+	return strconv.Atoi(v)
+}
+
+func checkVersion(sourceType, targetType string) Finding {
+	const check = "Version"
+
+	if targetType == "" {
+		return Finding{SeverityWarning, check, "the instance type is not set in the application spec, so the nais default is used for both instances"}
+	}
+
+	target, err := majorVersion(targetType)
+	if err != nil {
+		return Finding{SeverityBlocker, check, err.Error()}
+	}
+
+	source, err := majorVersion(sourceType)
+	if err != nil {
+		return Finding{SeverityWarning, check, fmt.Sprintf("unable to determine the source version: %v", err)}
+	}
+
+	switch {
+	case target < source:
+		return Finding{SeverityBlocker, check, fmt.Sprintf("downgrading from %s to %s is not supported", sourceType, targetType)}
+	case target-source > 1:
+		return Finding{SeverityWarning, check, fmt.Sprintf("upgrading %d major versions from %s to %s, make sure the application and its driver support %s", target-source, sourceType, targetType, targetType)}
+	case target > source:
+		return Finding{SeverityOK, check, fmt.Sprintf("upgrading from %s to %s", sourceType, targetType)}
+	default:
+		return Finding{SeverityOK, check, fmt.Sprintf("staying on %s", targetType)}
+	}
+}
+
+// parseCustomTier parses a tier such as db-custom-2-7680 into CPUs and memory in MB.
+func parseCustomTier(tier string) (cpus, memory int, ok bool) {
+	parts := strings.Split(tier, "-")
+	if len(parts) != 4 || parts[0] != "db" || parts[1] != "custom" {
+		return 0, 0, false
+	}
+	cpus, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	memory, err = strconv.Atoi(parts[3])
+	if err != nil {
+		return 0, 0, false
+	}
+	return cpus, memory, true
+}
+
+func checkTier(sourceTier, targetTier string) Finding {
+	const check = "Tier"
+
+	if targetTier != "" && !strings.HasPrefix(targetTier, "db-") {
+		return Finding{SeverityBlocker, check, fmt.Sprintf("invalid tier %q, must start with db-", targetTier)}
+	}
+
+	sourceCPUs, sourceMemory, sourceOK := parseCustomTier(sourceTier)
+	targetCPUs, targetMemory, targetOK := parseCustomTier(targetTier)
+	if sourceOK && targetOK && (targetCPUs < sourceCPUs || targetMemory < sourceMemory) {
+		return Finding{SeverityWarning, check, fmt.Sprintf("the target tier %s is smaller than the source tier %s, make sure it can handle the load", targetTier, sourceTier)}
+	}
+
+	return Finding{SeverityOK, check, fmt.Sprintf("using tier %s", orNaisDefault(targetTier))}
+}
+
+func orNaisDefault(s string) string {
+	if s == "" {
+		return "<nais default>"
+	}
+	return s
+}
+
+func checkDisk(dataBytes int64, target config.InstanceConfig) Finding {
+	const check = "Disk"
+
+	autoresize := false
+	target.DiskAutoresize.Do(func(v bool) { autoresize = v })
+
+	dataGB := float64(dataBytes) / (1 << 30)
+	if autoresize {
+		return Finding{SeverityOK, check, fmt.Sprintf("%.1f GB of data, and disk autoresize is enabled", dataGB)}
+	}
+
+	diskSize := defaultDiskSize
+	target.DiskSize.Do(func(v int) { diskSize = v })
+
+	if dataGB*diskHeadroom > float64(diskSize) {
+		return Finding{SeverityBlocker, check, fmt.Sprintf("%.1f GB of data does not fit on a %d GB disk with room for replication, use a larger disk size or enable disk autoresize", dataGB, diskSize)}
+	}
+	return Finding{SeverityOK, check, fmt.Sprintf("%.1f GB of data fits on a %d GB disk", dataGB, diskSize)}
+}
+
+type extension struct {
+	name    string
+	version string
+}
+
+func checkExtensions(extensions []extension, targetType string) []Finding {
+	const check = "Extensions"
+
+	target, err := majorVersion(targetType)
+	var ret []Finding
+	var names []string
+	for _, e := range extensions {
+		names = append(names, e.name+" "+e.version)
+		if removedIn, ok := removedExtensions[e.name]; ok && err == nil && target >= removedIn {
+			ret = append(ret, Finding{SeverityBlocker, check, fmt.Sprintf("extension %s is not available in POSTGRES_%d and later, drop it before migrating", e.name, removedIn)})
+		}
+	}
+
+	if len(ret) > 0 {
+		return ret
+	}
+	if len(names) == 0 {
+		return []Finding{{SeverityOK, check, "no extensions installed"}}
+	}
+	return []Finding{{SeverityOK, check, "available in the target version: " + strings.Join(names, ", ")}}
+}
+
+func listFinding(check string, severity Severity, items []string, problem, ok string) Finding {
+	if len(items) == 0 {
+		return Finding{SeverityOK, check, ok}
+	}
+	return Finding{severity, check, fmt.Sprintf("%s: %s", problem, strings.Join(items, ", "))}
+}
+
+// userRelations excludes relations that belong to the system.
+const userRelations = `n.nspname NOT LIKE 'pg\_%' AND n.nspname <> 'information_schema'`
+
+func checkDatabase(ctx context.Context, db *sql.DB, target config.InstanceConfig) ([]Finding, error) {
+	var findings []Finding
+
+	var dataBytes int64
+	if err := db.QueryRowContext(ctx, `SELECT coalesce(sum(pg_database_size(datname)), 0)::bigint FROM pg_database WHERE datallowconn AND has_database_privilege(datname, 'CONNECT')`).Scan(&dataBytes); err != nil {
+		return nil, fmt.Errorf("checking database size: %w", err)
+	}
+	findings = append(findings, checkDisk(dataBytes, target))
+
+	noPrimaryKey, err := queryStrings(ctx, db, `
+		SELECT format('%I.%I', n.nspname, c.relname)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND NOT c.relispartition AND `+userRelations+`
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint k WHERE k.conrelid = c.oid AND k.contype = 'p')
+		ORDER BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("checking primary keys: %w", err)
+	}
+	findings = append(findings, listFinding("Primary keys", SeverityBlocker, noPrimaryKey,
+		"tables without a primary key cannot replicate updates and deletes, add primary keys to", "all tables have a primary key"))
+
+	unlogged, err := queryStrings(ctx, db, `
+		SELECT format('%I.%I', n.nspname, c.relname)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r' AND c.relpersistence = 'u' AND `+userRelations+`
+		ORDER BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("checking unlogged tables: %w", err)
+	}
+	findings = append(findings, listFinding("Unlogged tables", SeverityWarning, unlogged,
+		"data in unlogged tables is not replicated", "no unlogged tables"))
+
+	var largeObjects int64
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM pg_largeobject_metadata`).Scan(&largeObjects); err != nil {
+		return nil, fmt.Errorf("checking large objects: %w", err)
+	}
+	if largeObjects > 0 {
+		findings = append(findings, Finding{SeverityWarning, "Large objects", fmt.Sprintf("%d large objects are not replicated", largeObjects)})
+	} else {
+		findings = append(findings, Finding{SeverityOK, "Large objects", "no large objects"})
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT extname, extversion FROM pg_extension WHERE extname <> 'plpgsql' ORDER BY extname`)
+	if err != nil {
+		return nil, fmt.Errorf("checking extensions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var extensions []extension
+	for rows.Next() {
+		var e extension
+		if err := rows.Scan(&e.name, &e.version); err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return append(findings, checkExtensions(extensions, target.Type.String())...), nil
+}
+
+func queryStrings(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ret []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, rows.Err()
+}
+
+// PrintFindings prints the findings of a pre-flight check, and returns an error if any of them blocks the migration.
+func PrintFindings(findings []Finding) error {
+	blockers, warnings := 0, 0
+	data := pterm.TableData{{"", "Check", "Result"}}
+	for _, f := range findings {
+		var mark string
+		switch f.Severity {
+		case SeverityBlocker:
+			blockers++
+			mark = pterm.Red("✗")
+		case SeverityWarning:
+			warnings++
+			mark = pterm.Yellow("!")
+		default:
+			mark = pterm.Green("✓")
+		}
+		data = append(data, []string{mark, f.Check, f.Message})
+	}
+
+	pterm.DefaultSection.Println("Pre-flight checks")
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Println()
+
+	if blockers > 0 {
+		return fmt.Errorf("found %d blocker(s) and %d warning(s), fix the blockers before running setup", blockers, warnings)
+	}
+	if warnings > 0 {
+		pterm.Warning.Printfln("Found %d warning(s), review them before running setup.", warnings)
+		return nil
+	}
+	pterm.Success.Println("No problems found.")
+	return nil
+}
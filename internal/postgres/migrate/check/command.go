@@ -0,0 +1,54 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nais/cli/internal/k8s"
+	"github.com/nais/cli/internal/option"
+	"github.com/nais/cli/internal/postgres"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/cli/internal/postgres/migrate"
+	"github.com/nais/cli/internal/postgres/migrate/config"
+	"github.com/nais/naistrix"
+)
+
+func Run(ctx context.Context, applicationName, team, environment string, flags *flag.MigrateCheck, out *naistrix.OutputWriter) error {
+	cfg := config.Config{
+		AppName: applicationName,
+		Team:    team,
+	}
+	if flags.TargetTier != "" {
+		cfg.Target.Tier = option.Some(flags.TargetTier)
+	}
+	if flags.TargetType != "" {
+		cfg.Target.Type = option.Some(strings.ToUpper(flags.TargetType))
+	}
+	if flags.TargetDiskAutoResize {
+		cfg.Target.DiskAutoresize = option.Some(true)
+	}
+	if flags.TargetDiskSize > 0 {
+		cfg.Target.DiskSize = option.Some(flags.TargetDiskSize)
+	}
+
+	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+	clientSet, err := k8s.SetupClientGo(environment)
+	if err != nil {
+		return err
+	}
+
+	db, _, err := postgres.OpenDatabase(ctx, applicationName, team, environment, flags.Postgres, postgres.ReasonMigrationCheck, out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	migrator := migrate.NewMigrator(client, clientSet, cfg, true, true)
+	findings, err := migrator.Check(ctx, db)
+	if err != nil {
+		return fmt.Errorf("error checking migration: %w", err)
+	}
+
+	return migrate.PrintFindings(findings)
+}
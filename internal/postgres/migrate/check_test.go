@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/nais/cli/internal/option"
+	"github.com/nais/cli/internal/postgres/migrate/config"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		source, target string
+		want           Severity
+	}{
+		{"POSTGRES_15", "POSTGRES_15", SeverityOK},
+		{"POSTGRES_15", "POSTGRES_16", SeverityOK},
+		{"POSTGRES_12", "POSTGRES_16", SeverityWarning},
+		{"POSTGRES_16", "POSTGRES_15", SeverityBlocker},
+		{"POSTGRES_16", "MYSQL_8_0", SeverityBlocker},
+		{"", "", SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		if got := checkVersion(tt.source, tt.target); got.Severity != tt.want {
+			t.Errorf("checkVersion(%q, %q) = %v, want severity %q", tt.source, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTier(t *testing.T) {
+	tests := []struct {
+		source, target string
+		want           Severity
+	}{
+		{"db-custom-1-3840", "db-custom-2-7680", SeverityOK},
+		{"db-custom-2-7680", "db-custom-1-3840", SeverityWarning},
+		{"db-f1-micro", "db-custom-1-3840", SeverityOK},
+		{"db-custom-1-3840", "custom-1-3840", SeverityBlocker},
+	}
+
+	for _, tt := range tests {
+		if got := checkTier(tt.source, tt.target); got.Severity != tt.want {
+			t.Errorf("checkTier(%q, %q) = %v, want severity %q", tt.source, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDisk(t *testing.T) {
+	const gb = 1 << 30
+
+	tests := map[string]struct {
+		data   int64
+		target config.InstanceConfig
+		want   Severity
+	}{
+		"fits on default disk":    {data: 5 * gb, want: SeverityOK},
+		"too large for default":   {data: 9 * gb, want: SeverityBlocker},
+		"fits on configured disk": {data: 40 * gb, target: config.InstanceConfig{DiskSize: option.Some(100)}, want: SeverityOK},
+		"autoresize":              {data: 400 * gb, target: config.InstanceConfig{DiskAutoresize: option.Some(true)}, want: SeverityOK},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := checkDisk(tt.data, tt.target); got.Severity != tt.want {
+				t.Errorf("checkDisk() = %v, want severity %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	source := config.InstanceConfig{
+		Tier:     option.Some("db-custom-2-7680"),
+		Type:     option.Some("POSTGRES_15"),
+		DiskSize: option.Some(200),
+	}
+
+	target := checkTarget(source, config.InstanceConfig{Type: option.Some("POSTGRES_17")})
+	if target.Type.String() != "POSTGRES_17" || target.Tier.String() != "db-custom-2-7680" {
+		t.Errorf("expected type from target and tier from source, got %v", target)
+	}
+	if got := checkDisk(150<<30, target); got.Severity != SeverityOK {
+		t.Errorf("expected the disk size of the source to be used, got %v", got)
+	}
+
+	source.DiskAutoresize = option.Some(true)
+	target = checkTarget(source, config.InstanceConfig{})
+	target.DiskSize.Do(func(v int) { t.Errorf("expected disk size to be cleared with autoresize, got %d", v) })
+	if got := checkDisk(400<<30, target); got.Severity != SeverityOK {
+		t.Errorf("expected disk autoresize of the source to be used, got %v", got)
+	}
+}
+
+func TestCheckExtensions(t *testing.T) {
+	extensions := []extension{{"adminpack", "2.1"}, {"pg_trgm", "1.6"}}
+
+	if got := checkExtensions(extensions, "POSTGRES_16"); len(got) != 1 || got[0].Severity != SeverityOK {
+		t.Errorf("expected extensions to be available in POSTGRES_16, got %v", got)
+	}
+	if got := checkExtensions(extensions, "POSTGRES_17"); len(got) != 1 || got[0].Severity != SeverityBlocker {
+		t.Errorf("expected adminpack to block POSTGRES_17, got %v", got)
+	}
+	if got := checkExtensions(nil, "POSTGRES_17"); len(got) != 1 || got[0].Severity != SeverityOK {
+		t.Errorf("expected no findings for no extensions, got %v", got)
+	}
+}
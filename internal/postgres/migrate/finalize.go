@@ -8,6 +8,10 @@ import (
 )
 
 func (m *Migrator) Finalize(ctx context.Context) error {
+	if err := m.requireAllowed(ctx, CommandFinalize); err != nil {
+		return err
+	}
+
 	cfgMap, err := m.cfg.PopulateFromConfigMap(ctx, m.client)
 	if err != nil {
 		return err
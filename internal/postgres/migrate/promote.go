@@ -9,6 +9,10 @@ import (
 )
 
 func (m *Migrator) Promote(ctx context.Context) error {
+	if err := m.requireAllowed(ctx, CommandPromote); err != nil {
+		return err
+	}
+
 	cfgMap, err := m.cfg.PopulateFromConfigMap(ctx, m.client)
 	if err != nil {
 		return err
@@ -7,6 +7,10 @@ import (
 )
 
 func (m *Migrator) Rollback(ctx context.Context) error {
+	if err := m.requireAllowed(ctx, CommandRollback); err != nil {
+		return err
+	}
+
 	cfgMap, err := m.cfg.PopulateFromConfigMap(ctx, m.client)
 	if err != nil {
 		return err
@@ -144,6 +144,9 @@ func (m *Migrator) Setup(ctx context.Context) error {
 	ui.CmdStyle.Printfln("\tnais postgres migrate promote %s %s", m.cfg.AppName, m.cfg.Target.InstanceName)
 	pterm.Println()
 	pterm.Info.Println("Be aware that during promotion (the next step), your instance will be unavailable for some time.")
+	pterm.Println()
+	pterm.Println("You can check the progress of the migration and which step comes next at any time with:")
+	ui.CmdStyle.Printfln("\tnais postgres migrate status %s", m.cfg.AppName)
 	return nil
 }
 
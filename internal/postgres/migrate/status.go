@@ -0,0 +1,316 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/migrate/ui"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	"github.com/pterm/pterm"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type StepState string
+
+const (
+	StepNotStarted StepState = "not started"
+	StepRunning    StepState = "running"
+	StepSucceeded  StepState = "succeeded"
+	StepFailed     StepState = "failed"
+)
+
+// Step is the state of the job for one command in a migration.
+type Step struct {
+	Command   Command
+	State     StepState
+	Job       string
+	Started   time.Time
+	Completed time.Time
+}
+
+type Phase string
+
+const (
+	PhaseNone           Phase = "not migrating"
+	PhaseSetupPending   Phase = "setup pending"
+	PhaseSettingUp      Phase = "setting up"
+	PhaseSetupFailed    Phase = "setup failed"
+	PhaseReplicating    Phase = "replicating"
+	PhasePromoting      Phase = "promoting"
+	PhasePromoteFailed  Phase = "promotion failed"
+	PhasePromoted       Phase = "promoted"
+	PhaseFinalizing     Phase = "finalizing"
+	PhaseFinalizeFailed Phase = "finalize failed"
+	PhaseFinalized      Phase = "finalized"
+	PhaseRollingBack    Phase = "rolling back"
+	PhaseRollbackFailed Phase = "rollback failed"
+	PhaseRolledBack     Phase = "rolled back"
+)
+
+// Status is the state of a migration, reconstructed from the migration ConfigMap and the labels of the jobs started
+// for each command.
+type Status struct {
+	AppName        string
+	MigrationName  string
+	SourceInstance string
+	TargetInstance string
+	CreatedAt      time.Time
+	Steps          []Step
+	Phase          Phase
+	// Next are the commands that can be run in the current phase.
+	Next []Command
+}
+
+// GetStatus returns the status of the migration of an application. An application has at most one migration in
+// progress, as setup refuses to start a new one while a migration ConfigMap exists.
+func GetStatus(ctx context.Context, client ctrl.Client, appName, team string) (*Status, error) {
+	cfgMaps := &corev1.ConfigMapList{}
+	err := client.List(ctx, cfgMaps, ctrl.InNamespace(team), ctrl.MatchingLabels{"migrator.nais.io/app-name": appName})
+	if err != nil {
+		return nil, fmt.Errorf("listing migration config: %w", err)
+	}
+
+	status := &Status{AppName: appName}
+	switch len(cfgMaps.Items) {
+	case 0:
+		status.Phase = PhaseNone
+		status.Next = []Command{CommandSetup}
+		return status, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("found %d migration configs for %s, contact nais team", len(cfgMaps.Items), appName)
+	}
+
+	cfgMap := cfgMaps.Items[0]
+	status.MigrationName = cfgMap.Labels["migrator.nais.io/migration-name"]
+	if status.MigrationName == "" {
+		status.MigrationName = cfgMap.Name
+	}
+	status.SourceInstance = cfgMap.Data["SOURCE_INSTANCE_NAME"]
+	status.TargetInstance = cfgMap.Data["TARGET_INSTANCE_NAME"]
+	status.CreatedAt = cfgMap.CreationTimestamp.Time
+
+	labels := ctrl.MatchingLabels{"migrator.nais.io/migration-name": status.MigrationName}
+	jobs := &batchv1.JobList{}
+	if err := client.List(ctx, jobs, ctrl.InNamespace(team), labels); err != nil {
+		return nil, fmt.Errorf("listing migration jobs: %w", err)
+	}
+	naisjobs := &nais_io_v1.NaisjobList{}
+	if err := client.List(ctx, naisjobs, ctrl.InNamespace(team), labels); err != nil {
+		return nil, fmt.Errorf("listing migration naisjobs: %w", err)
+	}
+
+	for _, command := range []Command{CommandSetup, CommandPromote, CommandFinalize, CommandRollback} {
+		step := jobStep(command, jobs.Items)
+		if step.State == StepNotStarted {
+			// The Naisjob exists before its first job is created.
+			for _, naisjob := range naisjobs.Items {
+				if naisjob.Labels["migrator.nais.io/command"] == string(command) {
+					step.State = StepRunning
+					step.Job = naisjob.Name
+					step.Started = naisjob.CreationTimestamp.Time
+				}
+			}
+		}
+		status.Steps = append(status.Steps, step)
+	}
+
+	status.Phase, status.Next = phase(status.Steps)
+	return status, nil
+}
+
+// jobStep returns the state of the latest job for the command.
+func jobStep(command Command, jobs []batchv1.Job) Step {
+	step := Step{Command: command, State: StepNotStarted}
+
+	var latest *batchv1.Job
+	for i, job := range jobs {
+		if job.Labels["migrator.nais.io/command"] != string(command) {
+			continue
+		}
+		if latest == nil || job.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = &jobs[i]
+		}
+	}
+	if latest == nil {
+		return step
+	}
+
+	step.Job = latest.Name
+	step.Started = latest.CreationTimestamp.Time
+	if latest.Status.StartTime != nil {
+		step.Started = latest.Status.StartTime.Time
+	}
+
+	step.State = StepRunning
+	if latest.Status.Succeeded > 0 {
+		step.State = StepSucceeded
+		if latest.Status.CompletionTime != nil {
+			step.Completed = latest.Status.CompletionTime.Time
+		}
+		return step
+	}
+	for _, c := range latest.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			step.State = StepFailed
+			step.Completed = c.LastTransitionTime.Time
+		}
+	}
+	return step
+}
+
+// phase decides the phase of a migration with a ConfigMap from the state of its steps, and which commands are allowed
+// next. Nothing is allowed while a job is running, and rollback is possible until finalize has been started. A failed
+// finalize or rollback can be run again.
+func phase(steps []Step) (Phase, []Command) {
+	state := map[Command]StepState{}
+	for _, s := range steps {
+		state[s.Command] = s.State
+	}
+
+	switch state[CommandRollback] {
+	case StepRunning:
+		return PhaseRollingBack, nil
+	case StepFailed:
+		return PhaseRollbackFailed, []Command{CommandRollback}
+	case StepSucceeded:
+		return PhaseRolledBack, nil
+	}
+
+	switch state[CommandFinalize] {
+	case StepRunning:
+		return PhaseFinalizing, nil
+	case StepFailed:
+		return PhaseFinalizeFailed, []Command{CommandFinalize}
+	case StepSucceeded:
+		return PhaseFinalized, nil
+	}
+
+	switch state[CommandPromote] {
+	case StepRunning:
+		return PhasePromoting, nil
+	case StepFailed:
+		return PhasePromoteFailed, []Command{CommandRollback}
+	case StepSucceeded:
+		return PhasePromoted, []Command{CommandFinalize, CommandRollback}
+	}
+
+	switch state[CommandSetup] {
+	case StepRunning:
+		return PhaseSettingUp, nil
+	case StepFailed:
+		return PhaseSetupFailed, []Command{CommandRollback}
+	case StepSucceeded:
+		return PhaseReplicating, []Command{CommandPromote, CommandRollback}
+	}
+
+	return PhaseSetupPending, []Command{CommandRollback}
+}
+
+// Allows returns an error explaining why the command cannot be run in the current phase, if it cannot.
+func (s *Status) Allows(command Command, targetInstance string) error {
+	if s.Phase != PhaseNone && command != CommandSetup && targetInstance != "" && targetInstance != s.TargetInstance {
+		return fmt.Errorf("the migration of %s in progress targets instance %q, not %q", s.AppName, s.TargetInstance, targetInstance)
+	}
+
+	if slices.Contains(s.Next, command) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("cannot run %s: the migration of %s is in phase %q", command, s.AppName, s.Phase)
+	switch {
+	case s.Phase == PhaseNone:
+		msg = fmt.Sprintf("cannot run %s: there is no migration in progress for %s, start one with setup", command, s.AppName)
+	case len(s.Next) > 0:
+		msg += fmt.Sprintf(", the next step is %s", joinCommands(s.Next))
+	case s.running():
+		msg += ", wait for the running job to complete"
+	default:
+		msg += ", contact nais team if the migration is stuck"
+	}
+	return fmt.Errorf("%s (see 'nais postgres migrate status %s')", msg, s.AppName)
+}
+
+func (s *Status) running() bool {
+	return slices.ContainsFunc(s.Steps, func(step Step) bool { return step.State == StepRunning })
+}
+
+func joinCommands(commands []Command) string {
+	names := make([]string, 0, len(commands))
+	for _, c := range commands {
+		names = append(names, string(c))
+	}
+	return strings.Join(names, " or ")
+}
+
+// requireAllowed refuses to run a command out of order.
+func (m *Migrator) requireAllowed(ctx context.Context, command Command) error {
+	status, err := GetStatus(ctx, m.client, m.cfg.AppName, m.cfg.Team)
+	if err != nil {
+		return err
+	}
+	return status.Allows(command, m.cfg.Target.InstanceName.String())
+}
+
+// Status prints the state of the migration of the application, and the commands that can be run next.
+func (m *Migrator) Status(ctx context.Context) error {
+	status, err := GetStatus(ctx, m.client, m.cfg.AppName, m.cfg.Team)
+	if err != nil {
+		return err
+	}
+
+	pterm.DefaultSection.Println("Migration status")
+	pterm.Printfln("Application: %s", m.cfg.AppName)
+	pterm.Printfln("Namespace: %s", m.cfg.Team)
+	pterm.Printfln("Phase: %s", status.Phase)
+
+	if status.Phase == PhaseNone {
+		pterm.Println()
+		pterm.Println("There is no migration in progress. To start one, run:")
+		ui.CmdStyle.Printfln("\tnais postgres migrate setup %s <target_sql_instance_name>", m.cfg.AppName)
+		return nil
+	}
+
+	pterm.Printfln("Source instance: %s", status.SourceInstance)
+	pterm.Printfln("Target instance: %s", status.TargetInstance)
+	pterm.Printfln("Started: %s", status.CreatedAt.Local().Format(time.DateTime))
+	pterm.Println()
+
+	data := pterm.TableData{{"Step", "State", "Job", "Started", "Completed"}}
+	for _, step := range status.Steps {
+		if step.Command == CommandRollback && step.State == StepNotStarted {
+			continue
+		}
+		data = append(data, []string{string(step.Command), string(step.State), step.Job, formatTime(step.Started), formatTime(step.Completed)})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	pterm.Println()
+
+	if len(status.Next) == 0 {
+		if status.running() {
+			pterm.Info.Println("A migration job is running. Wait for it to complete before running the next step.")
+			ui.CmdStyle.Printfln("\tkubectl logs -f -l migrator.nais.io/migration-name=%s", status.MigrationName)
+		} else {
+			pterm.Warning.Println("No further steps can be run. Contact nais team if the migration is stuck.")
+		}
+		return nil
+	}
+
+	pterm.Println("Next allowed steps:")
+	for _, command := range status.Next {
+		ui.CmdStyle.Printfln("\tnais postgres migrate %s %s %s", command, m.cfg.AppName, status.TargetInstance)
+	}
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,30 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nais/cli/internal/k8s"
+	"github.com/nais/cli/internal/postgres/migrate"
+	"github.com/nais/cli/internal/postgres/migrate/config"
+)
+
+func Run(ctx context.Context, applicationName, team, environment string) error {
+	cfg := config.Config{
+		AppName: applicationName,
+		Team:    team,
+	}
+
+	client := k8s.SetupControllerRuntimeClient(k8s.WithKubeContext(environment))
+	clientSet, err := k8s.SetupClientGo(environment)
+	if err != nil {
+		return err
+	}
+
+	migrator := migrate.NewMigrator(client, clientSet, cfg, false, false)
+	if err := migrator.Status(ctx); err != nil {
+		return fmt.Errorf("error getting migration status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package migrate_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/migrate"
+	liberatorscheme "github.com/nais/liberator/pkg/scheme"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrl_fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetStatus(t *testing.T) {
+	const migrationName = "migration-my-app-target-instance"
+
+	cfgMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      migrationName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"migrator.nais.io/app-name":       "my-app",
+				"migrator.nais.io/migration-name": migrationName,
+			},
+		},
+		Data: map[string]string{
+			"SOURCE_INSTANCE_NAME": sourceName,
+			"TARGET_INSTANCE_NAME": targetName,
+		},
+	}
+
+	job := func(command migrate.Command, state migrate.StepState, created time.Time) *batchv1.Job {
+		j := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              migrationName + "-" + string(command) + "-" + created.Format("150405"),
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					"migrator.nais.io/migration-name": migrationName,
+					"migrator.nais.io/command":        string(command),
+				},
+			},
+		}
+		switch state {
+		case migrate.StepSucceeded:
+			j.Status.Succeeded = 1
+		case migrate.StepFailed:
+			j.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		default:
+			j.Status.Active = 1
+		}
+		return j
+	}
+
+	now := time.Now().Truncate(time.Second)
+	tests := map[string]struct {
+		objects []ctrl.Object
+		phase   migrate.Phase
+		next    []migrate.Command
+	}{
+		"no migration": {
+			phase: migrate.PhaseNone,
+			next:  []migrate.Command{migrate.CommandSetup},
+		},
+		"setup running": {
+			objects: []ctrl.Object{cfgMap, job(migrate.CommandSetup, migrate.StepRunning, now)},
+			phase:   migrate.PhaseSettingUp,
+		},
+		"setup done": {
+			objects: []ctrl.Object{cfgMap, job(migrate.CommandSetup, migrate.StepSucceeded, now)},
+			phase:   migrate.PhaseReplicating,
+			next:    []migrate.Command{migrate.CommandPromote, migrate.CommandRollback},
+		},
+		"promote failed": {
+			objects: []ctrl.Object{
+				cfgMap,
+				job(migrate.CommandSetup, migrate.StepSucceeded, now.Add(-2*time.Hour)),
+				job(migrate.CommandPromote, migrate.StepFailed, now),
+			},
+			phase: migrate.PhasePromoteFailed,
+			next:  []migrate.Command{migrate.CommandRollback},
+		},
+		"latest promote job decides": {
+			objects: []ctrl.Object{
+				cfgMap,
+				job(migrate.CommandSetup, migrate.StepSucceeded, now.Add(-2*time.Hour)),
+				job(migrate.CommandPromote, migrate.StepFailed, now.Add(-time.Hour)),
+				job(migrate.CommandPromote, migrate.StepSucceeded, now),
+			},
+			phase: migrate.PhasePromoted,
+			next:  []migrate.Command{migrate.CommandFinalize, migrate.CommandRollback},
+		},
+		"finalizing": {
+			objects: []ctrl.Object{
+				cfgMap,
+				job(migrate.CommandSetup, migrate.StepSucceeded, now.Add(-2*time.Hour)),
+				job(migrate.CommandPromote, migrate.StepSucceeded, now.Add(-time.Hour)),
+				job(migrate.CommandFinalize, migrate.StepRunning, now),
+			},
+			phase: migrate.PhaseFinalizing,
+		},
+		"finalize failed": {
+			objects: []ctrl.Object{
+				cfgMap,
+				job(migrate.CommandSetup, migrate.StepSucceeded, now.Add(-2*time.Hour)),
+				job(migrate.CommandPromote, migrate.StepSucceeded, now.Add(-time.Hour)),
+				job(migrate.CommandFinalize, migrate.StepFailed, now),
+			},
+			phase: migrate.PhaseFinalizeFailed,
+			next:  []migrate.Command{migrate.CommandFinalize},
+		},
+		"rollback failed": {
+			objects: []ctrl.Object{
+				cfgMap,
+				job(migrate.CommandSetup, migrate.StepSucceeded, now.Add(-time.Hour)),
+				job(migrate.CommandRollback, migrate.StepFailed, now),
+			},
+			phase: migrate.PhaseRollbackFailed,
+			next:  []migrate.Command{migrate.CommandRollback},
+		},
+	}
+
+	scheme, err := liberatorscheme.All()
+	if err != nil {
+		t.Fatalf("failed to create scheme: %v", err)
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := ctrl_fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			status, err := migrate.GetStatus(context.Background(), client, "my-app", namespace)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.Phase != tc.phase {
+				t.Errorf("expected phase %q, got %q", tc.phase, status.Phase)
+			}
+			if !slices.Equal(status.Next, tc.next) {
+				t.Errorf("expected next %v, got %v", tc.next, status.Next)
+			}
+		})
+	}
+}
+
+func TestStatus_Allows(t *testing.T) {
+	status := &migrate.Status{
+		AppName:        "my-app",
+		TargetInstance: targetName,
+		Phase:          migrate.PhaseReplicating,
+		Next:           []migrate.Command{migrate.CommandPromote, migrate.CommandRollback},
+	}
+
+	if err := status.Allows(migrate.CommandPromote, targetName); err != nil {
+		t.Errorf("expected promote to be allowed, got %v", err)
+	}
+
+	err := status.Allows(migrate.CommandFinalize, targetName)
+	if err == nil || !strings.Contains(err.Error(), "the next step is promote or rollback") {
+		t.Errorf("expected finalize to be refused with the next step, got %v", err)
+	}
+
+	err = status.Allows(migrate.CommandPromote, "other-instance")
+	if err == nil || !strings.Contains(err.Error(), "targets instance") {
+		t.Errorf("expected a different target instance to be refused, got %v", err)
+	}
+
+	none := &migrate.Status{AppName: "my-app", Phase: migrate.PhaseNone, Next: []migrate.Command{migrate.CommandSetup}}
+	err = none.Allows(migrate.CommandPromote, targetName)
+	if err == nil || !strings.Contains(err.Error(), "no migration in progress") {
+		t.Errorf("expected promote without a migration to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+)
+
+const (
+	instanceUserRole          = "roles/cloudsql.instanceUser"
+	instanceUserGrantDuration = 1 * time.Hour
+
+	// grantRefreshMargin is how long before expiry a temporary grant is renewed.
+	grantRefreshMargin = 10 * time.Minute
+	// grantRetryInterval is how long to wait before retrying a failed renewal.
+	grantRetryInterval = 1 * time.Minute
+)
+
+// ProxyTarget is a database to proxy, given on the command line as [ENVIRONMENT/]APP_NAME.
+type ProxyTarget struct {
+	Environment string
+	AppName     string
+}
+
+func (t ProxyTarget) String() string {
+	return t.Environment + "/" + t.AppName
+}
+
+// ParseProxyTarget parses a target in the form [ENVIRONMENT/]APP_NAME. The default environment is used when the target
+// does not include one.
+func ParseProxyTarget(s, defaultEnvironment string) (ProxyTarget, error) {
+	environment, appName, found := strings.Cut(s, "/")
+	if !found {
+		environment, appName = defaultEnvironment, s
+	}
+
+	if appName == "" {
+		return ProxyTarget{}, fmt.Errorf("invalid target %q: missing application name", s)
+	}
+	if environment == "" {
+		return ProxyTarget{}, fmt.Errorf("invalid target %q: missing environment, use ENVIRONMENT/APP_NAME or the -e, --environment flag", s)
+	}
+
+	return ProxyTarget{Environment: environment, AppName: appName}, nil
+}
+
+// postgresFlags returns a copy of the flags with the environment set to the environment of the target.
+func (t ProxyTarget) postgresFlags(fl *flag.Postgres) *flag.Postgres {
+	additional := flags.AdditionalFlags{}
+	if fl.AdditionalFlags != nil {
+		additional = *fl.AdditionalFlags
+	}
+	additional.Environment = flags.Environment(t.Environment)
+
+	return &flag.Postgres{
+		GlobalFlags: &flags.GlobalFlags{
+			GlobalFlags:     fl.GlobalFlags.GlobalFlags,
+			AdditionalFlags: &additional,
+		},
+		Reason: fl.Reason,
+	}
+}
+
+// proxyStats holds connection counters for a single proxy.
+type proxyStats struct {
+	activeClients atomic.Int64
+	totalClients  atomic.Int64
+	// bytesIn is the number of bytes sent from clients to the database, and bytesOut the number of bytes sent back.
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+}
+
+type countingReader struct {
+	r     io.Reader
+	count *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.count.Add(int64(n))
+	return n, err
+}
+
+// grantKeeper grants the current user temporary access to Cloud SQL instances, and renews the grants before they expire
+// for as long as the proxy is running. Each project is only granted once, no matter how many proxies use it.
+type grantKeeper struct {
+	grant  func(ctx context.Context, projectID string) (time.Time, error)
+	margin time.Duration
+	retry  time.Duration
+	out    *naistrix.OutputWriter
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+	pending map[string]*pendingGrant
+}
+
+// pendingGrant is a grant that is being made, or has been made, for a project. done is closed when the grant is made.
+type pendingGrant struct {
+	done chan struct{}
+	err  error
+}
+
+func newGrantKeeper(out *naistrix.OutputWriter) *grantKeeper {
+	return &grantKeeper{
+		grant: func(ctx context.Context, projectID string) (time.Time, error) {
+			return grantUserAccess(ctx, projectID, instanceUserRole, instanceUserGrantDuration, out)
+		},
+		margin:  grantRefreshMargin,
+		retry:   grantRetryInterval,
+		out:     out,
+		expires: map[string]time.Time{},
+		pending: map[string]*pendingGrant{},
+	}
+}
+
+// keep grants access to the project, unless it has already been granted, and keeps renewing it until ctx is done. The
+// lock is not held while granting, so the status endpoint is not blocked by gcloud. Concurrent calls for the same project
+// wait for the first one, and a failed grant is retried by the next call.
+func (g *grantKeeper) keep(ctx context.Context, projectID string) error {
+	g.mu.Lock()
+	if p, ok := g.pending[projectID]; ok {
+		g.mu.Unlock()
+		select {
+		case <-p.done:
+			return p.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	p := &pendingGrant{done: make(chan struct{})}
+	g.pending[projectID] = p
+	g.mu.Unlock()
+
+	expires, err := g.grant(ctx, projectID)
+
+	g.mu.Lock()
+	if err != nil {
+		delete(g.pending, projectID)
+	} else {
+		g.expires[projectID] = expires
+	}
+	p.err = err
+	close(p.done)
+	g.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if !expires.IsZero() {
+		go g.renew(ctx, projectID, expires)
+	}
+	return nil
+}
+
+func (g *grantKeeper) renew(ctx context.Context, projectID string, expires time.Time) {
+	next := expires.Add(-g.margin)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		newExpires, err := g.grant(ctx, projectID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			g.out.Warnf("Unable to renew database access in project %s, retrying in %s: %v\n", projectID, g.retry, err)
+			next = time.Now().Add(g.retry)
+			continue
+		}
+
+		g.mu.Lock()
+		g.expires[projectID] = newExpires
+		g.mu.Unlock()
+
+		if newExpires.IsZero() {
+			return
+		}
+		g.out.Verbosef("Renewed database access in project %s until %s\n", projectID, newExpires.Local().Format(time.TimeOnly))
+		next = newExpires.Add(-g.margin)
+	}
+}
+
+// expiry returns when the grant for the project expires, or the zero time if it does not.
+func (g *grantKeeper) expiry(projectID string) time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.expires[projectID]
+}
+
+// multiProxy is one of the proxies started by [RunMultiProxy].
+type multiProxy struct {
+	target    ProxyTarget
+	address   string
+	projectID string
+	database  string
+	stats     *proxyStats
+}
+
+// ProxyStatus is the status of a single proxy, as reported by the status endpoint.
+type ProxyStatus struct {
+	Environment   string     `json:"environment"`
+	App           string     `json:"app"`
+	Address       string     `json:"address"`
+	Database      string     `json:"database"`
+	ActiveClients int64      `json:"active_clients"`
+	TotalClients  int64      `json:"total_clients"`
+	BytesIn       int64      `json:"bytes_in"`
+	BytesOut      int64      `json:"bytes_out"`
+	GrantExpires  *time.Time `json:"grant_expires,omitempty"`
+}
+
+func statusHandler(proxies []*multiProxy, grants *grantKeeper) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ret := make([]ProxyStatus, 0, len(proxies))
+		for _, p := range proxies {
+			status := ProxyStatus{
+				Environment:   p.target.Environment,
+				App:           p.target.AppName,
+				Address:       p.address,
+				Database:      p.database,
+				ActiveClients: p.stats.activeClients.Load(),
+				TotalClients:  p.stats.totalClients.Load(),
+				BytesIn:       p.stats.bytesIn.Load(),
+				BytesOut:      p.stats.bytesOut.Load(),
+			}
+			if p.projectID != "" {
+				if expires := grants.expiry(p.projectID); !expires.IsZero() {
+					status.GrantExpires = &expires
+				}
+			}
+			ret = append(ret, status)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(ret)
+	})
+}
+
+// RunMultiProxy starts proxies to several databases in one process, on consecutive ports starting at the port given
+// in the flags, together with a local HTTP endpoint reporting the status of each proxy. It returns when all proxies have
+// stopped, with the errors of the proxies that failed.
+func RunMultiProxy(ctx context.Context, team string, targets []ProxyTarget, fl *flag.Proxy, out *naistrix.OutputWriter) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	grants := newGrantKeeper(out)
+	proxies := make([]*multiProxy, 0, len(targets))
+	dbs := make([]DB, 0, len(targets))
+	for i, target := range targets {
+		sv, err := GetSecretValuesWithUserReason(ctx, target.AppName, team, target.Environment, target.postgresFlags(fl.Postgres), fl.Reason, out)
+		if err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+
+		db, err := NewDBInfo(ctx, target.AppName, team, target.Environment)
+		if err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		db.SetSecretValues(sv)
+
+		connectionInfo, err := db.DBConnection(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+
+		p := &multiProxy{
+			target:   target,
+			address:  net.JoinHostPort(fl.Host, strconv.Itoa(int(fl.Port)+i)),
+			database: connectionInfo.dbName,
+			stats:    &proxyStats{},
+		}
+		if cloudSQL, err := db.ToCloudSQLDBInfo(); err == nil {
+			if p.projectID, err = cloudSQL.ProjectID(ctx); err != nil {
+				return fmt.Errorf("%s: %w", target, err)
+			}
+		}
+
+		proxies = append(proxies, p)
+		dbs = append(dbs, db)
+	}
+
+	statusPort := fl.StatusPort
+	if statusPort == 0 {
+		statusPort = fl.Port + uint(len(targets))
+	}
+	statusAddress := net.JoinHostPort(fl.Host, strconv.Itoa(int(statusPort)))
+
+	rows := [][]string{{"Environment", "App", "Address", "Database"}}
+	for _, p := range proxies {
+		rows = append(rows, []string{p.target.Environment, p.target.AppName, p.address, p.database})
+	}
+	if err := out.Table().Render(rows); err != nil {
+		return err
+	}
+	out.Println()
+	out.Printf("Status is available at http://%s/\n", statusAddress)
+
+	server := &http.Server{
+		Addr:              statusAddress,
+		Handler:           statusHandler(proxies, grants),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			out.Warnf("Unable to start status endpoint: %v\n", err)
+		}
+	}()
+	defer func() { _ = server.Close() }()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	wg := sync.WaitGroup{}
+	for i, p := range proxies {
+		wg.Go(func() {
+			var err error
+			if cloudSQL, cerr := dbs[i].ToCloudSQLDBInfo(); cerr == nil {
+				var connectionName string
+				connectionName, err = cloudSQL.ConnectionName(ctx)
+				if err == nil {
+					err = runProxy(ctx, p.projectID, connectionName, p.address, make(chan int, 1), p.stats, grants, out)
+				}
+			} else {
+				port := fl.Port + uint(i)
+				err = dbs[i].RunProxy(ctx, fl.Host, &port, make(chan int, 1), out, false)
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				out.Errorf("Proxy for %s stopped: %v\n", p.target, err)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", p.target, err))
+				mu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
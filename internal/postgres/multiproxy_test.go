@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nais/naistrix"
+)
+
+func TestParseProxyTarget(t *testing.T) {
+	tests := []struct {
+		input   string
+		env     string
+		want    ProxyTarget
+		wantErr bool
+	}{
+		{input: "my-app", env: "dev", want: ProxyTarget{Environment: "dev", AppName: "my-app"}},
+		{input: "prod/my-app", env: "dev", want: ProxyTarget{Environment: "prod", AppName: "my-app"}},
+		{input: "prod/my-app", want: ProxyTarget{Environment: "prod", AppName: "my-app"}},
+		{input: "my-app", wantErr: true},
+		{input: "prod/", env: "dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseProxyTarget(tt.input, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProxyTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseProxyTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrantKeeperRenewsBeforeExpiry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var grants atomic.Int32
+	g := newGrantKeeper(naistrix.NewOutputWriter(io.Discard, new(naistrix.Count)))
+	g.margin = 40 * time.Millisecond
+	g.grant = func(context.Context, string) (time.Time, error) {
+		grants.Add(1)
+		return time.Now().Add(50 * time.Millisecond), nil
+	}
+
+	if err := g.keep(ctx, "project"); err != nil {
+		t.Fatal(err)
+	}
+	// A second proxy in the same project must not grant again.
+	if err := g.keep(ctx, "project"); err != nil {
+		t.Fatal(err)
+	}
+	if n := grants.Load(); n != 1 {
+		t.Fatalf("expected 1 grant, got %d", n)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for grants.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := grants.Load(); n < 3 {
+		t.Errorf("expected the grant to be renewed, got %d grants", n)
+	}
+	if g.expiry("project").IsZero() {
+		t.Error("expected grant expiry to be recorded")
+	}
+}
+
+func TestGrantKeeperDoesNotLockWhileGranting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	var grants atomic.Int32
+	g := newGrantKeeper(naistrix.NewOutputWriter(io.Discard, new(naistrix.Count)))
+	g.grant = func(context.Context, string) (time.Time, error) {
+		grants.Add(1)
+		<-release
+		return time.Time{}, nil
+	}
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() { errs <- g.keep(ctx, "project") }()
+	}
+
+	expired := make(chan struct{})
+	go func() {
+		g.expiry("project")
+		close(expired)
+	}()
+	select {
+	case <-expired:
+	case <-time.After(time.Second):
+		t.Fatal("expiry blocked while a grant was in progress")
+	}
+
+	close(release)
+	for range 2 {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := grants.Load(); n != 1 {
+		t.Errorf("expected 1 grant, got %d", n)
+	}
+}
+
+func TestStatusHandler(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	g := newGrantKeeper(naistrix.NewOutputWriter(io.Discard, new(naistrix.Count)))
+	g.expires["project"] = expires
+
+	p := &multiProxy{
+		target:    ProxyTarget{Environment: "dev", AppName: "my-app"},
+		address:   "localhost:5432",
+		projectID: "project",
+		database:  "my-db",
+		stats:     &proxyStats{},
+	}
+	p.stats.activeClients.Add(2)
+	p.stats.totalClients.Add(3)
+	if _, err := io.Copy(io.Discard, &countingReader{r: strings.NewReader("hello"), count: &p.stats.bytesIn}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	statusHandler([]*multiProxy{p}, g).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	var statuses []ProxyStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &statuses); err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(statuses))
+	}
+
+	s := statuses[0]
+	if s.App != "my-app" || s.ActiveClients != 2 || s.TotalClients != 3 || s.BytesIn != 5 {
+		t.Errorf("unexpected status %+v", s)
+	}
+	if s.GrantExpires == nil || !s.GrantExpires.Equal(expires) {
+		t.Errorf("expected grant expiry %v, got %v", expires, s.GrantExpires)
+	}
+}
@@ -47,7 +47,7 @@ func RotatePassword(ctx context.Context, appName, team, environment string, fl *
 	}
 
 	out.Println("Grant user cloudsql.admin access for 5 minutes")
-	err = grantUserAccess(ctx, projectID, "roles/cloudsql.admin", 5*time.Minute, out)
+	_, err = grantUserAccess(ctx, projectID, "roles/cloudsql.admin", 5*time.Minute, out)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,400 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+	"golang.org/x/term"
+)
+
+// OpenDatabase opens a connection to the database of an application as the application user, through the in-process
+// Cloud SQL driver. The secret values are retrieved with the given reason, which is logged for audit purposes.
+func OpenDatabase(ctx context.Context, appName, team, environment string, fl *flag.Postgres, reason string, out *naistrix.OutputWriter) (*sql.DB, *ConnectionInfo, error) {
+	sv, err := GetSecretValuesWithUserReason(ctx, appName, team, environment, fl, reason, out)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := dbInfo.ToCloudSQLDBInfo(); err != nil {
+		return nil, nil, fmt.Errorf("only Cloud SQL databases are supported, use 'nais postgres proxy' for other databases: %w", err)
+	}
+
+	dbInfo.SetSecretValues(sv)
+
+	connectionInfo, err := dbInfo.DBConnection(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open("cloudsqlpostgres", connectionInfo.ProxyConnectionString())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, connectionInfo, nil
+}
+
+// RunQuery runs SQL statements against the database of an application. Statements are read from the command flag, a
+// file, or stdin. When none is given and stdin is a terminal, an interactive prompt is started instead.
+func RunQuery(ctx context.Context, appName, team, environment string, fl *flag.Query, out *naistrix.OutputWriter) error {
+	script, interactive, err := queryScript(fl)
+	if err != nil {
+		return err
+	}
+
+	db, connectionInfo, err := OpenDatabase(ctx, appName, team, environment, fl.Postgres, fl.Reason, out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	q := &querier{db: db, write: fl.Write, timeout: fl.Timeout, format: fl.Output}
+	if interactive {
+		return runREPL(ctx, q, connectionInfo.dbName, historyFile(), out)
+	}
+
+	return q.run(ctx, script, out)
+}
+
+func queryScript(fl *flag.Query) (script string, interactive bool, err error) {
+	switch {
+	case fl.Command != "":
+		return fl.Command, false, nil
+	case fl.File != "":
+		b, err := os.ReadFile(fl.File)
+		if err != nil {
+			return "", false, fmt.Errorf("reading %s: %w", fl.File, err)
+		}
+		return string(b), false, nil
+	case term.IsTerminal(int(os.Stdin.Fd())): // #nosec G115
+		return "", true, nil
+	default:
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", false, fmt.Errorf("reading statements from stdin: %w", err)
+		}
+		return string(b), false, nil
+	}
+}
+
+// querier runs statements in a transaction, which is read-only unless writes are allowed.
+type querier struct {
+	db      *sql.DB
+	write   bool
+	timeout time.Duration
+	format  flag.QueryOutput
+}
+
+// queryResult is the result of a single statement.
+type queryResult struct {
+	columns []string
+	rows    [][]any
+}
+
+func (q *querier) run(ctx context.Context, script string, out *naistrix.OutputWriter) error {
+	results, err := q.exec(ctx, script)
+	if err != nil {
+		return formatInvalidGrantError(err)
+	}
+
+	for _, result := range results {
+		if err := renderResult(result, q.format, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *querier) exec(ctx context.Context, script string) ([]queryResult, error) {
+	if !q.write {
+		if stmt, ok := transactionControl(script); ok {
+			return nil, fmt.Errorf("%q is not allowed in read-only mode, use --write to run statements that change the database", stmt)
+		}
+	}
+
+	// The settings are also made for the session, so that they still apply if a statement manages to end the
+	// transaction.
+	conn, err := q.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	timeout := strconv.FormatInt(q.timeout.Milliseconds(), 10)
+	if _, err := conn.ExecContext(ctx, "SET SESSION statement_timeout = "+timeout); err != nil {
+		return nil, fmt.Errorf("setting statement timeout: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SET SESSION default_transaction_read_only = "+strconv.FormatBool(!q.write)); err != nil {
+		return nil, fmt.Errorf("setting read-only mode: %w", err)
+	}
+
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: !q.write})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Without arguments the statements are sent with the simple query protocol, which allows several statements in
+	// one script, each with its own result set.
+	rows, err := tx.QueryContext(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []queryResult
+	for {
+		result, err := readResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if q.write {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func readResult(rows *sql.Rows) (queryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return queryResult{}, err
+	}
+
+	result := queryResult{columns: columns}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return queryResult{}, err
+		}
+
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.rows = append(result.rows, values)
+	}
+
+	return result, rows.Err()
+}
+
+func renderResult(result queryResult, format flag.QueryOutput, out *naistrix.OutputWriter) error {
+	if len(result.columns) == 0 {
+		if format == flag.QueryOutputTable {
+			out.Println("OK")
+		}
+		return nil
+	}
+
+	switch format {
+	case flag.QueryOutputJSON:
+		ret := make([]map[string]any, 0, len(result.rows))
+		for _, row := range result.rows {
+			m := make(map[string]any, len(row))
+			for i, v := range row {
+				m[result.columns[i]] = v
+			}
+			ret = append(ret, m)
+		}
+		return out.JSON(output.JSONWithPrettyOutput()).Render(ret)
+
+	case flag.QueryOutputCSV:
+		buf := &bytes.Buffer{}
+		w := csv.NewWriter(buf)
+		_ = w.Write(result.columns)
+		for _, row := range result.rows {
+			_ = w.Write(formatRow(row, ""))
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		out.Printf("%s", buf.String())
+		return nil
+
+	default:
+		if len(result.rows) == 0 {
+			out.Printf("(0 rows)\n")
+			return nil
+		}
+
+		tbl := [][]string{result.columns}
+		for _, row := range result.rows {
+			tbl = append(tbl, formatRow(row, "NULL"))
+		}
+		if err := out.Table().Render(tbl); err != nil {
+			return err
+		}
+		out.Printf("(%d rows)\n", len(result.rows))
+		return nil
+	}
+}
+
+func formatRow(row []any, null string) []string {
+	ret := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+			ret[i] = null
+		case time.Time:
+			ret[i] = v.Format(time.RFC3339Nano)
+		default:
+			ret[i] = fmt.Sprint(v)
+		}
+	}
+	return ret
+}
+
+// statementComplete reports whether the input ends a statement, i.e. ends with a semicolon outside of quotes.
+func statementComplete(input string) bool {
+	var quote rune
+	last := rune(0)
+	for _, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		}
+		if !strings.ContainsRune(" \t\r\n", r) {
+			last = r
+		}
+	}
+	return quote == 0 && last == ';'
+}
+
+// transactionControl returns the first statement in the script that ends the transaction it runs in or changes its
+// read-only mode, which would let the following statements run outside of the read-only transaction.
+func transactionControl(script string) (string, bool) {
+	for _, stmt := range splitStatements(script) {
+		words := strings.Fields(strings.ToLower(stmt))
+		if len(words) == 0 {
+			continue
+		}
+
+		switch words[0] {
+		case "begin", "start", "commit", "end", "rollback", "abort", "prepare":
+			if words[0] == "prepare" && (len(words) < 2 || words[1] != "transaction") {
+				continue
+			}
+			return stmt, true
+		case "set", "reset":
+			rest := strings.Join(words[1:], " ")
+			if strings.HasPrefix(rest, "session characteristics") || strings.Contains(rest, "read_only") || strings.Contains(rest, "read write") || rest == "all" {
+				return stmt, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitStatements splits a script into statements on semicolons outside of quotes, dollar quotes and comments. Comments
+// are removed from the statements.
+func splitStatements(script string) []string {
+	var (
+		ret  []string
+		stmt strings.Builder
+	)
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			i += end
+			stmt.WriteByte(' ')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				end = len(script) - i
+			}
+			i += end + 3
+			stmt.WriteByte(' ')
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(script[i+1:], c)
+			if end < 0 {
+				stmt.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			stmt.WriteString(script[i : i+end+2])
+			i += end + 1
+		case c == '$':
+			tag, ok := dollarTag(script[i:])
+			if !ok {
+				stmt.WriteByte(c)
+				continue
+			}
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				stmt.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			n := len(tag) + end + len(tag)
+			stmt.WriteString(script[i : i+n])
+			i += n - 1
+		case c == ';':
+			ret = append(ret, strings.TrimSpace(stmt.String()))
+			stmt.Reset()
+		default:
+			stmt.WriteByte(c)
+		}
+	}
+	if s := strings.TrimSpace(stmt.String()); s != "" {
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// dollarTag returns the opening tag of a dollar-quoted string, such as $$ or $body$, at the start of s.
+func dollarTag(s string) (string, bool) {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1], true
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 1 && c >= '0' && c <= '9'):
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
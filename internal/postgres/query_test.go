@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+)
+
+func TestStatementComplete(t *testing.T) {
+	tests := map[string]bool{
+		"select 1;":                 true,
+		"select 1;  \n":             true,
+		"select 1":                  false,
+		"select ';'":                false,
+		"select ';';":               true,
+		`select "a;b" from t`:       false,
+		"insert into t values ('a":  false,
+		"insert into t values ('a;": false,
+	}
+
+	for input, want := range tests {
+		if got := statementComplete(input); got != want {
+			t.Errorf("statementComplete(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestRenderResult(t *testing.T) {
+	result := queryResult{
+		columns: []string{"id", "name", "created"},
+		rows: [][]any{
+			{int64(1), "alice", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{int64(2), nil, nil},
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := renderResult(result, flag.QueryOutputCSV, naistrix.NewOutputWriter(buf, new(naistrix.Count))); err != nil {
+			t.Fatal(err)
+		}
+		want := "id,name,created\n1,alice,2024-01-02T03:04:05Z\n2,,\n"
+		if buf.String() != want {
+			t.Errorf("got %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("no columns", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := renderResult(queryResult{}, flag.QueryOutputCSV, naistrix.NewOutputWriter(buf, new(naistrix.Count))); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got %q", buf.String())
+		}
+	})
+}
+
+func TestFileHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	h := loadHistory(path)
+	h.Add("select 1;")
+	h.Add("  ")
+	h.Add("select 2;")
+
+	h = loadHistory(path)
+	if h.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", h.Len())
+	}
+	if got := h.At(0); got != "select 2;" {
+		t.Errorf("expected most recent entry first, got %q", got)
+	}
+
+	for i := range maxHistoryEntries {
+		h.Add("select " + strings.Repeat("x", i%3) + ";")
+	}
+	if h := loadHistory(path); h.Len() != maxHistoryEntries {
+		t.Errorf("expected history to be capped at %d entries, got %d", maxHistoryEntries, h.Len())
+	}
+}
+
+func TestTransactionControl(t *testing.T) {
+	tests := map[string]bool{
+		"select 1;":                    false,
+		"COMMIT; DELETE FROM t;":       true,
+		"select 1; end; delete from t": true,
+		"rollback":                     true,
+		"BEGIN; select 1":              true,
+		"prepare transaction 'x'":      true,
+		"prepare q as select 1":        false,
+		"set session characteristics as transaction read write": true,
+		"set default_transaction_read_only = off":               true,
+		"reset all":                             true,
+		"set search_path = public":              false,
+		"select 'x; commit'":                    false,
+		"select 1 -- ; commit\n":                false,
+		"select 1 /* ; commit */":               false,
+		"do $body$ begin perform 1; end $body$": false,
+		`select "commit; end" from t`:           false,
+		"select 1; /* comment */ commit":        true,
+		"create function f() returns int as $$ select 1; $$ language sql": false,
+	}
+
+	for input, want := range tests {
+		if _, got := transactionControl(input); got != want {
+			t.Errorf("transactionControl(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
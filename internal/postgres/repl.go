@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nais/naistrix"
+	"golang.org/x/term"
+)
+
+const maxHistoryEntries = 500
+
+// runREPL reads statements from the terminal and runs each of them in its own transaction, until the user quits.
+func runREPL(ctx context.Context, q *querier, dbName, historyPath string, out *naistrix.OutputWriter) error {
+	fd := int(os.Stdin.Fd()) // #nosec G115
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("setting terminal to raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	prompt := dbName + "=> "
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, prompt)
+	t.History = loadHistory(historyPath)
+	if w, h, err := term.GetSize(fd); err == nil {
+		_ = t.SetSize(w, h)
+	}
+
+	// Output is written through the terminal, which translates newlines while in raw mode.
+	termOut := naistrix.NewOutputWriter(t, new(naistrix.Count))
+	mode := "read-only"
+	if q.write {
+		mode = "read-write"
+	}
+	termOut.Printf("Connected to %s (%s). End statements with ';', and type \\q to quit.\n", dbName, mode)
+
+	var statement strings.Builder
+	for {
+		line, err := t.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if statement.Len() == 0 {
+			switch strings.TrimSpace(line) {
+			case "":
+				continue
+			case `\q`, "quit", "exit":
+				return nil
+			}
+		}
+
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if !statementComplete(statement.String()) {
+			t.SetPrompt(dbName + "-> ")
+			continue
+		}
+
+		if err := q.run(ctx, statement.String(), termOut); err != nil {
+			termOut.Errorf("%v\n", err)
+		}
+		statement.Reset()
+		t.SetPrompt(prompt)
+	}
+}
+
+// historyFile returns the path of the file where statements entered at the interactive prompt are kept. History is
+// not persisted if the user configuration directory cannot be created.
+func historyFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	dir = filepath.Join(dir, "nais")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "postgres_history")
+}
+
+// fileHistory is a [term.History] that is persisted to a file, so that it survives between sessions.
+type fileHistory struct {
+	path    string
+	entries []string
+}
+
+func loadHistory(path string) *fileHistory {
+	h := &fileHistory{path: path}
+	if path == "" {
+		return h
+	}
+
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return h
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) > maxHistoryEntries {
+		h.entries = h.entries[len(h.entries)-maxHistoryEntries:]
+	}
+	return h
+}
+
+func (h *fileHistory) Add(entry string) {
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > maxHistoryEntries {
+		h.entries = h.entries[1:]
+	}
+
+	if h.path == "" {
+		return
+	}
+
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) // #nosec G304
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintln(f, entry)
+	_ = f.Close()
+}
+
+func (h *fileHistory) Len() int {
+	return len(h.entries)
+}
+
+func (h *fileHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
@@ -17,14 +17,18 @@ import (
 
 // Hardcoded reasons for administrative operations
 const (
-	ReasonPasswordRotate = "Rotating database password via nais CLI"
-	ReasonPrepareAccess  = "Preparing database for IAM user access via nais CLI"
-	ReasonRevokeAccess   = "Revoking IAM user access from database via nais CLI"
-	ReasonListUsers      = "Listing database users via nais CLI"
-	ReasonAddUser        = "Adding database user via nais CLI"
-	ReasonDropUser       = "Dropping database user via nais CLI"
-	ReasonEnableAudit    = "Enabling audit logging via nais CLI"
-	ReasonVerifyAudit    = "Verifying audit configuration via nais CLI"
+	ReasonPasswordRotate      = "Rotating database password via nais CLI"
+	ReasonPrepareAccess       = "Preparing database for IAM user access via nais CLI"
+	ReasonRevokeAccess        = "Revoking IAM user access from database via nais CLI"
+	ReasonRevokeExpiredAccess = "Revoking expired IAM user access from database via nais CLI"
+	ReasonListUsers           = "Listing database users via nais CLI"
+	ReasonAddUser             = "Adding database user via nais CLI"
+	ReasonDropUser            = "Dropping database user via nais CLI"
+	ReasonEnableAudit         = "Enabling audit logging via nais CLI"
+	ReasonVerifyAudit         = "Verifying audit configuration via nais CLI"
+	ReasonInspect             = "Inspecting database schemas and privileges via nais CLI"
+	ReasonMigrationCheck      = "Checking database before migration via nais CLI"
+	ReasonTop                 = "Viewing query statistics via nais CLI"
 )
 
 // Default duration for in-cluster postgres access grants
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+	"golang.org/x/term"
+)
+
+// topQueryWidth is the number of characters of each statement shown in table output.
+const topQueryWidth = 80
+
+// Milliseconds is a duration in milliseconds, as reported by pg_stat_statements.
+type Milliseconds float64
+
+func (m Milliseconds) String() string {
+	return fmt.Sprintf("%.1f", float64(m))
+}
+
+// Percent is a percentage that renders with one decimal in table output.
+type Percent float64
+
+func (p Percent) String() string {
+	return fmt.Sprintf("%.1f", float64(p))
+}
+
+// TopStatement is the accumulated statistics of a normalized statement since the statistics were last reset.
+type TopStatement struct {
+	Calls     int64        `heading:"Calls" json:"calls"`
+	TotalTime Milliseconds `heading:"Total (ms)" json:"total_time_ms"`
+	MeanTime  Milliseconds `heading:"Mean (ms)" json:"mean_time_ms"`
+	Share     Percent      `heading:"% Time" json:"time_percent"`
+	Rows      int64        `heading:"Rows" json:"rows"`
+	Query     string       `heading:"Query" json:"query"`
+}
+
+// Top shows the statements of the database of an application with the highest total execution time, number of calls
+// or number of rows, from pg_stat_statements. The list is refreshed until interrupted, unless fl.Once is set.
+func Top(ctx context.Context, appName, team, environment string, fl *flag.Top, out *naistrix.OutputWriter) error {
+	// Get secret values (access is logged for audit purposes)
+	sv, err := GetSecretValues(ctx, appName, team, environment, fl.Postgres, ReasonTop, out)
+	if err != nil {
+		return err
+	}
+
+	dbInfo, err := NewDBInfo(ctx, appName, team, environment)
+	if err != nil {
+		return err
+	}
+
+	dbInfo.SetSecretValues(sv)
+
+	connectionInfo, err := dbInfo.DBConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("cloudsqlpostgres", connectionInfo.ProxyConnectionString())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	query, err := prepareTopQuery(ctx, db, fl.Sort)
+	if err != nil {
+		return formatInvalidGrantError(err)
+	}
+
+	if fl.Output == "json" {
+		statements, err := queryTopStatements(ctx, db, query, fl.Limit)
+		if err != nil {
+			return formatInvalidGrantError(err)
+		}
+		return out.JSON(output.JSONWithPrettyOutput()).Render(statements)
+	}
+
+	if fl.Once {
+		return renderTop(ctx, db, query, connectionInfo.dbName, fl, out)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	clearScreen := term.IsTerminal(int(os.Stdout.Fd())) // #nosec G115
+	ticker := time.NewTicker(fl.Interval)
+	defer ticker.Stop()
+	for {
+		if clearScreen {
+			out.Printf("\033[H\033[2J")
+		}
+		if err := renderTop(ctx, db, query, connectionInfo.dbName, fl, out); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		out.Printf("Refreshing every %s, press Ctrl+C to quit.\n", fl.Interval)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func renderTop(ctx context.Context, db *sql.DB, query, database string, fl *flag.Top, out *naistrix.OutputWriter) error {
+	statements, err := queryTopStatements(ctx, db, query, fl.Limit)
+	if err != nil {
+		return formatInvalidGrantError(err)
+	}
+
+	out.Printf("Top %d statements by %s in database %q at %s\n\n", fl.Limit, fl.Sort, database, time.Now().Format(time.TimeOnly))
+	if len(statements) == 0 {
+		out.Println("No statements recorded.")
+		return nil
+	}
+
+	for i := range statements {
+		statements[i].Query = truncateQuery(statements[i].Query, topQueryWidth)
+	}
+	return out.Table().Render(statements)
+}
+
+// prepareTopQuery checks that pg_stat_statements is installed, and returns the query for the statistics sorted by the
+// given column. The timing columns were renamed in Postgres 13.
+func prepareTopQuery(ctx context.Context, db *sql.DB, sort flag.TopSort) (string, error) {
+	var installed bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'pg_stat_statements')").Scan(&installed); err != nil {
+		return "", err
+	}
+	if !installed {
+		return "", fmt.Errorf("the pg_stat_statements extension is not installed in the database, install it with 'CREATE EXTENSION pg_stat_statements', e.g. in a database migration")
+	}
+
+	var version int
+	if err := db.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::int").Scan(&version); err != nil {
+		return "", err
+	}
+
+	return topQuery(version, sort), nil
+}
+
+func topQuery(serverVersion int, sort flag.TopSort) string {
+	totalTime, meanTime := "total_exec_time", "mean_exec_time"
+	if serverVersion < 130000 {
+		totalTime, meanTime = "total_time", "mean_time"
+	}
+
+	orderBy := totalTime
+	switch sort {
+	case flag.TopSortCalls:
+		orderBy = "calls"
+	case flag.TopSortRows:
+		orderBy = "rows"
+	}
+
+	return fmt.Sprintf(`
+		SELECT calls, %[1]s, %[2]s, rows, query,
+			coalesce(100 * %[1]s / nullif(sum(%[1]s) OVER (), 0), 0)
+		FROM pg_stat_statements
+		WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+		ORDER BY %[3]s DESC
+		LIMIT $1`, totalTime, meanTime, orderBy)
+}
+
+func queryTopStatements(ctx context.Context, db *sql.DB, query string, limit int) ([]TopStatement, error) {
+	rows, err := db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	ret := []TopStatement{}
+	for rows.Next() {
+		var s TopStatement
+		if err := rows.Scan(&s.Calls, &s.TotalTime, &s.MeanTime, &s.Rows, &s.Query, &s.Share); err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nais/cli/internal/postgres/command/flag"
+)
+
+func TestTopQuery(t *testing.T) {
+	tests := []struct {
+		version int
+		sort    flag.TopSort
+		columns string
+		orderBy string
+	}{
+		{version: 170002, sort: flag.TopSortTotalTime, columns: "total_exec_time, mean_exec_time", orderBy: "ORDER BY total_exec_time DESC"},
+		{version: 170002, sort: flag.TopSortCalls, columns: "total_exec_time, mean_exec_time", orderBy: "ORDER BY calls DESC"},
+		{version: 120015, sort: flag.TopSortTotalTime, columns: "total_time, mean_time", orderBy: "ORDER BY total_time DESC"},
+		{version: 120015, sort: flag.TopSortRows, columns: "total_time, mean_time", orderBy: "ORDER BY rows DESC"},
+	}
+
+	for _, tt := range tests {
+		got := topQuery(tt.version, tt.sort)
+		if !strings.Contains(got, "SELECT calls, "+tt.columns+", rows") {
+			t.Errorf("topQuery(%d, %s) selects wrong columns:\n%s", tt.version, tt.sort, got)
+		}
+		if !strings.Contains(got, tt.orderBy) {
+			t.Errorf("topQuery(%d, %s) does not contain %q:\n%s", tt.version, tt.sort, tt.orderBy, got)
+		}
+	}
+}
+
+func TestMillisecondsAndPercent(t *testing.T) {
+	if got := Milliseconds(1234.567).String(); got != "1234.6" {
+		t.Errorf("Milliseconds.String() = %q, want %q", got, "1234.6")
+	}
+	if got := Percent(12.34).String(); got != "12.3" {
+		t.Errorf("Percent.String() = %q, want %q", got, "12.3")
+	}
+}
@@ -0,0 +1,31 @@
+package flag
+
+import (
+	"context"
+
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/naistrix"
+)
+
+type Output string
+
+func (o *Output) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"yaml", "table"}, "Available output formats."
+}
+
+type yamlFile string
+
+var _ naistrix.FileAutoCompleter = (*yamlFile)(nil)
+
+func (yamlFile) FileExtensions() []string {
+	return []string{"yaml", "yml"}
+}
+
+type Render struct {
+	*flags.GlobalFlags
+	Output       Output   `name:"output" short:"o" usage:"Format output (yaml or table)."`
+	Mixin        yamlFile `name:"mixin" usage:"YAML |FILE| deep-merged over the manifest, as with nais apply. If omitted, an adjacent <base>.<env>.yaml is auto-loaded when present."`
+	Set          []string `name:"set" usage:"Override a single scalar field as |KEY=VALUE| using a dotted path, as with nais apply. Can be repeated."`
+	VarsFilePath yamlFile `name:"vars-file" short:"f" usage:"Path to the |FILE| containing template variables in YAML format."`
+	Vars         []string `name:"var" usage:"Template variable in |KEY=VALUE| form. Can be repeated."`
+}
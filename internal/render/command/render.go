@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/flags"
+	"github.com/nais/cli/internal/render"
+	"github.com/nais/cli/internal/render/command/flag"
+	"github.com/nais/cli/internal/validate"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func Render(parentFlags *flags.GlobalFlags) *naistrix.Command {
+	flags := &flag.Render{
+		GlobalFlags: parentFlags,
+		Output:      "yaml",
+	}
+	return &naistrix.Command{
+		Name:  "render",
+		Title: "Render the Kubernetes resources of a Nais manifest.",
+		Description: heredoc.Doc(`
+			Shows an approximation of the Deployment, Service, NetworkPolicy, HorizontalPodAutoscaler and PodDisruptionBudget that the platform generates from an Application, including the names of the environment variables it injects.
+
+			Templates in the manifest are expanded with the given variables, and the mixin of the environment is merged on top, as with nais apply. Nothing is read from the cluster, and the result is only an approximation of what is applied.
+		`),
+		Args: []naistrix.Argument{
+			{Name: "file"},
+		},
+		AutoCompleteExtensions: []string{"yaml", "yml"},
+		Flags:                  flags,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			func(context.Context, *naistrix.Arguments) error {
+				if flags.Output != "yaml" && flags.Output != "table" {
+					return fmt.Errorf("invalid output format %q, must be one of: yaml, table", flags.Output)
+				}
+				return nil
+			},
+		),
+		Examples: []naistrix.Example{
+			{
+				Description: "Render the resources of the application in the environment dev.",
+				Command:     ".nais/app.yaml --environment dev",
+			},
+			{
+				Description: "Show a summary of the resources and the environment variables of the container.",
+				Command:     ".nais/app.yaml --environment dev --vars-file .nais/dev-vars.yaml --output table",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			vars := validate.TemplateVariables{}
+			if flags.VarsFilePath != "" {
+				v, err := validate.TemplateVariablesFromFile(string(flags.VarsFilePath))
+				if err != nil {
+					return fmt.Errorf("load template variables: %w", err)
+				}
+				vars = v
+			}
+			maps.Copy(vars, validate.TemplateVariablesFromSlice(flags.Vars))
+
+			environment := string(flags.Environment)
+			app, err := render.LoadApplication(args.Get("file"), string(flags.Mixin), environment, flags.Set, vars, out)
+			if err != nil {
+				return err
+			}
+			if app.GetNamespace() == "" {
+				if flags.Team == "" {
+					out.Warnf("The Application has no namespace and no team is set, the resources are rendered without a namespace.\n")
+				}
+				app.SetNamespace(flags.Team)
+			}
+
+			result, err := render.Generate(app, environment)
+			if err != nil {
+				return err
+			}
+
+			if flags.Output == "table" {
+				resources := make([][]string, 0, len(result.Resources)+1)
+				resources = append(resources, []string{"Kind", "Name", "Summary"})
+				for _, r := range result.Resources {
+					resources = append(resources, []string{r.Kind, r.Name, r.Summary})
+				}
+				if err := out.Table().Render(resources); err != nil {
+					return err
+				}
+
+				out.Println()
+				env := make([][]string, 0, len(result.Env)+1)
+				env = append(env, []string{"Environment variable", "Source"})
+				for _, e := range result.Env {
+					env = append(env, []string{e.Name, e.Source})
+				}
+				return out.Table().Render(env)
+			}
+
+			b, err := render.YAML(result.Resources)
+			if err != nil {
+				return err
+			}
+			out.Printf("%s", b)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"slices"
+
+	"github.com/nais/cli/internal/aiven/aiven_config"
+	"github.com/nais/cli/internal/dev"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// kafkaMountPath is where the Kafka credentials are mounted in the container.
+const kafkaMountPath = "/var/run/secrets/nais.io/kafka/"
+
+// EnvVar is an environment variable of the container, and where its value comes from.
+type EnvVar struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// containerEnv returns the environment variables of the container: the ones in the manifest, followed by the ones the
+// platform injects. The names of the secrets holding credentials are approximate, as the platform adds a random suffix
+// to some of them.
+func containerEnv(app *nais_io_v1alpha1.Application, environment string) ([]corev1.EnvVar, []EnvVar) {
+	var env []corev1.EnvVar
+	var rows []EnvVar
+
+	for _, e := range app.Spec.Env {
+		env = append(env, corev1.EnvVar{Name: e.Name, Value: e.Value})
+		rows = append(rows, EnvVar{Name: e.Name, Source: "manifest"})
+	}
+
+	value := func(name, value string) {
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+		rows = append(rows, EnvVar{Name: name, Source: "platform"})
+	}
+	fromSecret := func(secret string, names ...string) {
+		for _, name := range names {
+			env = append(env, corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret}, Key: name},
+			}})
+			rows = append(rows, EnvVar{Name: name, Source: "secret " + secret})
+		}
+	}
+
+	name, namespace := app.GetName(), app.GetNamespace()
+	value("NAIS_APP_NAME", name)
+	value("NAIS_NAMESPACE", namespace)
+	value("NAIS_APP_IMAGE", app.Spec.Image)
+	value("NAIS_CLUSTER_NAME", environment)
+	value("NAIS_CLIENT_ID", environment+":"+namespace+":"+name)
+
+	if app.Spec.GCP != nil {
+		for _, instance := range app.Spec.GCP.SqlInstances {
+			databases := instance.Databases
+			if len(databases) == 0 {
+				databases = []nais_io_v1.CloudSqlDatabase{{Name: name}}
+			}
+			for _, db := range databases {
+				prefix := dev.DatabaseEnvPrefix(name, db)
+				var names []string
+				for _, suffix := range []string{"HOST", "PORT", "DATABASE", "USERNAME", "PASSWORD", "URL", "JDBC_URL", "SSLCERT", "SSLKEY", "SSLKEY_PK8", "SSLROOTCERT", "SSLMODE"} {
+					names = append(names, prefix+"_"+suffix)
+				}
+				fromSecret("google-sql-"+name, names...)
+			}
+		}
+	}
+
+	if app.Spec.Kafka != nil {
+		fromSecret("kafka-"+name+"-"+app.Spec.Kafka.Pool,
+			aiven_config.KafkaBrokersKey,
+			aiven_config.KafkaCertificateKey,
+			aiven_config.KafkaPrivateKeyKey,
+			aiven_config.KafkaCAKey,
+			aiven_config.KafkaCredStorePasswordKey,
+			aiven_config.KafkaSchemaRegistryKey,
+			aiven_config.KafkaSchemaRegistryUserKey,
+			aiven_config.KafkaSchemaRegistryPasswordKey,
+		)
+		value(aiven_config.KafkaCertificatePathKey, kafkaMountPath+aiven_config.KafkaCertificateCrtFile)
+		value(aiven_config.KafkaPrivateKeyPathKey, kafkaMountPath+aiven_config.KafkaPrivateKeyPemFile)
+		value(aiven_config.KafkaCAPathKey, kafkaMountPath+aiven_config.KafkaCACrtFile)
+		value(aiven_config.KafkaKeystorePathKey, kafkaMountPath+aiven_config.KafkaClientKeyStoreP12File)
+		value(aiven_config.KafkaTruststorePathKey, kafkaMountPath+aiven_config.KafkaClientTruststoreJksFile)
+	}
+
+	for _, v := range app.Spec.Valkey {
+		instance := dev.EnvName(v.Instance)
+		var names []string
+		for _, prefix := range []string{"VALKEY_URI_", "VALKEY_HOST_", "VALKEY_PORT_", "VALKEY_USERNAME_", "VALKEY_PASSWORD_"} {
+			names = append(names, prefix+instance)
+		}
+		fromSecret("aiven-valkey-"+v.Instance+"-"+name, names...)
+	}
+
+	if app.Spec.OpenSearch != nil {
+		fromSecret("aiven-opensearch-"+app.Spec.OpenSearch.Instance+"-"+name,
+			"OPEN_SEARCH_URI", "OPEN_SEARCH_HOST", "OPEN_SEARCH_PORT", "OPEN_SEARCH_USERNAME", "OPEN_SEARCH_PASSWORD")
+	}
+
+	// Kubernetes uses the last of variables with the same name, so the platform overrides the manifest.
+	seen := map[string]bool{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if seen[rows[i].Name] {
+			env = slices.Delete(env, i, i+1)
+			rows = slices.Delete(rows, i, i+1)
+			continue
+		}
+		seen[rows[i].Name] = true
+	}
+	return env, rows
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/nais/cli/internal/apply"
+	"github.com/nais/cli/internal/validate"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	"github.com/nais/naistrix"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadApplication reads the Application in a manifest as it would be applied to an environment: templates are expanded
+// with vars, and the mixin and --set overrides are merged on top, as by nais apply.
+func LoadApplication(path, mixin, environment string, sets []string, vars validate.TemplateVariables, out *naistrix.OutputWriter) (*nais_io_v1alpha1.Application, error) {
+	expand := func(data []byte) ([]byte, error) {
+		return validate.ExecTemplate(data, vars, out)
+	}
+
+	data, err := apply.Render(path, mixin, environment, sets, expand, out)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := validate.YAMLToJSONMessages(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var app *nais_io_v1alpha1.Application
+	for _, doc := range docs {
+		var meta metav1.TypeMeta
+		if err := json.Unmarshal(doc, &meta); err != nil {
+			return nil, err
+		}
+		if meta.Kind != "Application" {
+			continue
+		}
+		if app != nil {
+			return nil, fmt.Errorf("%s contains more than one Application", path)
+		}
+		app = &nais_io_v1alpha1.Application{}
+		if err := json.Unmarshal(doc, app); err != nil {
+			return nil, fmt.Errorf("parsing Application: %w", err)
+		}
+	}
+
+	if app == nil {
+		return nil, fmt.Errorf("no Application found in %s", path)
+	}
+	return app, nil
+}
+
+// YAML formats the resources as a multi-document YAML file.
+func YAML(resources []Resource) ([]byte, error) {
+	var ret []byte
+	for _, r := range resources {
+		j, err := json.Marshal(r.Object)
+		if err != nil {
+			return nil, err
+		}
+		y, err := yaml.JSONToYAML(j)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, "---\n"...)
+		ret = append(ret, y...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nais/cli/internal/validate"
+	"github.com/nais/naistrix"
+)
+
+func TestLoadApplication(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "nais.yaml")
+	mixin := filepath.Join(dir, "nais.dev.yaml")
+	files := map[string]string{
+		base:  "apiVersion: nais.io/v1alpha1\nkind: Application\nmetadata:\n  name: my-app\n  namespace: my-team\nspec:\n  image: \"{{ image }}\"\n  port: 8080\n",
+		mixin: "spec:\n  port: 9090\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := naistrix.NewOutputWriter(io.Discard, new(naistrix.Count))
+	app, err := LoadApplication(base, "", "dev", []string{"spec.replicas.min=3"}, validate.TemplateVariables{"image": "example.com/my-app:1"}, out)
+	if err != nil {
+		t.Fatalf("LoadApplication() error = %v", err)
+	}
+
+	if app.Spec.Image != "example.com/my-app:1" {
+		t.Errorf("image = %q, want the template expanded", app.Spec.Image)
+	}
+	if app.Spec.Port != 9090 {
+		t.Errorf("port = %d, want 9090 from the mixin", app.Spec.Port)
+	}
+	if app.Spec.Replicas == nil || app.Spec.Replicas.Min == nil || *app.Spec.Replicas.Min != 3 {
+		t.Errorf("replicas = %+v, want min 3 from --set", app.Spec.Replicas)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	result, err := Generate(application(t, ""), "dev")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	b, err := YAML(result.Resources)
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	if got := strings.Count(string(b), "---\n"); got != len(result.Resources) {
+		t.Errorf("YAML() has %d documents, want %d", got, len(result.Resources))
+	}
+	if !strings.Contains(string(b), "kind: Deployment") {
+		t.Errorf("YAML() does not contain the Deployment:\n%s", b)
+	}
+}
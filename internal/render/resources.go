@@ -0,0 +1,507 @@
+package render
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Defaults of Naiserator for fields that are not set in the manifest.
+const (
+	defaultPort          = 8080
+	defaultServicePort   = 80
+	defaultMinReplicas   = 2
+	defaultMaxReplicas   = 4
+	defaultCPUThreshold  = 50
+	defaultCPURequest    = "200m"
+	defaultMemoryRequest = "256Mi"
+	defaultMemoryLimit   = "512Mi"
+
+	// platformNamespace is where the ingress controllers and Prometheus run.
+	platformNamespace = "nais-system"
+)
+
+// Resource is a Kubernetes resource that Naiserator would generate from an Application.
+type Resource struct {
+	Kind    string
+	Name    string
+	Summary string
+	Object  any
+}
+
+// Result is the resources generated from an Application, and the environment variables of its container.
+type Result struct {
+	Resources []Resource
+	Env       []EnvVar
+}
+
+// Generate returns an approximation of the Deployment, Service, NetworkPolicy, HorizontalPodAutoscaler and
+// PodDisruptionBudget that Naiserator generates from an Application in the given environment. Resources for other
+// features, such as ingresses and certificates, are not included.
+func Generate(app *nais_io_v1alpha1.Application, environment string) (*Result, error) {
+	if app.GetName() == "" {
+		return nil, fmt.Errorf("the Application has no name")
+	}
+
+	env, rows := containerEnv(app, environment)
+	deployment, err := newDeployment(app, env)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Env: rows}
+	replicas := replicasOf(app.Spec.Replicas)
+	result.Resources = append(result.Resources,
+		Resource{
+			Kind: "Deployment",
+			Name: app.GetName(),
+			Summary: fmt.Sprintf("image %s, %s, %s, %d env vars",
+				cmp.Or(app.Spec.Image, "<unset>"), replicas, resourcesSummary(deployment.Spec.Template.Spec.Containers[0].Resources), len(rows)),
+			Object: deployment,
+		},
+		newService(app),
+		newNetworkPolicy(app, environment),
+	)
+
+	if hpa := newHPA(app, replicas); hpa != nil {
+		result.Resources = append(result.Resources, *hpa)
+	}
+	if pdb := newPDB(app, replicas); pdb != nil {
+		result.Resources = append(result.Resources, *pdb)
+	}
+	return result, nil
+}
+
+func objectMeta(app *nais_io_v1alpha1.Application) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      app.GetName(),
+		Namespace: app.GetNamespace(),
+		Labels: map[string]string{
+			"app":  app.GetName(),
+			"team": app.GetNamespace(),
+		},
+	}
+}
+
+func selector(app *nais_io_v1alpha1.Application) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app.GetName()}}
+}
+
+func containerPort(app *nais_io_v1alpha1.Application) int32 {
+	return int32(cmp.Or(app.Spec.Port, defaultPort)) // #nosec G115
+}
+
+func newDeployment(app *nais_io_v1alpha1.Application, env []corev1.EnvVar) (*appsv1.Deployment, error) {
+	requirements, err := resourceRequirements(app.Spec.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	port := containerPort(app)
+	container := corev1.Container{
+		Name:           app.GetName(),
+		Image:          app.Spec.Image,
+		Command:        app.Spec.Command,
+		Ports:          []corev1.ContainerPort{{Name: "http", ContainerPort: port, Protocol: corev1.ProtocolTCP}},
+		Env:            env,
+		EnvFrom:        envFrom(app.Spec.EnvFrom),
+		Resources:      requirements,
+		LivenessProbe:  probe(app.Spec.Liveness, port),
+		ReadinessProbe: probe(app.Spec.Readiness, port),
+		StartupProbe:   probe(app.Spec.Startup, port),
+	}
+
+	volumes, mounts := filesFrom(app.Spec.FilesFrom)
+	container.VolumeMounts = mounts
+
+	replicas := replicasOf(app.Spec.Replicas)
+	maxSurge := intstr.FromString("25%")
+	maxUnavailable := intstr.FromInt32(0)
+
+	meta := objectMeta(app)
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas.Min,
+			Selector: selector(app),
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: meta.Labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+					Volumes:    volumes,
+				},
+			},
+		},
+	}, nil
+}
+
+// resourceRequirements returns the resources of the container, with the defaults of Naiserator for the values that
+// are not set.
+func resourceRequirements(r *nais_io_v1.ResourceRequirements) (corev1.ResourceRequirements, error) {
+	requests := map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    defaultCPURequest,
+		corev1.ResourceMemory: defaultMemoryRequest,
+	}
+	limits := map[corev1.ResourceName]string{
+		corev1.ResourceMemory: defaultMemoryLimit,
+	}
+	if r != nil {
+		setResources(requests, r.Requests)
+		setResources(limits, r.Limits)
+	}
+
+	ret := corev1.ResourceRequirements{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+	for _, list := range []struct {
+		values map[corev1.ResourceName]string
+		into   corev1.ResourceList
+	}{{requests, ret.Requests}, {limits, ret.Limits}} {
+		for name, value := range list.values {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return ret, fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			list.into[name] = q
+		}
+	}
+	return ret, nil
+}
+
+func setResources(into map[corev1.ResourceName]string, spec *nais_io_v1.ResourceSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.Cpu != "" {
+		into[corev1.ResourceCPU] = spec.Cpu
+	}
+	if spec.Memory != "" {
+		into[corev1.ResourceMemory] = spec.Memory
+	}
+}
+
+func resourcesSummary(r corev1.ResourceRequirements) string {
+	format := func(list corev1.ResourceList) string {
+		var parts []string
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := list[name]; ok {
+				parts = append(parts, fmt.Sprintf("%s %s", name, q.String()))
+			}
+		}
+		return strings.Join(parts, "/")
+	}
+	ret := "requests " + format(r.Requests)
+	if len(r.Limits) > 0 {
+		ret += ", limits " + format(r.Limits)
+	}
+	return ret
+}
+
+func probe(p *nais_io_v1.Probe, port int32) *corev1.Probe {
+	if p == nil {
+		return nil
+	}
+	if p.Port != 0 {
+		port = int32(p.Port) // #nosec G115
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: p.Path, Port: intstr.FromInt32(port)},
+		},
+		InitialDelaySeconds: int32(p.InitialDelay),     // #nosec G115
+		PeriodSeconds:       int32(p.PeriodSeconds),    // #nosec G115
+		FailureThreshold:    int32(p.FailureThreshold), // #nosec G115
+		TimeoutSeconds:      int32(p.Timeout),          // #nosec G115
+	}
+}
+
+func envFrom(sources []nais_io_v1.EnvFrom) []corev1.EnvFromSource {
+	var ret []corev1.EnvFromSource
+	for _, s := range sources {
+		switch {
+		case s.ConfigMap != "":
+			ret = append(ret, corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: s.ConfigMap}}})
+		case s.Secret != "":
+			ret = append(ret, corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: s.Secret}}})
+		}
+	}
+	return ret
+}
+
+// filesFrom returns the volumes and mounts of the files of the application. Config maps and secrets are mounted in
+// /var/run/configmaps and /var/run/secrets unless another path is given.
+func filesFrom(files []nais_io_v1.FilesFrom) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	for i, f := range files {
+		var v corev1.Volume
+		var path string
+		switch {
+		case f.ConfigMap != "":
+			v = corev1.Volume{Name: f.ConfigMap, VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: f.ConfigMap}}}}
+			path = "/var/run/configmaps/" + f.ConfigMap
+		case f.Secret != "":
+			v = corev1.Volume{Name: f.Secret, VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: f.Secret}}}
+			path = "/var/run/secrets/" + f.Secret
+		case f.PersistentVolumeClaim != "":
+			v = corev1.Volume{Name: f.PersistentVolumeClaim, VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: f.PersistentVolumeClaim}}}
+		case f.EmptyDir != nil:
+			v = corev1.Volume{Name: fmt.Sprintf("empty-dir-%d", i), VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMedium(f.EmptyDir.Medium)}}}
+		default:
+			continue
+		}
+		volumes = append(volumes, v)
+		mounts = append(mounts, corev1.VolumeMount{Name: v.Name, MountPath: cmp.Or(f.MountPath, path), ReadOnly: v.EmptyDir == nil})
+	}
+	return volumes, mounts
+}
+
+// replicas is the number of replicas of an application, with the defaults of Naiserator for the values that are not
+// set.
+type replicas struct {
+	Min, Max     int32
+	CPUThreshold int32
+	Autoscaling  bool
+}
+
+func (r replicas) String() string {
+	if r.Min == r.Max {
+		return fmt.Sprintf("%d replicas", r.Min)
+	}
+	return fmt.Sprintf("%d-%d replicas", r.Min, r.Max)
+}
+
+func replicasOf(r *nais_io_v1.Replicas) replicas {
+	ret := replicas{Min: defaultMinReplicas, Max: defaultMaxReplicas, CPUThreshold: defaultCPUThreshold, Autoscaling: true}
+	if r != nil {
+		if r.Min != nil {
+			ret.Min = int32(*r.Min) // #nosec G115
+		}
+		if r.Max != nil {
+			ret.Max = int32(*r.Max) // #nosec G115
+		}
+		if r.CpuThresholdPercentage != 0 {
+			ret.CPUThreshold = int32(r.CpuThresholdPercentage) // #nosec G115
+		}
+		ret.Autoscaling = !r.DisableAutoScaling
+	}
+	if ret.Max < ret.Min {
+		ret.Max = ret.Min
+	}
+	if !ret.Autoscaling {
+		ret.Max = ret.Min
+	}
+	return ret
+}
+
+func newService(app *nais_io_v1alpha1.Application) Resource {
+	port := int32(defaultServicePort)
+	name := "http"
+	if app.Spec.Service != nil {
+		port = cmp.Or(app.Spec.Service.Port, port)
+		name = cmp.Or(app.Spec.Service.Protocol, name)
+	}
+
+	meta := objectMeta(app)
+	return Resource{
+		Kind:    "Service",
+		Name:    app.GetName(),
+		Summary: fmt.Sprintf("port %d (%s) to container port %d", port, name, containerPort(app)),
+		Object: &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: meta,
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{"app": app.GetName()},
+				Ports: []corev1.ServicePort{{
+					Name:       name,
+					Port:       port,
+					TargetPort: intstr.FromString("http"),
+					Protocol:   corev1.ProtocolTCP,
+				}},
+			},
+		},
+	}
+}
+
+func newHPA(app *nais_io_v1alpha1.Application, r replicas) *Resource {
+	if !r.Autoscaling || r.Min == r.Max {
+		return nil
+	}
+
+	return &Resource{
+		Kind:    "HorizontalPodAutoscaler",
+		Name:    app.GetName(),
+		Summary: fmt.Sprintf("%s at %d%% CPU", r, r.CPUThreshold),
+		Object: &autoscalingv2.HorizontalPodAutoscaler{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler"},
+			ObjectMeta: objectMeta(app),
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: app.GetName()},
+				MinReplicas:    &r.Min,
+				MaxReplicas:    r.Max,
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &r.CPUThreshold},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func newPDB(app *nais_io_v1alpha1.Application, r replicas) *Resource {
+	if r.Max <= 1 {
+		return nil
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	return &Resource{
+		Kind:    "PodDisruptionBudget",
+		Name:    app.GetName(),
+		Summary: "at most 1 pod unavailable",
+		Object: &policyv1.PodDisruptionBudget{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "policy/v1", Kind: "PodDisruptionBudget"},
+			ObjectMeta: objectMeta(app),
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+				Selector:       selector(app),
+			},
+		},
+	}
+}
+
+func newNetworkPolicy(app *nais_io_v1alpha1.Application, environment string) Resource {
+	var inbound []nais_io_v1.AccessPolicyRule
+	var outbound []nais_io_v1.AccessPolicyRule
+	var external []nais_io_v1.AccessPolicyExternalRule
+	if ap := app.Spec.AccessPolicy; ap != nil {
+		if ap.Inbound != nil {
+			for _, r := range ap.Inbound.Rules {
+				inbound = append(inbound, r.AccessPolicyRule)
+			}
+		}
+		if ap.Outbound != nil {
+			outbound = ap.Outbound.Rules
+			external = ap.Outbound.External
+		}
+	}
+
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: *selector(app),
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{},
+		Egress:      []networkingv1.NetworkPolicyEgressRule{},
+	}
+
+	var from []networkingv1.NetworkPolicyPeer
+	inboundNames := []string{}
+	for _, r := range inbound {
+		if r.Cluster != "" && r.Cluster != environment {
+			continue
+		}
+		from = append(from, peer(r, app.GetNamespace()))
+		inboundNames = append(inboundNames, ruleName(r, app.GetNamespace()))
+	}
+	// Traffic through ingresses and metrics scraping come from the platform.
+	if len(app.Spec.Ingresses) > 0 || (app.Spec.Prometheus != nil && app.Spec.Prometheus.Enabled) {
+		from = append(from, networkingv1.NetworkPolicyPeer{NamespaceSelector: namespaceSelector(platformNamespace)})
+		inboundNames = append(inboundNames, platformNamespace)
+	}
+	if len(from) > 0 {
+		spec.Ingress = append(spec.Ingress, networkingv1.NetworkPolicyIngressRule{From: from})
+	}
+
+	var to []networkingv1.NetworkPolicyPeer
+	outboundNames := []string{}
+	for _, r := range outbound {
+		if r.Cluster != "" && r.Cluster != environment {
+			continue
+		}
+		to = append(to, peer(r, app.GetNamespace()))
+		outboundNames = append(outboundNames, ruleName(r, app.GetNamespace()))
+	}
+	if len(to) > 0 {
+		spec.Egress = append(spec.Egress, networkingv1.NetworkPolicyEgressRule{To: to})
+	}
+
+	// Name lookups are always allowed.
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dns := intstr.FromInt32(53)
+	spec.Egress = append(spec.Egress, networkingv1.NetworkPolicyEgressRule{
+		To:    []networkingv1.NetworkPolicyPeer{{NamespaceSelector: namespaceSelector("kube-system")}},
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}, {Protocol: &tcp, Port: &dns}},
+	})
+
+	// External hosts are handled by FQDN network policies, only IP addresses end up in the network policy.
+	var hosts []string
+	for _, e := range external {
+		if e.IPv4 == "" {
+			hosts = append(hosts, e.Host)
+			continue
+		}
+		rule := networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: e.IPv4 + "/32"}}}}
+		for _, p := range e.Ports {
+			port := intstr.FromInt32(int32(p.Port)) // #nosec G115
+			protocol := corev1.Protocol(cmp.Or(strings.ToUpper(p.Protocol), string(corev1.ProtocolTCP)))
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+		}
+		spec.Egress = append(spec.Egress, rule)
+		outboundNames = append(outboundNames, e.IPv4)
+	}
+
+	summary := fmt.Sprintf("inbound from [%s], outbound to [%s]", strings.Join(inboundNames, ", "), strings.Join(outboundNames, ", "))
+	if len(hosts) > 0 {
+		summary += fmt.Sprintf(", external hosts [%s] through FQDN policy", strings.Join(hosts, ", "))
+	}
+
+	return Resource{
+		Kind:    "NetworkPolicy",
+		Name:    app.GetName(),
+		Summary: summary,
+		Object: &networkingv1.NetworkPolicy{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+			ObjectMeta: objectMeta(app),
+			Spec:       spec,
+		},
+	}
+}
+
+// peer returns the pods matched by an access policy rule, where * matches all applications or namespaces.
+func peer(r nais_io_v1.AccessPolicyRule, namespace string) networkingv1.NetworkPolicyPeer {
+	pods := &metav1.LabelSelector{}
+	if r.Application != "*" {
+		pods.MatchLabels = map[string]string{"app": r.Application}
+	}
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector:       pods,
+		NamespaceSelector: namespaceSelector(cmp.Or(r.Namespace, namespace)),
+	}
+}
+
+func namespaceSelector(namespace string) *metav1.LabelSelector {
+	if namespace == "*" {
+		return &metav1.LabelSelector{}
+	}
+	return &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": namespace}}
+}
+
+func ruleName(r nais_io_v1.AccessPolicyRule, namespace string) string {
+	return cmp.Or(r.Namespace, namespace) + "/" + r.Application
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/nais/cli/internal/validate"
+	nais_io_v1 "github.com/nais/liberator/pkg/apis/nais.io/v1"
+	nais_io_v1alpha1 "github.com/nais/liberator/pkg/apis/nais.io/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const manifest = `
+apiVersion: nais.io/v1alpha1
+kind: Application
+metadata:
+  name: my-app
+  namespace: my-team
+spec:
+  image: example.com/my-app:1
+  env:
+    - name: FOO
+      value: bar
+  gcp:
+    sqlInstances:
+      - type: POSTGRES_17
+  valkey:
+    - instance: sessions
+  accessPolicy:
+    inbound:
+      rules:
+        - application: frontend
+        - application: other
+          namespace: other-team
+        - application: remote
+          cluster: prod
+    outbound:
+      rules:
+        - application: backend
+      external:
+        - host: example.com
+        - ipv4: 10.0.0.1
+          ports:
+            - port: 443
+`
+
+func application(t *testing.T, extra string) *nais_io_v1alpha1.Application {
+	t.Helper()
+	docs, err := validate.YAMLToJSONMessages([]byte(manifest + extra))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &nais_io_v1alpha1.Application{}
+	if err := json.Unmarshal(docs[0], app); err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func kinds(resources []Resource) []string {
+	var ret []string
+	for _, r := range resources {
+		ret = append(ret, r.Kind)
+	}
+	return ret
+}
+
+func TestGenerate(t *testing.T) {
+	result, err := Generate(application(t, ""), "dev")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{"Deployment", "Service", "NetworkPolicy", "HorizontalPodAutoscaler", "PodDisruptionBudget"}
+	if got := kinds(result.Resources); !slices.Equal(got, want) {
+		t.Errorf("kinds = %v, want %v", got, want)
+	}
+
+	deployment := result.Resources[0].Object.(*appsv1.Deployment)
+	if got := *deployment.Spec.Replicas; got != defaultMinReplicas {
+		t.Errorf("replicas = %d, want %d", got, defaultMinReplicas)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if got := container.Resources.Requests.Cpu().String(); got != defaultCPURequest {
+		t.Errorf("cpu request = %s, want %s", got, defaultCPURequest)
+	}
+	if got := container.Ports[0].ContainerPort; got != defaultPort {
+		t.Errorf("container port = %d, want %d", got, defaultPort)
+	}
+
+	policy := result.Resources[2].Object.(*networkingv1.NetworkPolicy)
+	if got := len(policy.Spec.Ingress[0].From); got != 2 {
+		t.Errorf("inbound peers = %d, want 2 as the rule for another cluster is skipped", got)
+	}
+	if got := policy.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; got != "other-team" {
+		t.Errorf("inbound namespace = %q, want other-team", got)
+	}
+	// backend, DNS and the IP address; the host is handled by an FQDN policy.
+	if got := len(policy.Spec.Egress); got != 3 {
+		t.Errorf("egress rules = %d, want 3", got)
+	}
+}
+
+func TestGenerate_fixedReplicas(t *testing.T) {
+	app := application(t, "  replicas:\n    min: 1\n    max: 1\n")
+	result, err := Generate(app, "dev")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{"Deployment", "Service", "NetworkPolicy"}
+	if got := kinds(result.Resources); !slices.Equal(got, want) {
+		t.Errorf("kinds = %v, want %v", got, want)
+	}
+}
+
+func TestGenerate_invalidResources(t *testing.T) {
+	app := application(t, "  resources:\n    requests:\n      memory: lots\n")
+	if _, err := Generate(app, "dev"); err == nil {
+		t.Error("Generate() error = nil, want an error for an invalid quantity")
+	}
+}
+
+func TestContainerEnv(t *testing.T) {
+	_, rows := containerEnv(application(t, ""), "dev")
+
+	sources := map[string]string{}
+	for _, r := range rows {
+		sources[r.Name] = r.Source
+	}
+
+	want := map[string]string{
+		"FOO":                              "manifest",
+		"NAIS_CLIENT_ID":                   "platform",
+		"NAIS_DATABASE_MY_APP_MY_APP_HOST": "secret google-sql-my-app",
+		"VALKEY_URI_SESSIONS":              "secret aiven-valkey-sessions-my-app",
+	}
+	for name, source := range want {
+		if sources[name] != source {
+			t.Errorf("source of %s = %q, want %q", name, sources[name], source)
+		}
+	}
+	if _, ok := sources["KAFKA_BROKERS"]; ok {
+		t.Error("KAFKA_BROKERS is set without Kafka")
+	}
+}
+
+func TestContainerEnv_platformOverridesManifest(t *testing.T) {
+	app := application(t, "")
+	app.Spec.Env = append(app.Spec.Env, nais_io_v1.EnvVar{Name: "NAIS_APP_NAME", Value: "mine"})
+
+	env, rows := containerEnv(app, "dev")
+	var count int
+	for i, r := range rows {
+		if r.Name == "NAIS_APP_NAME" {
+			count++
+			if r.Source != "platform" || env[i].Value != "my-app" {
+				t.Errorf("NAIS_APP_NAME = %q from %s, want my-app from the platform", env[i].Value, r.Source)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("NAIS_APP_NAME is set %d times, want 1", count)
+	}
+}
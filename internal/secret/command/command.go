@@ -30,6 +30,11 @@ func Secrets(parentFlags *flags.GlobalFlags) *naistrix.Command {
 			deleteSecret(f),
 			set(f),
 			unset(f),
+			importSecret(f),
+			export(f),
+			copySecret(f),
+			rotate(f),
+			diff(f),
 		},
 	}
 }
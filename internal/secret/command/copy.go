@@ -0,0 +1,174 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/secret"
+	"github.com/nais/cli/internal/secret/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func copySecret(parentFlags *flag.Secret) *naistrix.Command {
+	f := &flag.Copy{Secret: parentFlags}
+	return &naistrix.Command{
+		Name:  "copy",
+		Title: "Copy a secret to another environment.",
+		Description: heredoc.Doc(`
+			Copies the values of a secret from one environment to another. The secret is created in the target environment if it does not exist.
+
+			The keys that will be added, changed and removed are shown before anything is changed, with the values masked. Values that are equal in both environments are left as is. With --replace, keys in the target secret that are not copied are removed.
+
+			Reading the secret values is logged for auditing purposes, and requires a reason. Updating a secret will cause a restart of workloads referencing the secret.
+		`),
+		Flags: f,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validateArgs,
+			func(_ context.Context, args *naistrix.Arguments) error {
+				if f.From == "" || f.To == "" {
+					return fmt.Errorf("--from and --to are required")
+				}
+				if f.From == f.To && (f.Rename == "" || f.Rename == args.Get("name")) {
+					return fmt.Errorf("--from and --to must be different environments, unless --rename is used")
+				}
+				if f.Replace && f.Keys != "" {
+					return fmt.Errorf("--replace and --keys are mutually exclusive")
+				}
+				if f.Reason != "" && len(f.Reason) < 10 {
+					return fmt.Errorf("reason must be at least 10 characters")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: autoCompleteSecretNames(parentFlags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Copy the secret my-secret from dev to prod.",
+				Command:     "my-secret --from dev --to prod --reason \"Setting up the prod environment\"",
+			},
+			{
+				Description: "Only copy some keys, to a secret with another name.",
+				Command:     "my-secret --from dev --to prod --keys API_URL,API_KEY --rename other-secret",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			from := metadataFromArgs(args, f.Team, string(f.From))
+			to := metadataFromArgs(args, f.Team, string(f.To))
+			if f.Rename != "" {
+				to.Name = f.Rename
+			}
+
+			if _, err := resolveSecretEnvironment(ctx, f.Team, from.Name, from.EnvironmentName); err != nil {
+				return err
+			}
+
+			targetEnvs, err := secret.SecretEnvironments(ctx, f.Team, to.Name)
+			if err != nil {
+				return fmt.Errorf("fetching environments for secret %q: %w", to.Name, err)
+			}
+			create := !slices.Contains(targetEnvs, to.EnvironmentName)
+
+			reason := f.Reason
+			if reason == "" {
+				out.Warnln("Viewing secret values is logged for auditing purposes.")
+				result, err := input.Input("Reason for accessing secret values (min 10 chars)")
+				if err != nil {
+					return fmt.Errorf("prompting for reason: %w", err)
+				}
+				if len(result) < 10 {
+					return fmt.Errorf("reason must be at least 10 characters")
+				}
+				reason = result
+			}
+
+			source, err := viewEntries(ctx, from, reason)
+			if err != nil {
+				return err
+			}
+
+			var target []secret.Entry
+			if !create {
+				if target, err = viewEntries(ctx, to, reason); err != nil {
+					return err
+				}
+			}
+
+			plan, err := secret.PlanCopy(source, target, splitKeys(f.Keys), f.Replace)
+			if err != nil {
+				return err
+			}
+
+			if !plan.Changed() && !create {
+				out.Infof("Secret %q in %q is already up to date.\n", to.Name, to.EnvironmentName)
+				return nil
+			}
+
+			if create {
+				out.Printf("Secret %q will be created in %q:\n", to.Name, to.EnvironmentName)
+			} else {
+				out.Printf("Secret %q in %q:\n", to.Name, to.EnvironmentName)
+			}
+			for _, e := range plan.Add {
+				out.Printf("  + %s %s\n", e.Key, secret.MaskedSize(e))
+			}
+			for _, e := range plan.Update {
+				out.Printf("  ~ %s %s\n", e.Key, secret.MaskedSize(e))
+			}
+			for _, k := range plan.Remove {
+				out.Printf("  - %s\n", k)
+			}
+
+			if !f.Yes {
+				if result, err := input.Confirm(fmt.Sprintf("Copy secret %q from %q to %q?", from.Name, from.EnvironmentName, to.EnvironmentName)); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if create {
+				if _, err := secret.Create(ctx, to); err != nil {
+					return fmt.Errorf("creating secret: %w", err)
+				}
+			}
+
+			if err := secret.ApplyImport(ctx, to, plan); err != nil {
+				return fmt.Errorf("copying values: %w", err)
+			}
+
+			out.Successf("Copied secret %q from %q to %q in %q\n", from.Name, from.EnvironmentName, to.Name, to.EnvironmentName)
+			return nil
+		},
+	}
+}
+
+// viewEntries reads the values of a secret. The access is logged with the given reason.
+func viewEntries(ctx context.Context, metadata secret.Metadata, reason string) ([]secret.Entry, error) {
+	values, err := naisapi.ViewSecretValues(ctx, metadata.TeamSlug, metadata.EnvironmentName, metadata.Name, reason)
+	if err != nil {
+		return nil, fmt.Errorf("viewing values of secret %q in %q: %w", metadata.Name, metadata.EnvironmentName, err)
+	}
+
+	entries := make([]secret.Entry, 0, len(values))
+	for _, v := range values {
+		entries = append(entries, secret.Entry{Key: v.Name, Value: v.Value, Encoding: v.Encoding})
+	}
+	return entries, nil
+}
+
+// splitKeys splits a comma-separated list of keys.
+func splitKeys(s string) []string {
+	var keys []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
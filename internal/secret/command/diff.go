@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/secret"
+	"github.com/nais/cli/internal/secret/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/output"
+)
+
+func diff(parentFlags *flag.Secret) *naistrix.Command {
+	f := &flag.Diff{Secret: parentFlags, Output: "table"}
+	return &naistrix.Command{
+		Name:  "diff",
+		Title: "Compare secrets across environments.",
+		Description: heredoc.Doc(`
+			Compares the keys of a secret in every environment it exists in, and shows a row per key with a column per environment. Keys that are missing in an environment are marked with -.
+
+			Secret values are only compared with --with-values, which requires a reason and is logged for auditing purposes. Equal values are labelled with the same letter, and the values themselves are never shown.
+
+			Use --all to compare every secret of the team.
+		`),
+		Flags: f,
+		Args:  []naistrix.Argument{{Name: "name", Repeatable: true}},
+		ValidateFunc: func(_ context.Context, args *naistrix.Arguments) error {
+			if f.All && args.Len() > 0 {
+				return fmt.Errorf("secret names cannot be given together with --all")
+			}
+			if !f.All && args.Len() == 0 {
+				return fmt.Errorf("expected a secret name, or --all")
+			}
+			if f.Reason != "" && !f.WithValues {
+				return fmt.Errorf("--reason can only be used together with --with-values")
+			}
+			if f.WithValues && len(f.Reason) < 10 {
+				return fmt.Errorf("--with-values requires --reason of at least 10 characters")
+			}
+			return nil
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Compare the keys of the secret my-secret across environments.",
+				Command:     "my-secret",
+			},
+			{
+				Description: "Also compare the values.",
+				Command:     "my-secret --with-values --reason \"Debugging prod-only crash\"",
+			},
+			{
+				Description: "Compare all secrets of the team.",
+				Command:     "--all",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			names := args.GetRepeatable("name")
+
+			var filter gql.SecretFilter
+			if len(names) == 1 {
+				filter.Name = names[0]
+			}
+			all, err := secret.GetAll(ctx, f.Team, filter)
+			if err != nil {
+				return fmt.Errorf("fetching secrets: %w", err)
+			}
+
+			entries := map[string]map[string][]secret.Entry{}
+			var environments []string
+			for _, s := range all {
+				if !f.All && !slices.Contains(names, s.Name) {
+					continue
+				}
+
+				env := s.TeamEnvironment.Environment.Name
+				metadata := secret.Metadata{TeamSlug: f.Team, EnvironmentName: env, Name: s.Name}
+
+				var es []secret.Entry
+				if f.WithValues {
+					if es, err = viewEntries(ctx, metadata, f.Reason); err != nil {
+						return err
+					}
+				} else {
+					for _, k := range s.Keys {
+						es = append(es, secret.Entry{Key: k})
+					}
+				}
+
+				if entries[s.Name] == nil {
+					entries[s.Name] = map[string][]secret.Entry{}
+				}
+				entries[s.Name][env] = es
+				if !slices.Contains(environments, env) {
+					environments = append(environments, env)
+				}
+			}
+
+			for _, name := range names {
+				if _, ok := entries[name]; !ok {
+					return fmt.Errorf("secret %q not found in team %q", name, f.Team)
+				}
+			}
+
+			secretNames := make([]string, 0, len(entries))
+			for name := range entries {
+				secretNames = append(secretNames, name)
+			}
+			slices.Sort(secretNames)
+			slices.Sort(environments)
+
+			var rows []secret.DiffRow
+			for _, name := range secretNames {
+				rows = append(rows, secret.Diff(name, entries[name], f.WithValues)...)
+			}
+
+			if f.Output == "json" {
+				return out.JSON(output.JSONWithPrettyOutput()).Render(rows)
+			}
+
+			if len(rows) == 0 {
+				out.Infoln("No secret keys found.")
+				return nil
+			}
+			return out.Table().Render(secret.FormatDiff(rows, environments, len(secretNames) > 1 || f.All))
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/secret"
+	"github.com/nais/cli/internal/secret/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+)
+
+func export(parentFlags *flag.Secret) *naistrix.Command {
+	f := &flag.Export{Secret: parentFlags}
+	return &naistrix.Command{
+		Name:  "export",
+		Title: "Export the values of a secret to a file.",
+		Description: heredoc.Doc(`
+			Exports the values of a secret as a .env file, a JSON object or a Kubernetes Secret manifest. The output can be imported again with "nais secret import".
+
+			Exporting secret values is logged for auditing purposes, and requires a reason.
+
+			Binary values can only be exported losslessly as a Kubernetes Secret manifest. A secret with binary values is not exported as a .env file or JSON object unless --allow-binary is given, in which case the binary values are written BASE64-encoded, and are imported again as text.
+		`),
+		Flags: f,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(f),
+			validateArgs,
+			func(context.Context, *naistrix.Arguments) error {
+				if f.Reason == "" {
+					return fmt.Errorf("--reason is required, exporting secret values is logged for auditing purposes")
+				}
+				if len(f.Reason) < 10 {
+					return fmt.Errorf("reason must be at least 10 characters")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: autoCompleteSecretNames(parentFlags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Write the values of the secret my-secret in environment dev to a .env file.",
+				Command:     "my-secret --environment dev --file .env --reason \"Running the app locally\"",
+			},
+			{
+				Description: "Print the secret as a Kubernetes Secret manifest.",
+				Command:     "my-secret --environment dev --format yaml --reason \"Moving the secret to another team\"",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			metadata := metadataFromArgs(args, f.Team, string(f.Environment))
+
+			format := secret.FileFormat(f.Format)
+			switch {
+			case format != "":
+			case f.File != "":
+				var err error
+				if format, err = secret.DetectFileFormat(f.File); err != nil {
+					return err
+				}
+			default:
+				format = secret.FileFormatEnv
+			}
+
+			entries, err := viewEntries(ctx, metadata, f.Reason)
+			if err != nil {
+				return err
+			}
+
+			data, binary, err := secret.FormatFile(metadata, entries, format)
+			if err != nil {
+				return err
+			}
+			if len(binary) > 0 && !f.AllowBinary {
+				return fmt.Errorf("keys %s have binary values, which cannot be imported again from a %s file; use --format yaml, or --allow-binary to write them BASE64-encoded", strings.Join(binary, ", "), format)
+			}
+
+			if f.File == "" {
+				out.Printf("%s", data)
+				return nil
+			}
+
+			if err := os.WriteFile(f.File, data, 0o600); err != nil {
+				return fmt.Errorf("writing to file %q: %w", f.File, err)
+			}
+			if len(binary) > 0 {
+				out.Warnf("Binary values are BASE64-encoded in the file: %s\n", strings.Join(binary, ", "))
+			}
+			out.Successf("Wrote %d keys from secret %q to %s\n", len(entries), metadata.Name, f.File)
+			return nil
+		},
+	}
+}
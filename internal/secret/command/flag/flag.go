@@ -2,6 +2,7 @@ package flag
 
 import (
 	"context"
+	"time"
 
 	activityutil "github.com/nais/cli/internal/activity"
 	"github.com/nais/cli/internal/flags"
@@ -72,3 +73,60 @@ type Unset struct {
 	Key string `name:"key" usage:"Name of the key to unset."`
 	Yes bool   `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
 }
+
+type FileFormat string
+
+func (f *FileFormat) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"env", "json", "yaml"}, "Available file formats."
+}
+
+type Import struct {
+	*Secret
+	File    string     `name:"file" short:"f" usage:"|FILE| to import values from (.env, JSON or Kubernetes Secret YAML)."`
+	Format  FileFormat `name:"format" usage:"Format of the file (env, json or yaml). Detected from the file extension by default."`
+	Replace bool       `name:"replace" usage:"Remove keys in the secret that are not in the file."`
+	Merge   bool       `name:"merge" usage:"Keep keys in the secret that are not in the file. This is the default."`
+	Yes     bool       `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type Export struct {
+	*Secret
+	File        string     `name:"file" short:"f" usage:"Write the values to |FILE| instead of stdout."`
+	Format      FileFormat `name:"format" usage:"Format of the output (env, json or yaml). Detected from the file extension when --file is set, env otherwise."`
+	Reason      string     `name:"reason" usage:"Reason for accessing secret values (min 10 chars)."`
+	AllowBinary bool       `name:"allow-binary" usage:"Write binary values BASE64-encoded in .env and JSON files. They are imported again as text."`
+}
+
+type Copy struct {
+	*Secret
+	From    flags.Environment `name:"from" usage:"|ENVIRONMENT| to copy the secret from."`
+	To      flags.Environment `name:"to" usage:"|ENVIRONMENT| to copy the secret to."`
+	Keys    string            `name:"keys" usage:"Comma-separated list of |KEYS| to copy. All keys are copied by default."`
+	Rename  string            `name:"rename" usage:"Copy to a secret with another |NAME| in the target environment."`
+	Replace bool              `name:"replace" usage:"Remove keys in the target secret that are not copied. Cannot be used with --keys."`
+	Reason  string            `name:"reason" usage:"Reason for accessing secret values (min 10 chars)."`
+	Yes     bool              `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type Generator string
+
+func (g *Generator) AutoComplete(context.Context, *naistrix.Arguments, string, any) ([]string, string) {
+	return []string{"password:32", "uuid", "hex:64", "rsa:2048"}, "Available generators."
+}
+
+type Rotate struct {
+	*Secret
+	Key      string        `name:"key" usage:"Name of the |KEY| to rotate."`
+	Generate Generator     `name:"generate" usage:"|GENERATOR| of the new value: password:N, uuid, hex:N or rsa:BITS."`
+	Restart  bool          `name:"restart" usage:"Wait for the applications using the secret to become ready after the restart caused by the update."`
+	Timeout  time.Duration `name:"timeout" usage:"Maximum time to wait for the applications to become ready when |--restart| is set."`
+	Yes      bool          `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
+
+type Diff struct {
+	*Secret
+	Output     Output `name:"output" short:"o" usage:"Format output (table or json)."`
+	All        bool   `name:"all" usage:"Compare all secrets of the team."`
+	WithValues bool   `name:"with-values" usage:"Also compare secret values (access is logged). Requires --reason."`
+	Reason     string `name:"reason" usage:"Reason for accessing secret values (min 10 chars). Used with --with-values."`
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/naisapi"
+	"github.com/nais/cli/internal/secret"
+	"github.com/nais/cli/internal/secret/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func importSecret(parentFlags *flag.Secret) *naistrix.Command {
+	f := &flag.Import{Secret: parentFlags}
+	return &naistrix.Command{
+		Name:  "import",
+		Title: "Import values into a secret from a file.",
+		Description: heredoc.Doc(`
+			Imports the values in a .env file, a JSON object or a Kubernetes Secret manifest into a secret. The secret is created if it does not exist.
+
+			The keys that will be added, updated and removed are shown before anything is changed, with the values masked. Existing values are never read, so every imported key that already exists is updated. With --replace, keys that are not in the file are removed from the secret.
+
+			Updating a secret will cause a restart of workloads referencing the secret.
+		`),
+		Flags: f,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(f),
+			validateArgs,
+			func(context.Context, *naistrix.Arguments) error {
+				if f.File == "" {
+					return fmt.Errorf("--file is required")
+				}
+				if f.Replace && f.Merge {
+					return fmt.Errorf("--replace and --merge are mutually exclusive")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: autoCompleteSecretNames(parentFlags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Add and update the values in .env in the secret my-secret in environment dev.",
+				Command:     "my-secret --environment dev --file .env",
+			},
+			{
+				Description: "Make the secret contain exactly the values of a Kubernetes Secret manifest.",
+				Command:     "my-secret --environment dev --file secret.yaml --replace",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			metadata := metadataFromArgs(args, f.Team, string(f.Environment))
+
+			format := secret.FileFormat(f.Format)
+			if format == "" {
+				var err error
+				if format, err = secret.DetectFileFormat(f.File); err != nil {
+					return err
+				}
+			}
+
+			data, err := os.ReadFile(f.File)
+			if err != nil {
+				return fmt.Errorf("reading file %q: %w", f.File, err)
+			}
+
+			entries, err := secret.ParseFile(data, format)
+			if err != nil {
+				return fmt.Errorf("parsing file %q: %w", f.File, err)
+			}
+			if len(entries) == 0 {
+				return fmt.Errorf("no values found in %q", f.File)
+			}
+
+			const maxValueSize = 1 << 20 // 1 MiB
+			for _, e := range entries {
+				if len(e.Value) > maxValueSize {
+					return fmt.Errorf("value of key %q too large (%d bytes); maximum size is 1 MiB", e.Key, len(e.Value))
+				}
+			}
+
+			create := false
+			var existingKeys []string
+			existing, err := secret.Get(ctx, metadata)
+			if err != nil {
+				if !naisapi.IsNotFound(err) {
+					return fmt.Errorf("fetching secret: %w", err)
+				}
+				create = true
+			} else {
+				existingKeys = existing.Keys
+			}
+
+			plan := secret.PlanImport(existingKeys, entries, f.Replace)
+
+			if create {
+				out.Printf("Secret %q will be created in %q:\n", metadata.Name, metadata.EnvironmentName)
+			} else {
+				out.Printf("Secret %q in %q:\n", metadata.Name, metadata.EnvironmentName)
+			}
+			for _, e := range plan.Add {
+				out.Printf("  + %s %s\n", e.Key, secret.MaskedSize(e))
+			}
+			for _, e := range plan.Update {
+				out.Printf("  ~ %s %s\n", e.Key, secret.MaskedSize(e))
+			}
+			for _, k := range plan.Remove {
+				out.Printf("  - %s\n", k)
+			}
+
+			if !f.Yes {
+				if result, err := input.Confirm(fmt.Sprintf("Apply the changes to secret %q in %q?", metadata.Name, metadata.EnvironmentName)); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if create {
+				if _, err := secret.Create(ctx, metadata); err != nil {
+					return fmt.Errorf("creating secret: %w", err)
+				}
+			}
+
+			if err := secret.ApplyImport(ctx, metadata, plan); err != nil {
+				return fmt.Errorf("importing values: %w", err)
+			}
+
+			out.Successf("Imported %d keys into secret %q in %q\n", len(entries), metadata.Name, metadata.EnvironmentName)
+			if len(plan.Remove) > 0 {
+				out.Successf("Removed %d keys from secret %q in %q\n", len(plan.Remove), metadata.Name, metadata.EnvironmentName)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/apply/resource"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/secret"
+	"github.com/nais/cli/internal/secret/command/flag"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+)
+
+func rotate(parentFlags *flag.Secret) *naistrix.Command {
+	f := &flag.Rotate{Secret: parentFlags, Timeout: 10 * time.Minute}
+	return &naistrix.Command{
+		Name:  "rotate",
+		Title: "Rotate a value in a secret.",
+		Description: heredoc.Doc(`
+			Generates a new random value locally and sets it for a key in a secret. The value is never printed.
+
+			Updating the value restarts the applications using the secret. With --restart, the command waits for the restarted applications to become ready in the same way as "nais apply --wait". Jobs using the secret get the new value on their next run.
+		`),
+		Flags: f,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(f),
+			validateArgs,
+			func(context.Context, *naistrix.Arguments) error {
+				if f.Key == "" {
+					return fmt.Errorf("--key is required")
+				}
+				if f.Generate == "" {
+					return fmt.Errorf("--generate is required")
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: autoCompleteSecretNames(parentFlags),
+		Examples: []naistrix.Example{
+			{
+				Description: "Set a new 32 character password for the key DB_PASSWORD in the secret my-secret.",
+				Command:     "my-secret --environment dev --key DB_PASSWORD --generate password:32",
+			},
+			{
+				Description: "Set a new RSA key, and wait for the applications using it to become ready after the restart.",
+				Command:     "my-secret --environment prod --key PRIVATE_KEY --generate rsa:4096 --restart",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			metadata := metadataFromArgs(args, f.Team, string(f.Environment))
+
+			value, err := secret.Generate(string(f.Generate))
+			if err != nil {
+				return err
+			}
+
+			existing, err := secret.Get(ctx, metadata)
+			if err != nil {
+				return fmt.Errorf("fetching secret: %w", err)
+			}
+
+			var applications, jobs []string
+			for _, w := range existing.Workloads.Nodes {
+				switch w.GetTypename() {
+				case "Application":
+					applications = append(applications, w.GetName())
+				case "Job":
+					jobs = append(jobs, w.GetName())
+				}
+			}
+
+			out.Warnf("You are about to replace the value of key %q in secret %q in %q.\n", f.Key, metadata.Name, metadata.EnvironmentName)
+			if len(applications) > 0 {
+				out.Warnf("These applications will be restarted: %s\n", strings.Join(applications, ", "))
+			}
+			if !f.Yes {
+				if result, err := input.Confirm("Are you sure you want to continue?"); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			// The update triggers the restart, so the rollouts to wait for are those started after this.
+			since := time.Now()
+			if _, err := secret.SetValue(ctx, metadata, f.Key, value, gql.ValueEncodingPlainText); err != nil {
+				return fmt.Errorf("setting secret value: %w", err)
+			}
+			out.Successf("Rotated key %q in secret %q in %q\n", f.Key, metadata.Name, metadata.EnvironmentName)
+
+			if !f.Restart {
+				return nil
+			}
+
+			for _, j := range jobs {
+				out.Infof("Job/%s will use the new value on its next run.\n", j)
+			}
+			return waitForApplications(ctx, metadata, applications, since, f.Timeout, out)
+		},
+	}
+}
+
+// waitForApplications waits for the applications to become ready after a rollout started after since, sharing one
+// timeout.
+func waitForApplications(ctx context.Context, metadata secret.Metadata, applications []string, since time.Time, timeout time.Duration, out *naistrix.OutputWriter) error {
+	if len(applications) == 0 {
+		out.Infoln("No applications are using this secret.")
+		return nil
+	}
+
+	r, ok := resource.ForCRD("nais.io/v1alpha1", "Application")
+	if !ok {
+		return fmt.Errorf("waiting for applications is not supported")
+	}
+	waiter, ok := r.(resource.Waiter)
+	if !ok {
+		return fmt.Errorf("waiting for applications is not supported")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var errs []string
+	for _, a := range applications {
+		if err := waiter.Wait(ctx, metadata.TeamSlug, metadata.EnvironmentName, a, since, out); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("wait failed for %d application(s):\n  %s", len(errs), strings.Join(errs, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package secret
+
+import (
+	"github.com/nais/cli/internal/keyvalue"
+)
+
+// PlanCopy returns the changes needed to copy the source entries into the target entries. Only the given keys are
+// copied, or all keys if none are given. Target entries with the same value are left as is, and target keys that are not
+// copied are removed when replace is true.
+func PlanCopy(source, target []Entry, keys []string, replace bool) (ImportPlan, error) {
+	return keyvalue.PlanCopy(source, target, entryKey, keys, replace)
+}
@@ -0,0 +1,71 @@
+package secret
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func TestPlanCopy(t *testing.T) {
+	t.Parallel()
+
+	source := []Entry{
+		{Key: "SAME", Value: "1", Encoding: gql.ValueEncodingPlainText},
+		{Key: "CHANGED", Value: "new", Encoding: gql.ValueEncodingPlainText},
+		{Key: "NEW", Value: "x", Encoding: gql.ValueEncodingPlainText},
+	}
+	target := []Entry{
+		{Key: "SAME", Value: "1", Encoding: gql.ValueEncodingPlainText},
+		{Key: "CHANGED", Value: "old", Encoding: gql.ValueEncodingPlainText},
+		{Key: "EXTRA", Value: "y", Encoding: gql.ValueEncodingPlainText},
+	}
+
+	tests := []struct {
+		name    string
+		keys    []string
+		replace bool
+		want    ImportPlan
+		wantErr bool
+	}{
+		{
+			name: "all keys",
+			want: ImportPlan{Add: []Entry{source[2]}, Update: []Entry{source[1]}},
+		},
+		{
+			name:    "replace",
+			replace: true,
+			want:    ImportPlan{Add: []Entry{source[2]}, Update: []Entry{source[1]}, Remove: []string{"EXTRA"}},
+		},
+		{
+			name: "selected keys",
+			keys: []string{"NEW", "SAME"},
+			want: ImportPlan{Add: []Entry{source[2]}},
+		},
+		{
+			name:    "missing key",
+			keys:    []string{"MISSING"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := PlanCopy(source, target, tt.keys, tt.replace)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("PlanCopy() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PlanCopy() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanCopy() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
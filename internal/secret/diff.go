@@ -0,0 +1,37 @@
+package secret
+
+import (
+	"github.com/nais/cli/internal/keyvalue"
+)
+
+// DiffRow is a key of a secret and its state in each environment the secret exists in. When values are compared, equal
+// values have the same label in every environment.
+type DiffRow struct {
+	Secret string `json:"secret"`
+	keyvalue.DiffRow
+}
+
+// Diff compares the keys of a secret across environments, and their values when compareValues is true. entries maps
+// each environment the secret exists in to its entries. The rows are sorted by key.
+func Diff(name string, entries map[string][]Entry, compareValues bool) []DiffRow {
+	rows := keyvalue.Diff(entries, entryKey, compareValues)
+	ret := make([]DiffRow, 0, len(rows))
+	for _, r := range rows {
+		ret = append(ret, DiffRow{Secret: name, DiffRow: r})
+	}
+	return ret
+}
+
+// FormatDiff formats diff rows as a matrix with a column per environment for table rendering. The cell is empty in
+// environments the secret does not exist in. The secret name is only included when withSecret is true.
+func FormatDiff(rows []DiffRow, environments []string, withSecret bool) [][]string {
+	diff := make([]keyvalue.DiffRow, 0, len(rows))
+	var names []string
+	for _, r := range rows {
+		diff = append(diff, r.DiffRow)
+		if withSecret {
+			names = append(names, r.Secret)
+		}
+	}
+	return keyvalue.FormatDiff(diff, environments, "Secret", names)
+}
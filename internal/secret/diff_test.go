@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	entries := map[string][]Entry{
+		"dev":  {{Key: "A", Value: "1"}, {Key: "B", Value: "x"}, {Key: "DEV_ONLY", Value: "d"}},
+		"prod": {{Key: "A", Value: "1"}, {Key: "B", Value: "y"}},
+	}
+
+	t.Run("keys", func(t *testing.T) {
+		t.Parallel()
+
+		got := FormatDiff(Diff("my-secret", entries, false), []string{"dev", "prod"}, false)
+		want := [][]string{
+			{"Key", "dev", "prod", "Status"},
+			{"A", "✓", "✓", "same"},
+			{"B", "✓", "✓", "same"},
+			{"DEV_ONLY", "✓", "-", "missing in prod"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FormatDiff() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+
+		got := FormatDiff(Diff("my-secret", entries, true), []string{"dev", "prod", "test"}, true)
+		want := [][]string{
+			{"Secret", "Key", "dev", "prod", "test", "Status"},
+			{"my-secret", "A", "A", "A", "", "same"},
+			{"my-secret", "B", "A", "B", "", "2 different values"},
+			{"my-secret", "DEV_ONLY", "A", "-", "", "missing in prod"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FormatDiff() = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+package secret
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nais/cli/internal/keyvalue"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormat is a file format that secret values can be imported from and exported to.
+type FileFormat string
+
+const (
+	// FileFormatEnv is a .env file with one KEY=VALUE pair per line.
+	FileFormatEnv FileFormat = "env"
+	// FileFormatJSON is a JSON object with a string value per key.
+	FileFormatJSON FileFormat = "json"
+	// FileFormatYAML is a Kubernetes Secret manifest.
+	FileFormatYAML FileFormat = "yaml"
+)
+
+var AllFileFormats = []FileFormat{FileFormatEnv, FileFormatJSON, FileFormatYAML}
+
+// keyPattern is the keys allowed in a Kubernetes Secret.
+var keyPattern = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// DetectFileFormat returns the format of a file from its extension.
+func DetectFileFormat(path string) (FileFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FileFormatJSON, nil
+	case ".yaml", ".yml":
+		return FileFormatYAML, nil
+	case ".env":
+		return FileFormatEnv, nil
+	}
+	if strings.HasPrefix(filepath.Base(path), ".env") {
+		return FileFormatEnv, nil
+	}
+	return "", fmt.Errorf("unable to detect the format of %q, use --format with one of: %v", path, AllFileFormats)
+}
+
+// kubernetesSecret is the part of a Kubernetes Secret manifest that holds the values.
+type kubernetesSecret struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace,omitempty"`
+	} `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+}
+
+// ParseFile parses the entries of a secret from data in the given format. Values that are not valid UTF-8 are returned
+// with BASE64 encoding.
+func ParseFile(data []byte, format FileFormat) ([]Entry, error) {
+	var entries []Entry
+	var err error
+	switch format {
+	case FileFormatEnv:
+		entries, err = parseEnv(data)
+	case FileFormatJSON:
+		entries, err = parseJSON(data)
+	case FileFormatYAML:
+		entries, err = parseKubernetesSecret(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, must be one of: %v", format, AllFileFormats)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if !keyPattern.MatchString(e.Key) {
+			return nil, fmt.Errorf("invalid key %q, keys may only contain letters, digits, '-', '_' and '.'", e.Key)
+		}
+		if seen[e.Key] {
+			return nil, fmt.Errorf("key %q is set more than once", e.Key)
+		}
+		seen[e.Key] = true
+	}
+
+	sortEntries(entries)
+	return entries, nil
+}
+
+func entryKey(e Entry) string {
+	return e.Key
+}
+
+func sortEntries(entries []Entry) {
+	slices.SortFunc(entries, func(a, b Entry) int { return strings.Compare(a.Key, b.Key) })
+}
+
+func parseEnv(data []byte) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		entries = append(entries, Entry{Key: strings.TrimSpace(key), Value: value, Encoding: gql.ValueEncodingPlainText})
+	}
+	return entries, scanner.Err()
+}
+
+// parseEnvValue parses a value in a .env file. Double-quoted values support the escapes \n, \r, \t, \" and \\,
+// single-quoted values are taken literally, and unquoted values end at a comment.
+func parseEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		var b strings.Builder
+		escaped := false
+		for i, r := range value[1:] {
+			switch {
+			case escaped:
+				switch r {
+				case 'n':
+					b.WriteRune('\n')
+				case 'r':
+					b.WriteRune('\r')
+				case 't':
+					b.WriteRune('\t')
+				default:
+					b.WriteRune(r)
+				}
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				if rest := strings.TrimSpace(value[i+2:]); rest != "" && !strings.HasPrefix(rest, "#") {
+					return "", fmt.Errorf("unexpected characters after quoted value")
+				}
+				return b.String(), nil
+			default:
+				b.WriteRune(r)
+			}
+		}
+		return "", fmt.Errorf("unterminated quoted value")
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+		return value[1 : end+1], nil
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+func parseJSON(data []byte) ([]Entry, error) {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(values))
+	for k, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("the value of key %q is not a string", k)
+		}
+		entries = append(entries, Entry{Key: k, Value: s, Encoding: gql.ValueEncodingPlainText})
+	}
+	return entries, nil
+}
+
+func parseKubernetesSecret(data []byte) ([]Entry, error) {
+	var s kubernetesSecret
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	if s.Kind != "Secret" {
+		return nil, fmt.Errorf("expected a Kubernetes Secret, got kind %q", s.Kind)
+	}
+
+	entries := make([]Entry, 0, len(s.Data)+len(s.StringData))
+	for k, v := range s.Data {
+		raw, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the value of key %q: %w", k, err)
+		}
+		if utf8.Valid(raw) {
+			entries = append(entries, Entry{Key: k, Value: string(raw), Encoding: gql.ValueEncodingPlainText})
+		} else {
+			entries = append(entries, Entry{Key: k, Value: v, Encoding: gql.ValueEncodingBase64})
+		}
+	}
+	// Values in stringData take precedence over data, as in Kubernetes.
+	for k, v := range s.StringData {
+		entries = slices.DeleteFunc(entries, func(e Entry) bool { return e.Key == k })
+		entries = append(entries, Entry{Key: k, Value: v, Encoding: gql.ValueEncodingPlainText})
+	}
+	return entries, nil
+}
+
+// FormatFile formats the entries of a secret in the given format. Binary values are written as BASE64 in .env and JSON
+// files, and their keys are returned so that the caller can warn about them.
+func FormatFile(metadata Metadata, entries []Entry, format FileFormat) ([]byte, []string, error) {
+	entries = slices.Clone(entries)
+	sortEntries(entries)
+
+	var binary []string
+	for _, e := range entries {
+		if e.Encoding == gql.ValueEncodingBase64 {
+			binary = append(binary, e.Key)
+		}
+	}
+
+	switch format {
+	case FileFormatEnv:
+		var b strings.Builder
+		for _, e := range entries {
+			fmt.Fprintf(&b, "%s=%s\n", e.Key, quoteEnvValue(e.Value))
+		}
+		return []byte(b.String()), binary, nil
+	case FileFormatJSON:
+		values := make(map[string]string, len(entries))
+		for _, e := range entries {
+			values[e.Key] = e.Value
+		}
+		b, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(b, '\n'), binary, nil
+	case FileFormatYAML:
+		s := kubernetesSecret{APIVersion: "v1", Kind: "Secret", Type: "Opaque", Data: map[string]string{}}
+		s.Metadata.Name = metadata.Name
+		s.Metadata.Namespace = metadata.TeamSlug
+		for _, e := range entries {
+			if e.Encoding == gql.ValueEncodingBase64 {
+				s.Data[e.Key] = e.Value
+			} else {
+				s.Data[e.Key] = base64.StdEncoding.EncodeToString([]byte(e.Value))
+			}
+		}
+		b, err := yaml.Marshal(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Binary values are lossless in a Kubernetes Secret.
+		return b, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported format %q, must be one of: %v", format, AllFileFormats)
+	}
+}
+
+// quoteEnvValue double-quotes a value for a .env file, escaping what parseEnvValue unescapes.
+func quoteEnvValue(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// ImportPlan is the changes needed to import entries into a secret, either from a file or from the same secret in
+// another environment.
+type ImportPlan = keyvalue.Plan[Entry]
+
+// PlanImport returns the changes needed to import entries into a secret with the given keys. Keys that are not imported
+// are removed when replace is true, and kept otherwise.
+func PlanImport(existingKeys []string, entries []Entry, replace bool) ImportPlan {
+	var plan ImportPlan
+	imported := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		imported[e.Key] = true
+		if slices.Contains(existingKeys, e.Key) {
+			plan.Update = append(plan.Update, e)
+		} else {
+			plan.Add = append(plan.Add, e)
+		}
+	}
+
+	if replace {
+		for _, k := range existingKeys {
+			if !imported[k] {
+				plan.Remove = append(plan.Remove, k)
+			}
+		}
+		slices.Sort(plan.Remove)
+	}
+	return plan
+}
+
+// ApplyImport applies the changes of an import plan to a secret, one key at a time.
+func ApplyImport(ctx context.Context, metadata Metadata, plan ImportPlan) error {
+	for _, e := range plan.Add {
+		if err := addValue(ctx, metadata, e.Key, e.Value, e.Encoding); err != nil {
+			return fmt.Errorf("adding key %q: %w", e.Key, err)
+		}
+	}
+	for _, e := range plan.Update {
+		if err := updateValue(ctx, metadata, e.Key, e.Value, e.Encoding); err != nil {
+			return fmt.Errorf("updating key %q: %w", e.Key, err)
+		}
+	}
+	for _, k := range plan.Remove {
+		if err := RemoveValue(ctx, metadata, k); err != nil {
+			return fmt.Errorf("removing key %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// MaskedSize describes the size of the value of an entry without revealing it.
+func MaskedSize(e Entry) string {
+	size := len(e.Value)
+	if e.Encoding == gql.ValueEncodingBase64 {
+		if raw, err := base64.StdEncoding.DecodeString(e.Value); err == nil {
+			return fmt.Sprintf("<binary, %d bytes>", len(raw))
+		}
+		return "<binary>"
+	}
+	return fmt.Sprintf("<%d bytes>", size)
+}
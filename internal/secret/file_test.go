@@ -0,0 +1,207 @@
+package secret
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00})
+
+	tests := []struct {
+		name    string
+		format  FileFormat
+		data    string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name:   "env",
+			format: FileFormatEnv,
+			data: `# comment
+export B="line1\nline2 \"quoted\"" # trailing
+A=plain value # comment
+C='literal \n'
+D=
+`,
+			want: []Entry{
+				{Key: "A", Value: "plain value", Encoding: gql.ValueEncodingPlainText},
+				{Key: "B", Value: "line1\nline2 \"quoted\"", Encoding: gql.ValueEncodingPlainText},
+				{Key: "C", Value: `literal \n`, Encoding: gql.ValueEncodingPlainText},
+				{Key: "D", Value: "", Encoding: gql.ValueEncodingPlainText},
+			},
+		},
+		{
+			name:    "env without value",
+			format:  FileFormatEnv,
+			data:    "A\n",
+			wantErr: true,
+		},
+		{
+			name:    "env with unterminated quote",
+			format:  FileFormatEnv,
+			data:    `A="value`,
+			wantErr: true,
+		},
+		{
+			name:    "env with duplicate key",
+			format:  FileFormatEnv,
+			data:    "A=1\nA=2\n",
+			wantErr: true,
+		},
+		{
+			name:   "json",
+			format: FileFormatJSON,
+			data:   `{"b": "2", "a": "1"}`,
+			want: []Entry{
+				{Key: "a", Value: "1", Encoding: gql.ValueEncodingPlainText},
+				{Key: "b", Value: "2", Encoding: gql.ValueEncodingPlainText},
+			},
+		},
+		{
+			name:    "json with non-string value",
+			format:  FileFormatJSON,
+			data:    `{"a": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "json with invalid key",
+			format:  FileFormatJSON,
+			data:    `{"a b": "1"}`,
+			wantErr: true,
+		},
+		{
+			name:   "kubernetes secret",
+			format: FileFormatYAML,
+			data: `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-secret
+data:
+  text: ` + base64.StdEncoding.EncodeToString([]byte("hello")) + `
+  keystore.p12: ` + binary + `
+  overridden: ` + base64.StdEncoding.EncodeToString([]byte("old")) + `
+stringData:
+  overridden: new
+`,
+			want: []Entry{
+				{Key: "keystore.p12", Value: binary, Encoding: gql.ValueEncodingBase64},
+				{Key: "overridden", Value: "new", Encoding: gql.ValueEncodingPlainText},
+				{Key: "text", Value: "hello", Encoding: gql.ValueEncodingPlainText},
+			},
+		},
+		{
+			name:    "other kubernetes resource",
+			format:  FileFormatYAML,
+			data:    "apiVersion: v1\nkind: ConfigMap\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseFile([]byte(tt.data), tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseFile() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFile() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFileRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	metadata := Metadata{TeamSlug: "my-team", EnvironmentName: "dev", Name: "my-secret"}
+	binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00})
+	entries := []Entry{
+		{Key: "TEXT", Value: "multi\nline \"quoted\" \\ $HOME", Encoding: gql.ValueEncodingPlainText},
+		{Key: "EMPTY", Value: "", Encoding: gql.ValueEncodingPlainText},
+		{Key: "keystore.p12", Value: binary, Encoding: gql.ValueEncodingBase64},
+	}
+
+	for _, format := range AllFileFormats {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			data, binaryKeys, err := FormatFile(metadata, entries, format)
+			if err != nil {
+				t.Fatalf("FormatFile() error = %v", err)
+			}
+
+			got, err := ParseFile(data, format)
+			if err != nil {
+				t.Fatalf("ParseFile() error = %v\n%s", err, data)
+			}
+
+			want := []Entry{entries[1], entries[0], entries[2]}
+			if format == FileFormatYAML {
+				if len(binaryKeys) != 0 {
+					t.Errorf("FormatFile() binary keys = %v, want none", binaryKeys)
+				}
+			} else {
+				if !reflect.DeepEqual(binaryKeys, []string{"keystore.p12"}) {
+					t.Errorf("FormatFile() binary keys = %v, want [keystore.p12]", binaryKeys)
+				}
+				// Binary values are written as BASE64 text, and read back as such.
+				want[2].Encoding = gql.ValueEncodingPlainText
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry{{Key: "a"}, {Key: "b"}}
+	existing := []string{"b", "d", "c"}
+
+	merge := PlanImport(existing, entries, false)
+	if !reflect.DeepEqual(merge, ImportPlan{Add: []Entry{{Key: "a"}}, Update: []Entry{{Key: "b"}}}) {
+		t.Errorf("PlanImport() merge = %#v", merge)
+	}
+
+	replace := PlanImport(existing, entries, true)
+	if !reflect.DeepEqual(replace.Remove, []string{"c", "d"}) {
+		t.Errorf("PlanImport() replace removes %v, want [c d]", replace.Remove)
+	}
+}
+
+func TestDetectFileFormat(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]FileFormat{
+		".env":            FileFormatEnv,
+		".env.local":      FileFormatEnv,
+		"prod.env":        FileFormatEnv,
+		"secret.json":     FileFormatJSON,
+		"k8s/secret.yaml": FileFormatYAML,
+		"secret.YML":      FileFormatYAML,
+	} {
+		if got, err := DetectFileFormat(path); err != nil || got != want {
+			t.Errorf("DetectFileFormat(%q) = %q, %v, want %q", path, got, err, want)
+		}
+	}
+
+	if _, err := DetectFileFormat("secret.txt"); err == nil {
+		t.Errorf("DetectFileFormat(secret.txt) error = nil, want error")
+	}
+}
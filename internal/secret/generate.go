@@ -0,0 +1,99 @@
+package secret
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// passwordAlphabet is the characters of generated passwords. Symbols are left out so that passwords can be used in
+// URLs and connection strings without escaping.
+const passwordAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// GeneratorKinds are the kinds of values Generate can generate, with the default size if the kind has one.
+var GeneratorKinds = []string{"password:32", "uuid", "hex:64", "rsa:2048"}
+
+// Generate generates a random value from a spec of the form KIND[:SIZE]:
+//
+//   - password:N is N letters and digits, 32 by default.
+//   - uuid is a random UUID.
+//   - hex:N is N hex digits, 64 by default.
+//   - rsa:N is a PEM-encoded PKCS #8 RSA private key of N bits, 2048 by default.
+func Generate(spec string) (string, error) {
+	kind, sizeStr, hasSize := strings.Cut(spec, ":")
+	size := 0
+	if hasSize {
+		var err error
+		if size, err = strconv.Atoi(sizeStr); err != nil || size <= 0 {
+			return "", fmt.Errorf("invalid size %q in %q, must be a positive number", sizeStr, spec)
+		}
+	}
+
+	switch kind {
+	case "password":
+		return generatePassword(sizeOrDefault(size, 32))
+	case "uuid":
+		if hasSize {
+			return "", fmt.Errorf("uuid does not take a size")
+		}
+		return uuid.NewString(), nil
+	case "hex":
+		size = sizeOrDefault(size, 64)
+		if size%2 != 0 {
+			return "", fmt.Errorf("hex size must be an even number of digits, got %d", size)
+		}
+		b := make([]byte, size/2)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(b), nil
+	case "rsa":
+		size = sizeOrDefault(size, 2048)
+		if size < 2048 {
+			return "", fmt.Errorf("rsa keys must be at least 2048 bits, got %d", size)
+		}
+		return generateRSAKey(size)
+	default:
+		return "", fmt.Errorf("unknown generator %q, must be one of: %s", kind, strings.Join(GeneratorKinds, ", "))
+	}
+}
+
+func sizeOrDefault(size, def int) int {
+	if size == 0 {
+		return def
+	}
+	return size
+}
+
+func generatePassword(length int) (string, error) {
+	size := big.NewInt(int64(len(passwordAlphabet)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func generateRSAKey(bits int) (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return "", fmt.Errorf("generating RSA key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("encoding RSA key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
@@ -0,0 +1,76 @@
+package secret
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("password", func(t *testing.T) {
+		t.Parallel()
+		for spec, length := range map[string]int{"password": 32, "password:12": 12} {
+			v, err := Generate(spec)
+			if err != nil {
+				t.Fatalf("Generate(%q) error = %v", spec, err)
+			}
+			if len(v) != length || strings.Trim(v, passwordAlphabet) != "" {
+				t.Errorf("Generate(%q) = %q, want %d characters from the alphabet", spec, v, length)
+			}
+		}
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		t.Parallel()
+		v, err := Generate("uuid")
+		if err != nil {
+			t.Fatalf("Generate(uuid) error = %v", err)
+		}
+		if _, err := uuid.Parse(v); err != nil {
+			t.Errorf("Generate(uuid) = %q, not a UUID: %v", v, err)
+		}
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		t.Parallel()
+		v, err := Generate("hex:16")
+		if err != nil {
+			t.Fatalf("Generate(hex:16) error = %v", err)
+		}
+		if _, err := hex.DecodeString(v); err != nil || len(v) != 16 {
+			t.Errorf("Generate(hex:16) = %q, want 16 hex digits", v)
+		}
+	})
+
+	t.Run("rsa", func(t *testing.T) {
+		t.Parallel()
+		v, err := Generate("rsa:2048")
+		if err != nil {
+			t.Fatalf("Generate(rsa:2048) error = %v", err)
+		}
+		block, _ := pem.Decode([]byte(v))
+		if block == nil {
+			t.Fatalf("Generate(rsa:2048) = %q, not PEM", v)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			t.Fatalf("parsing key: %v", err)
+		}
+		if k, ok := key.(*rsa.PrivateKey); !ok || k.N.BitLen() != 2048 {
+			t.Errorf("Generate(rsa:2048) is not a 2048 bit RSA key")
+		}
+	})
+
+	for _, spec := range []string{"hex:15", "rsa:1024", "uuid:4", "password:0", "password:x", "base64:32"} {
+		if _, err := Generate(spec); err == nil {
+			t.Errorf("Generate(%q) error = nil, want error", spec)
+		}
+	}
+}
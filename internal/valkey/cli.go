@@ -0,0 +1,220 @@
+package valkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/naistrix"
+	"golang.org/x/term"
+)
+
+const commandTimeout = 30 * time.Second
+
+// forceRequired are commands that delete or stop everything, and are only sent when forced.
+var forceRequired = []string{"FLUSHALL", "FLUSHDB", "SHUTDOWN", "SWAPDB", "DEBUG"}
+
+// scripting are commands that run scripts and functions, which can call any command, including the ones in
+// forceRequired, and are therefore also only sent when forced.
+var scripting = []string{"EVAL", "EVAL_RO", "EVALSHA", "EVALSHA_RO", "FCALL", "FCALL_RO", "FUNCTION", "SCRIPT"}
+
+// unsupported are commands that switch the connection into a mode where the server pushes replies, which the client
+// does not handle.
+var unsupported = []string{"MONITOR", "SUBSCRIBE", "PSUBSCRIBE", "SSUBSCRIBE", "SYNC", "PSYNC", "RESET"}
+
+// CommandPolicy decides which commands may be sent to the server.
+type CommandPolicy struct {
+	// Allow are the only commands that may be sent, if any are given.
+	Allow []string
+	// Deny are commands that may not be sent.
+	Deny []string
+	// Force allows the commands that delete all data.
+	Force bool
+}
+
+// Check returns an error if the command may not be sent.
+func (p CommandPolicy) Check(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given")
+	}
+
+	name := strings.ToUpper(args[0])
+	contains := func(list []string) bool {
+		return slices.ContainsFunc(list, func(s string) bool { return strings.EqualFold(s, name) })
+	}
+
+	switch {
+	case contains(unsupported):
+		return fmt.Errorf("%s is not supported by nais valkey cli", name)
+	case contains(p.Deny):
+		return fmt.Errorf("%s is denied", name)
+	case len(p.Allow) > 0 && !contains(p.Allow):
+		return fmt.Errorf("%s is not in the list of allowed commands", name)
+	case contains(forceRequired) && !p.Force:
+		return fmt.Errorf("%s deletes or stops everything on the instance, use --force to run it", name)
+	case contains(scripting) && !p.Force:
+		return fmt.Errorf("%s runs scripts that can delete or stop everything on the instance, use --force to run it", name)
+	}
+	return nil
+}
+
+// RunCLI creates temporary credentials for a Valkey instance and connects to it. The command is run if given, or else
+// commands are read from an interactive prompt until the user quits.
+func RunCLI(ctx context.Context, metadata Metadata, permission gql.CredentialPermission, ttl string, policy CommandPolicy, command string, out *naistrix.OutputWriter) error {
+	creds, err := CreateCredentials(ctx, metadata.TeamSlug, metadata.EnvironmentName, metadata.Name, permission, ttl)
+	if err != nil {
+		return fmt.Errorf("creating Valkey credentials: %w", err)
+	}
+
+	c, err := dial(ctx, creds.Host, creds.Port, creds.Username, creds.Password, commandTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.Close() }()
+
+	if command != "" {
+		args, err := SplitCommandLine(command)
+		if err != nil {
+			return err
+		}
+		return runCommand(c, policy, args, out)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) { // #nosec G115
+		return fmt.Errorf("no command given and stdin is not a terminal, use --command")
+	}
+	return runREPL(c, metadata.Name, permission, policy, out)
+}
+
+func runCommand(c *conn, policy CommandPolicy, args []string, out *naistrix.OutputWriter) error {
+	if err := policy.Check(args); err != nil {
+		return err
+	}
+
+	reply, err := c.do(args...)
+	if err != nil {
+		return err
+	}
+
+	out.Println(FormatReply(reply))
+	if reply.Kind == ReplyError {
+		return fmt.Errorf("%s failed", strings.ToUpper(args[0]))
+	}
+	return nil
+}
+
+func runREPL(c *conn, name string, permission gql.CredentialPermission, policy CommandPolicy, out *naistrix.OutputWriter) error {
+	fd := int(os.Stdin.Fd()) // #nosec G115
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("setting terminal to raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, name+"> ")
+	if w, h, err := term.GetSize(fd); err == nil {
+		_ = t.SetSize(w, h)
+	}
+
+	// Output is written through the terminal, which translates newlines while in raw mode.
+	termOut := naistrix.NewOutputWriter(t, new(naistrix.Count))
+	termOut.Printf("Connected to %s with %s permission. Type quit to exit.\n", name, permission)
+
+	for {
+		line, err := t.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		args, err := SplitCommandLine(line)
+		if err != nil {
+			termOut.Errorf("%v\n", err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if cmd := strings.ToLower(args[0]); cmd == "quit" || cmd == "exit" {
+			return nil
+		}
+
+		if err := policy.Check(args); err != nil {
+			termOut.Errorf("%v\n", err)
+			continue
+		}
+
+		reply, err := c.do(args...)
+		if err != nil {
+			// The connection cannot be used after a failed read or write.
+			return err
+		}
+		termOut.Println(FormatReply(reply))
+	}
+}
+
+// SplitCommandLine splits a line into arguments on whitespace. Arguments can be quoted with single or double quotes,
+// and double-quoted arguments support the escapes \n, \r, \t, \" and \\.
+func SplitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			switch r {
+			case 'n':
+				current.WriteRune('\n')
+			case 'r':
+				current.WriteRune('\r')
+			case 't':
+				current.WriteRune('\t')
+			default:
+				current.WriteRune(r)
+			}
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unbalanced quotes")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/aiven"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/cli/internal/valkey"
+	"github.com/nais/cli/internal/valkey/command/flag"
+	"github.com/nais/naistrix"
+)
+
+func cli(parentFlags *flag.Valkey) *naistrix.Command {
+	flags := &flag.CLI{
+		Valkey:     parentFlags,
+		Permission: flag.Permission(gql.CredentialPermissionRead),
+		TTL:        "1h",
+	}
+	return &naistrix.Command{
+		Name:  "cli",
+		Title: "Connect to a Valkey instance.",
+		Description: heredoc.Doc(`
+			Creates temporary credentials for a Valkey instance and connects to it over TLS, with an interactive prompt similar to valkey-cli.
+
+			The credentials have READ permission unless another permission is given. Commands can be limited with --allow and --deny, and commands that delete all data, such as FLUSHALL, are refused unless --force is given. So are scripts and functions (EVAL, FCALL, FUNCTION and similar), as they can run any command.
+		`),
+		Flags: flags,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			validateArgs,
+			func(context.Context, *naistrix.Arguments) error {
+				if !aiven.IsValidPermission(gql.CredentialPermission(flags.Permission)) {
+					return fmt.Errorf("invalid permission %q, must be one of: %v", flags.Permission, gql.AllCredentialPermission)
+				}
+				return nil
+			},
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() != 0 {
+				return nil, ""
+			}
+			return autoCompleteValkeyNames(ctx, flags.Team, string(flags.Environment), true)
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "Start an interactive prompt for a Valkey instance named my-valkey in environment dev.",
+				Command:     "my-valkey --environment dev",
+			},
+			{
+				Description: "Get a single key.",
+				Command:     "my-valkey --environment dev --command 'GET session:1234'",
+			},
+			{
+				Description: "Only allow looking up keys and their time to live.",
+				Command:     "my-valkey --environment dev --allow GET --allow TTL --allow SCAN",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			policy := valkey.CommandPolicy{
+				Allow: flags.Allow,
+				Deny:  flags.Deny,
+				Force: flags.Force,
+			}
+			return valkey.RunCLI(
+				ctx,
+				metadataFromArgs(args, flags.Team, string(flags.Environment)),
+				gql.CredentialPermission(flags.Permission),
+				flags.TTL,
+				policy,
+				flags.Command,
+				out,
+			)
+		},
+	}
+}
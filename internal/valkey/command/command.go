@@ -23,11 +23,13 @@ func Valkey(parentFlags *flags.GlobalFlags) *naistrix.Command {
 		StickyFlags:  f,
 		ValidateFunc: validation.RequireTeam(f),
 		SubCommands: []*naistrix.Command{
+			cli(f),
 			create(f),
 			credentials(f),
 			delete(f),
 			get(f),
 			list(f),
+			maintenance(f),
 			updateValkey(f),
 		},
 	}
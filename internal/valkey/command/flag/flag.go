@@ -161,3 +161,19 @@ func (p *Permission) AutoComplete(context.Context, *naistrix.Arguments, string,
 	}
 	return perms, "Available permission levels."
 }
+
+type CLI struct {
+	*Valkey
+	Permission Permission `name:"permission" short:"p" usage:"Permission level for the credentials (READ, WRITE, READWRITE, ADMIN)."`
+	TTL        string     `name:"ttl" usage:"Time-to-live for the temporary credentials used to connect (e.g. '1h', '1d'). Maximum 30 days."`
+	Command    string     `name:"command" short:"c" usage:"Run |COMMAND| and exit instead of starting an interactive prompt."`
+	Allow      []string   `name:"allow" usage:"Only allow |COMMAND|. Can be repeated."`
+	Deny       []string   `name:"deny" usage:"Deny |COMMAND|. Can be repeated."`
+	Force      bool       `name:"force" usage:"Allow commands that delete all data, such as FLUSHALL and FLUSHDB, and scripts such as EVAL."`
+}
+
+type Maintenance struct {
+	*Valkey
+	Start bool `name:"start" usage:"Start the pending maintenance updates now."`
+	Yes   bool `name:"yes" short:"y" usage:"Automatic yes to prompts; assume 'yes' as answer to all prompts and run non-interactively."`
+}
@@ -16,7 +16,7 @@ func get(parentFlags *flag.Valkey) *naistrix.Command {
 	return &naistrix.Command{
 		Name:        "get",
 		Title:       "Get a Valkey instance.",
-		Description: "This command describes a Valkey instance, listing its current configuration and access list.",
+		Description: "This command describes a Valkey instance, listing its current configuration, access list and pending maintenance.",
 		Flags:       flags,
 		Args:        defaultArgs,
 		ValidateFunc: naistrix.ValidateFuncs(
@@ -49,7 +49,12 @@ func get(parentFlags *flag.Valkey) *naistrix.Command {
 			}
 
 			out.Println("Valkey access list")
-			return out.Table(output.TableWithTopMargin()).Render(valkey.FormatAccessList(metadata, existing))
+			if err := out.Table(output.TableWithTopMargin()).Render(valkey.FormatAccessList(metadata, existing)); err != nil {
+				return fmt.Errorf("rendering table: %w", err)
+			}
+
+			out.Println()
+			return renderMaintenance(out, &existing.Maintenance.ValkeyMaintenanceFields)
 		},
 	}
 }
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/nais/cli/internal/naisapi/gql"
+	"github.com/nais/cli/internal/validation"
+	"github.com/nais/cli/internal/valkey"
+	"github.com/nais/cli/internal/valkey/command/flag"
+	"github.com/nais/naistrix"
+	"github.com/nais/naistrix/input"
+	"github.com/nais/naistrix/output"
+)
+
+func maintenance(parentFlags *flag.Valkey) *naistrix.Command {
+	flags := &flag.Maintenance{Valkey: parentFlags}
+	return &naistrix.Command{
+		Name:  "maintenance",
+		Title: "Show and start pending maintenance for a Valkey instance.",
+		Description: heredoc.Doc(`
+			Lists the pending maintenance updates for a Valkey instance, with their deadlines and the weekly maintenance window.
+
+			Updates are applied in the maintenance window, or at their deadline if they are mandatory. Maintenance may restart the instance, so use --start to apply the updates at a time that suits you instead.
+		`),
+		Flags: flags,
+		Args:  defaultArgs,
+		ValidateFunc: naistrix.ValidateFuncs(
+			validation.RequireEnvironment(flags),
+			validateArgs,
+		),
+		AutoCompleteFunc: func(ctx context.Context, args *naistrix.Arguments, _ string) ([]string, string) {
+			if args.Len() == 0 {
+				return autoCompleteValkeyNames(ctx, flags.Team, string(flags.Environment), true)
+			}
+			return nil, ""
+		},
+		Examples: []naistrix.Example{
+			{
+				Description: "List pending maintenance for a Valkey instance named some-valkey in environment dev.",
+				Command:     "some-valkey --environment dev",
+			},
+			{
+				Description: "Start the pending maintenance now.",
+				Command:     "some-valkey --environment dev --start",
+			},
+		},
+		RunFunc: func(ctx context.Context, args *naistrix.Arguments, out *naistrix.OutputWriter) error {
+			metadata := metadataFromArgs(args, flags.Team, string(flags.Environment))
+
+			m, err := valkey.GetMaintenance(ctx, metadata)
+			if err != nil {
+				return fmt.Errorf("fetching maintenance for Valkey instance: %w", err)
+			}
+
+			if err := renderMaintenance(out, m); err != nil {
+				return err
+			}
+			if len(m.Updates.Nodes) == 0 {
+				return nil
+			}
+
+			if !flags.Start {
+				out.Infof("Run with --start to apply the updates now.\n")
+				return nil
+			}
+
+			out.Warnln("Maintenance may restart the Valkey instance, and it may be unavailable for a short while.")
+			if !flags.Yes {
+				if result, err := input.Confirm("Are you sure you want to continue?"); err != nil {
+					return err
+				} else if !result {
+					return fmt.Errorf("cancelled by user")
+				}
+			}
+
+			if err := valkey.StartMaintenance(ctx, metadata); err != nil {
+				return fmt.Errorf("starting maintenance: %w", err)
+			}
+
+			out.Successf("Started maintenance of Valkey instance %q in %q\n", metadata.Name, metadata.EnvironmentName)
+			return nil
+		},
+	}
+}
+
+func renderMaintenance(out *naistrix.OutputWriter, m *gql.ValkeyMaintenanceFields) error {
+	if window := valkey.FormatMaintenanceWindow(m); window != "" {
+		out.Printf("Maintenance window: %s\n", window)
+	}
+
+	if len(m.Updates.Nodes) == 0 {
+		out.Println("No pending maintenance updates.")
+		return nil
+	}
+
+	out.Println("Pending maintenance updates")
+	return out.Table(output.TableWithTopMargin()).Render(valkey.FormatMaintenanceUpdates(m))
+}
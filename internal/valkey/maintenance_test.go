@@ -0,0 +1,36 @@
+package valkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nais/cli/internal/naisapi/gql"
+)
+
+func TestFormatMaintenance(t *testing.T) {
+	m := &gql.ValkeyMaintenanceFields{}
+	if got := FormatMaintenanceWindow(m); got != "" {
+		t.Errorf("FormatMaintenanceWindow() = %q, want empty", got)
+	}
+
+	m.Window.DayOfWeek = gql.WeekdayMonday
+	m.Window.TimeOfDay = "04:00:00"
+	if got, want := FormatMaintenanceWindow(m), "MONDAY at 04:00:00"; got != want {
+		t.Errorf("FormatMaintenanceWindow() = %q, want %q", got, want)
+	}
+
+	deadline := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.Updates.Nodes = append(m.Updates.Nodes, gql.ValkeyMaintenanceFieldsUpdatesValkeyMaintenanceUpdateConnectionNodesValkeyMaintenanceUpdate{
+		Title:       "Valkey 8.1",
+		Description: "Upgrade to Valkey 8.1",
+		Deadline:    deadline,
+	})
+
+	rows := FormatMaintenanceUpdates(m)
+	if len(rows) != 2 {
+		t.Fatalf("expected header and one update, got %v", rows)
+	}
+	if got := rows[1]; got[0] != "Valkey 8.1" || got[1] != deadline.Local().Format(time.DateTime) || got[2] != "-" {
+		t.Errorf("unexpected row: %v", got)
+	}
+}
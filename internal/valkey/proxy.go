@@ -0,0 +1,74 @@
+package valkey
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// StartProxy listens on a random local port and forwards plain TCP connections to a Valkey server over TLS, so that
+// local clients can connect without TLS. It returns the local port, and runs until ctx is cancelled.
+func StartProxy(ctx context.Context, host string, port int) (int, error) {
+	var lc net.ListenConfig
+	l, err := lc.Listen(ctx, "tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("starting proxy: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config:    &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12},
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+
+	go func() {
+		for {
+			local, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = local.Close() }()
+
+				remote, err := dialer.DialContext(ctx, "tcp", address)
+				if err != nil {
+					return
+				}
+				defer func() { _ = remote.Close() }()
+				pipe(local, remote)
+			}()
+		}
+	}()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// pipe copies data in both directions until one side closes.
+func pipe(a, b net.Conn) {
+	var once sync.Once
+	done := make(chan struct{})
+	closeBoth := func() {
+		_ = a.Close()
+		_ = b.Close()
+		close(done)
+	}
+	go func() {
+		_, _ = io.Copy(a, b)
+		once.Do(closeBoth)
+	}()
+	go func() {
+		_, _ = io.Copy(b, a)
+		once.Do(closeBoth)
+	}()
+	<-done
+}
@@ -0,0 +1,182 @@
+package valkey
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ReplyKind int
+
+const (
+	ReplyStatus ReplyKind = iota
+	ReplyError
+	ReplyInteger
+	ReplyBulk
+	ReplyNil
+	ReplyArray
+)
+
+// Reply is a reply from the server in the RESP2 protocol.
+type Reply struct {
+	Kind    ReplyKind
+	Str     string
+	Integer int64
+	Array   []Reply
+}
+
+// conn is a connection to a Valkey server that sends one command at a time and waits for its reply.
+type conn struct {
+	nc      net.Conn
+	r       *bufio.Reader
+	timeout time.Duration
+}
+
+// dial connects to the server over TLS and authenticates.
+func dial(ctx context.Context, host string, port int, username, password string, timeout time.Duration) (*conn, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config:    &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12},
+	}
+	nc, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s:%d: %w", host, port, err)
+	}
+
+	c := &conn{nc: nc, r: bufio.NewReader(nc), timeout: timeout}
+	reply, err := c.do("AUTH", username, password)
+	if err != nil {
+		_ = nc.Close()
+		return nil, err
+	}
+	if reply.Kind == ReplyError {
+		_ = nc.Close()
+		return nil, fmt.Errorf("authenticating: %s", reply.Str)
+	}
+	return c, nil
+}
+
+func (c *conn) Close() error {
+	return c.nc.Close()
+}
+
+// do sends a command and reads its reply. Error replies from the server are returned as replies, not errors.
+func (c *conn) do(args ...string) (Reply, error) {
+	if c.timeout > 0 {
+		_ = c.nc.SetDeadline(time.Now().Add(c.timeout))
+	}
+	if _, err := c.nc.Write(encodeCommand(args)); err != nil {
+		return Reply{}, err
+	}
+	return readReply(c.r)
+}
+
+// encodeCommand encodes a command as an array of bulk strings.
+func encodeCommand(args []string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(b.String())
+}
+
+func readReply(r *bufio.Reader) (Reply, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return Reply{}, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return Reply{}, fmt.Errorf("invalid reply: empty line")
+	}
+
+	prefix, rest := line[0], line[1:]
+	switch prefix {
+	case '+':
+		return Reply{Kind: ReplyStatus, Str: rest}, nil
+	case '-':
+		return Reply{Kind: ReplyError, Str: rest}, nil
+	case ':':
+		n, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid integer reply %q", rest)
+		}
+		return Reply{Kind: ReplyInteger, Integer: n}, nil
+	case '$':
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid bulk string length %q", rest)
+		}
+		if n < 0 {
+			return Reply{Kind: ReplyNil}, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return Reply{}, err
+		}
+		return Reply{Kind: ReplyBulk, Str: string(buf[:n])}, nil
+	case '*':
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid array length %q", rest)
+		}
+		if n < 0 {
+			return Reply{Kind: ReplyNil}, nil
+		}
+		reply := Reply{Kind: ReplyArray, Array: make([]Reply, 0, n)}
+		for range n {
+			elem, err := readReply(r)
+			if err != nil {
+				return Reply{}, err
+			}
+			reply.Array = append(reply.Array, elem)
+		}
+		return reply, nil
+	default:
+		return Reply{}, fmt.Errorf("unsupported reply type %q", prefix)
+	}
+}
+
+// FormatReply formats a reply the way valkey-cli does.
+func FormatReply(reply Reply) string {
+	return formatReply(reply, "")
+}
+
+func formatReply(reply Reply, indent string) string {
+	switch reply.Kind {
+	case ReplyStatus:
+		return reply.Str
+	case ReplyError:
+		return "(error) " + reply.Str
+	case ReplyInteger:
+		return fmt.Sprintf("(integer) %d", reply.Integer)
+	case ReplyNil:
+		return "(nil)"
+	case ReplyBulk:
+		return strconv.Quote(reply.Str)
+	case ReplyArray:
+		if len(reply.Array) == 0 {
+			return "(empty array)"
+		}
+		width := len(strconv.Itoa(len(reply.Array)))
+		lines := make([]string, 0, len(reply.Array))
+		for i, elem := range reply.Array {
+			prefix := fmt.Sprintf("%*d) ", width, i+1)
+			line := prefix + formatReply(elem, indent+strings.Repeat(" ", len(prefix)))
+			if i > 0 {
+				line = indent + line
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,105 @@
+package valkey
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEncodeCommand(t *testing.T) {
+	got := string(encodeCommand([]string{"SET", "key", "a value"}))
+	want := "*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$7\r\na value\r\n"
+	if got != want {
+		t.Errorf("encodeCommand() = %q, want %q", got, want)
+	}
+}
+
+func TestReadReplyAndFormat(t *testing.T) {
+	tests := map[string]string{
+		"+OK\r\n":                             "OK",
+		"-ERR unknown command\r\n":            "(error) ERR unknown command",
+		":42\r\n":                             "(integer) 42",
+		"$5\r\nhello\r\n":                     `"hello"`,
+		"$-1\r\n":                             "(nil)",
+		"*0\r\n":                              "(empty array)",
+		"*2\r\n$1\r\na\r\n*2\r\n:1\r\n:2\r\n": "1) \"a\"\n2) 1) (integer) 1\n   2) (integer) 2",
+	}
+
+	for input, want := range tests {
+		reply, err := readReply(bufio.NewReader(strings.NewReader(input)))
+		if err != nil {
+			t.Errorf("readReply(%q) failed: %v", input, err)
+			continue
+		}
+		if got := FormatReply(reply); got != want {
+			t.Errorf("FormatReply(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if _, err := readReply(bufio.NewReader(strings.NewReader("?what\r\n"))); err == nil {
+		t.Error("expected error for unsupported reply type")
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := map[string][]string{
+		"GET key":                  {"GET", "key"},
+		"  SET  key   value ":      {"SET", "key", "value"},
+		`SET key "hello world"`:    {"SET", "key", "hello world"},
+		`SET key 'it''s'`:          {"SET", "key", "its"},
+		`SET key "line\nbreak \""`: {"SET", "key", "line\nbreak \""},
+		`SET key ""`:               {"SET", "key", ""},
+		"":                         nil,
+	}
+
+	for input, want := range tests {
+		got, err := SplitCommandLine(input)
+		if err != nil {
+			t.Errorf("SplitCommandLine(%q) failed: %v", input, err)
+			continue
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("SplitCommandLine(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if _, err := SplitCommandLine(`GET "key`); err == nil {
+		t.Error("expected error for unbalanced quotes")
+	}
+}
+
+func TestCommandPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CommandPolicy
+		args    []string
+		allowed bool
+	}{
+		{name: "default allows get", args: []string{"get", "key"}, allowed: true},
+		{name: "flushall requires force", args: []string{"flushall"}, allowed: false},
+		{name: "flushall with force", policy: CommandPolicy{Force: true}, args: []string{"FLUSHALL"}, allowed: true},
+		{name: "eval requires force", args: []string{"EVAL", "return redis.call('FLUSHALL')", "0"}, allowed: false},
+		{name: "evalsha requires force", args: []string{"evalsha", "abc", "0"}, allowed: false},
+		{name: "fcall requires force", args: []string{"FCALL", "flush", "0"}, allowed: false},
+		{name: "function requires force", args: []string{"FUNCTION", "LOAD", "code"}, allowed: false},
+		{name: "eval with force", policy: CommandPolicy{Force: true}, args: []string{"EVAL", "return 1", "0"}, allowed: true},
+		{name: "monitor is unsupported", policy: CommandPolicy{Force: true}, args: []string{"MONITOR"}, allowed: false},
+		{name: "denied", policy: CommandPolicy{Deny: []string{"keys"}}, args: []string{"KEYS", "*"}, allowed: false},
+		{name: "not in allow list", policy: CommandPolicy{Allow: []string{"GET"}}, args: []string{"SET", "k", "v"}, allowed: false},
+		{name: "in allow list", policy: CommandPolicy{Allow: []string{"GET"}}, args: []string{"get", "k"}, allowed: true},
+		{name: "allow list does not override force", policy: CommandPolicy{Allow: []string{"FLUSHDB"}}, args: []string{"FLUSHDB"}, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.args)
+			if tt.allowed && err != nil {
+				t.Errorf("expected %v to be allowed, got %v", tt.args, err)
+			}
+			if !tt.allowed && err == nil {
+				t.Errorf("expected %v to be refused", tt.args)
+			}
+		})
+	}
+}
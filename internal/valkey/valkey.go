@@ -103,6 +103,9 @@ func Get(ctx context.Context, metadata Metadata) (*gql.GetValkeyTeamEnvironmentV
 				tier
 				maxMemoryPolicy
 				state
+				maintenance {
+				  ...ValkeyMaintenanceFields
+				}
 				access(first: 1000, orderBy: {direction: ASC, field: ACCESS}) {
 				  edges {
 					node {